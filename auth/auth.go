@@ -0,0 +1,169 @@
+// Package auth implements API key authentication and authorization for the REST and gRPC servers.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope defines what an API key may do. Each scope includes the ones before it.
+type Scope int
+
+const (
+	// ScopeRead allows querying devices and groups.
+	ScopeRead Scope = iota + 1
+	// ScopeControl allows changing the state of devices and groups.
+	ScopeControl
+	// ScopeAdmin allows everything, including raw gateway access.
+	ScopeAdmin
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeControl:
+		return "control"
+	case ScopeAdmin:
+		return "admin"
+	}
+	return fmt.Sprintf("Scope(%d)", int(s))
+}
+
+// ParseScope parses the name of a scope as used in the configuration.
+func ParseScope(s string) (Scope, error) {
+	switch strings.ToLower(s) {
+	case "read", "read-only", "readonly":
+		return ScopeRead, nil
+	case "control":
+		return ScopeControl, nil
+	case "admin":
+		return ScopeAdmin, nil
+	}
+	return 0, fmt.Errorf("unknown scope %q, use one of read, control or admin", s)
+}
+
+// ErrUnauthenticated is returned for missing or unknown API keys.
+var ErrUnauthenticated = errors.New("missing or invalid API key")
+
+// Key is an API key as configured in the api_keys section of the configuration. Devices and Groups restrict
+// the key to the listed device and group IDs, leaving them empty allows all devices and groups.
+type Key struct {
+	Name    string `mapstructure:"name"`
+	Key     string `mapstructure:"key"`
+	Scope   string `mapstructure:"scope"`
+	Devices []int  `mapstructure:"devices"`
+	Groups  []int  `mapstructure:"groups"`
+}
+
+// Restricted reports whether the key is restricted to some devices or groups.
+func (k Key) Restricted() bool {
+	return len(k.Devices) > 0 || len(k.Groups) > 0
+}
+
+// CheckGateways rejects keys restricted to devices or groups if the server has more than one gateway. Device
+// and group IDs repeat on every gateway, so a restriction would grant the same IDs on all of them.
+func CheckGateways(keys []Key, gateways int) error {
+	if gateways < 2 {
+		return nil
+	}
+	for i, k := range keys {
+		if k.Restricted() {
+			return fmt.Errorf("api key %d (%s) is restricted to devices or groups, which is not supported with several gateways", i, k.Name)
+		}
+	}
+	return nil
+}
+
+// Principal is the identity of an authenticated API key.
+//
+// All methods may be called on a nil Principal, which represents anonymous access with authentication
+// disabled and is allowed everything.
+type Principal struct {
+	Name    string
+	Scope   Scope
+	Devices []int
+	Groups  []int
+}
+
+// Allows reports whether the principal has at least the passed scope.
+func (p *Principal) Allows(scope Scope) bool {
+	return p == nil || p.Scope >= scope
+}
+
+// AllowsDevice reports whether the principal may access the device.
+func (p *Principal) AllowsDevice(deviceId int) bool {
+	return p == nil || len(p.Devices) == 0 || slices.Contains(p.Devices, deviceId)
+}
+
+// AllowsGroup reports whether the principal may access the group.
+func (p *Principal) AllowsGroup(groupId int) bool {
+	return p == nil || len(p.Groups) == 0 || slices.Contains(p.Groups, groupId)
+}
+
+// Authenticator resolves API keys to principals.
+type Authenticator struct {
+	keys       [][]byte
+	principals []*Principal
+}
+
+// New creates an Authenticator accepting the passed keys. Keys must be unique, non-empty and have a valid scope.
+func New(keys []Key) (*Authenticator, error) {
+	a := &Authenticator{}
+	seen := map[string]bool{}
+	for i, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("api key %d (%s) has no key", i, k.Name)
+		}
+		if seen[k.Key] {
+			return nil, fmt.Errorf("api key %d (%s) is not unique", i, k.Name)
+		}
+		seen[k.Key] = true
+		scope, err := ParseScope(k.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("api key %d (%s): %w", i, k.Name, err)
+		}
+		a.keys = append(a.keys, []byte(k.Key))
+		a.principals = append(a.principals, &Principal{Name: k.Name, Scope: scope, Devices: k.Devices, Groups: k.Groups})
+	}
+	return a, nil
+}
+
+// Authenticate returns the principal of the passed key. All keys are compared in constant time.
+func (a *Authenticator) Authenticate(key string) (*Principal, error) {
+	var match *Principal
+	for i, k := range a.keys {
+		if subtle.ConstantTimeCompare(k, []byte(key)) == 1 {
+			match = a.principals[i]
+		}
+	}
+	if match == nil {
+		return nil, ErrUnauthenticated
+	}
+	return match, nil
+}
+
+// TokenFromHeader extracts the API key from an "Authorization: Bearer <key>" header value.
+func TokenFromHeader(authorization string) string {
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "bearer ") {
+		return strings.TrimSpace(authorization[7:])
+	}
+	return ""
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(contextKey{}).(*Principal)
+	return p
+}
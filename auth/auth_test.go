@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	a, err := New([]Key{
+		{Name: "dashboard", Key: "read-key", Scope: "read"},
+		{Name: "automation", Key: "control-key", Scope: "control", Devices: []int{65538}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := a.Authenticate("control-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "automation" || !p.Allows(ScopeRead) || !p.Allows(ScopeControl) || p.Allows(ScopeAdmin) {
+		t.Fatalf("unexpected principal %+v", p)
+	}
+	if !p.AllowsDevice(65538) || p.AllowsDevice(65539) || !p.AllowsGroup(131073) {
+		t.Fatalf("unexpected allow-lists %+v", p)
+	}
+
+	if _, err := a.Authenticate("wrong"); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+	if _, err := a.Authenticate(""); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestNew_InvalidKeys(t *testing.T) {
+	for name, keys := range map[string][]Key{
+		"empty key":     {{Name: "a", Scope: "read"}},
+		"unknown scope": {{Name: "a", Key: "k", Scope: "superuser"}},
+		"duplicate key": {{Name: "a", Key: "k", Scope: "read"}, {Name: "b", Key: "k", Scope: "admin"}},
+	} {
+		if _, err := New(keys); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCheckGateways(t *testing.T) {
+	keys := []Key{{Name: "admin", Key: "a", Scope: "admin"}, {Name: "hallway", Key: "h", Scope: "control", Devices: []int{65538}}}
+	if err := CheckGateways(keys, 1); err != nil {
+		t.Fatalf("expected restricted keys to be allowed with one gateway, got %v", err)
+	}
+	if err := CheckGateways(keys[:1], 2); err != nil {
+		t.Fatalf("expected unrestricted keys to be allowed with several gateways, got %v", err)
+	}
+	if err := CheckGateways(keys, 2); err == nil || !strings.Contains(err.Error(), "hallway") {
+		t.Fatalf("expected the restricted key to be rejected with several gateways, got %v", err)
+	}
+}
+
+func TestNilPrincipalAllowsEverything(t *testing.T) {
+	var p *Principal
+	if !p.Allows(ScopeAdmin) || !p.AllowsDevice(1) || !p.AllowsGroup(1) {
+		t.Fatal("expected nil principal to allow everything")
+	}
+}
+
+func TestTokenFromHeader(t *testing.T) {
+	if got := TokenFromHeader("Bearer abc"); got != "abc" {
+		t.Fatalf("expected abc, got %q", got)
+	}
+	if got := TokenFromHeader("Basic abc"); got != "" {
+		t.Fatalf("expected no token, got %q", got)
+	}
+}
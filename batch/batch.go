@@ -0,0 +1,232 @@
+// Package batch validates and executes batches of device and group operations for the REST and gRPC APIs.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/validation"
+)
+
+// MaxOperations limits the number of operations in a single batch.
+const MaxOperations = 100
+
+// concurrency bounds the operations in flight. Calls to the gateway are serialized anyway, and keeping the
+// queue short lets StopOnError skip the operations waiting behind a failure.
+const concurrency = 4
+
+// Client defines the gateway operations used to execute a batch.
+type Client interface {
+	GetGroup(groupId int) (model.Group, error)
+	PutDeviceColor(deviceId int, x, y int) (model.Result, error)
+	PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error)
+	PutDeviceDimming(deviceId int, dimming int) (model.Result, error)
+	PutDevicePower(deviceId int, power int) (model.Result, error)
+	PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error)
+	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+	PutGroupPower(groupId int, power int) (model.Result, error)
+	PutGroupDimming(groupId int, dimming int) (model.Result, error)
+	PutGroupScene(groupId int, sceneId int) (model.Result, error)
+}
+
+// ValidationError is returned by Validate for a batch that is rejected as a whole.
+type ValidationError struct {
+	// Index of the invalid operation, -1 if the batch itself is invalid.
+	Index int
+	Msg   string
+}
+
+func (e ValidationError) Error() string {
+	if e.Index < 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("operations[%d]: %s", e.Index, e.Msg)
+}
+
+// ErrForbidden is returned by Authorize if the principal may not execute an operation of the batch.
+var ErrForbidden = errors.New("API key may not execute this batch")
+
+// Validate checks the batch and all its operations before anything is sent to the gateway. The values of the
+// operations are checked against the validate tags of model.BatchOperation, like all other requests.
+func Validate(req model.BatchRequest) error {
+	if len(req.Operations) == 0 {
+		return ValidationError{Index: -1, Msg: "at least one operation is required"}
+	}
+	if len(req.Operations) > MaxOperations {
+		return ValidationError{Index: -1, Msg: fmt.Sprintf("at most %d operations are allowed", MaxOperations)}
+	}
+	for i, op := range req.Operations {
+		if msg := validateOperation(op); msg != "" {
+			return ValidationError{Index: i, Msg: msg}
+		}
+	}
+	return nil
+}
+
+// validateOperation checks an operation, returning why it is invalid or "" if it is valid.
+func validateOperation(op model.BatchOperation) string {
+	if err := validation.Check(op); err != nil {
+		return err.Error()
+	}
+	switch {
+	case (op.DeviceId == 0) == (op.GroupId == 0):
+		return "exactly one of deviceId and groupId must be set"
+	case op.DeviceId < 0 || op.GroupId < 0:
+		return "ids must be positive"
+	case op.Power == nil && op.Dimmer == nil && op.RGBcolor == "" && op.X == nil && op.Y == nil && op.Positioning == nil && op.SceneId == nil:
+		return "at least one of power, dimmer, rgbcolor, x/y, positioning and sceneId must be set"
+	case (op.X == nil) != (op.Y == nil):
+		return "x and y must be set together"
+	case op.RGBcolor != "" && op.X != nil:
+		return "only one of rgbcolor and x/y may be set"
+	case op.SceneId != nil && op.GroupId == 0:
+		return "sceneId requires a groupId"
+	}
+	return ""
+}
+
+// Authorize checks that the principal may change all devices and groups targeted by the batch.
+func Authorize(p *auth.Principal, req model.BatchRequest) error {
+	if !p.Allows(auth.ScopeControl) {
+		return fmt.Errorf("%w: the control scope is required", ErrForbidden)
+	}
+	for i, op := range req.Operations {
+		if op.DeviceId != 0 && !p.AllowsDevice(op.DeviceId) {
+			return fmt.Errorf("%w: operations[%d] targets device %d", ErrForbidden, i, op.DeviceId)
+		}
+		if op.GroupId != 0 && !p.AllowsGroup(op.GroupId) {
+			return fmt.Errorf("%w: operations[%d] targets group %d", ErrForbidden, i, op.GroupId)
+		}
+	}
+	return nil
+}
+
+// Run executes the operations of a validated batch concurrently and returns their results in request order.
+func Run(ctx context.Context, client Client, req model.BatchRequest) model.BatchResponse {
+	start := time.Now()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]model.BatchResult, len(req.Operations))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, op := range req.Operations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = model.BatchResult{Index: i, Status: model.BatchStatusSkipped}
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			opStart := time.Now()
+			res, err := execute(ctx, client, op)
+			results[i].DurationMs = milliseconds(time.Since(opStart))
+			if err != nil {
+				results[i].Status, results[i].Error = model.BatchStatusFailed, err.Error()
+				if req.StopOnError {
+					cancel()
+				}
+				return
+			}
+			results[i].Status, results[i].Result = model.BatchStatusOK, res.Msg
+		}()
+	}
+	wg.Wait()
+	return model.BatchResponse{Results: results, DurationMs: milliseconds(time.Since(start))}
+}
+
+// execute applies all attributes of the operation, stopping at the first failure. The result of the last
+// gateway call is returned.
+func execute(ctx context.Context, client Client, op model.BatchOperation) (model.Result, error) {
+	var steps []func() (model.Result, error)
+	if op.DeviceId != 0 {
+		steps = deviceSteps(client, op.DeviceId, op)
+	} else {
+		steps = groupSteps(client, op)
+	}
+	var res model.Result
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		var err error
+		if res, err = step(); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func deviceSteps(client Client, deviceId int, op model.BatchOperation) []func() (model.Result, error) {
+	var steps []func() (model.Result, error)
+	switch {
+	case op.Power != nil && op.Dimmer != nil:
+		steps = append(steps, func() (model.Result, error) { return client.PutDeviceState(deviceId, *op.Power, *op.Dimmer) })
+	case op.Power != nil:
+		steps = append(steps, func() (model.Result, error) { return client.PutDevicePower(deviceId, *op.Power) })
+	case op.Dimmer != nil:
+		steps = append(steps, func() (model.Result, error) { return client.PutDeviceDimming(deviceId, *op.Dimmer) })
+	}
+	return append(steps, colorAndPositionSteps(client, deviceId, op)...)
+}
+
+func colorAndPositionSteps(client Client, deviceId int, op model.BatchOperation) []func() (model.Result, error) {
+	var steps []func() (model.Result, error)
+	if op.RGBcolor != "" {
+		steps = append(steps, func() (model.Result, error) { return client.PutDeviceColorRGB(deviceId, op.RGBcolor) })
+	}
+	if op.X != nil {
+		steps = append(steps, func() (model.Result, error) { return client.PutDeviceColor(deviceId, *op.X, *op.Y) })
+	}
+	if op.Positioning != nil {
+		steps = append(steps, func() (model.Result, error) { return client.PutDevicePositioning(deviceId, *op.Positioning) })
+	}
+	return steps
+}
+
+func groupSteps(client Client, op model.BatchOperation) []func() (model.Result, error) {
+	var steps []func() (model.Result, error)
+	if op.Power != nil {
+		steps = append(steps, func() (model.Result, error) { return client.PutGroupPower(op.GroupId, *op.Power) })
+	}
+	if op.Dimmer != nil {
+		steps = append(steps, func() (model.Result, error) { return client.PutGroupDimming(op.GroupId, *op.Dimmer) })
+	}
+	if op.RGBcolor != "" || op.X != nil || op.Positioning != nil {
+		// the gateway has no group level color or position, apply them to each device of the group
+		steps = append(steps, func() (model.Result, error) {
+			group, err := client.GetGroup(op.GroupId)
+			if err != nil {
+				return model.Result{}, err
+			}
+			var res model.Result
+			for _, deviceId := range group.Content.DeviceList.DeviceIds {
+				for _, step := range colorAndPositionSteps(client, deviceId, op) {
+					if res, err = step(); err != nil {
+						return res, fmt.Errorf("device %d: %w", deviceId, err)
+					}
+				}
+			}
+			return res, nil
+		})
+	}
+	if op.SceneId != nil {
+		steps = append(steps, func() (model.Result, error) { return client.PutGroupScene(op.GroupId, *op.SceneId) })
+	}
+	return steps
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,159 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/model"
+)
+
+// mockClient records the calls made to it. Calls fail with err, after delay.
+type mockClient struct {
+	mu    sync.Mutex
+	calls []string
+	group model.Group
+	err   error
+	delay time.Duration
+}
+
+func (m *mockClient) record(format string, args ...any) (model.Result, error) {
+	time.Sleep(m.delay)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, fmt.Sprintf(format, args...))
+	return model.Result{Msg: "Changed"}, m.err
+}
+
+func (m *mockClient) GetGroup(_ int) (model.Group, error) { return m.group, nil }
+func (m *mockClient) PutDeviceColor(id int, x, y int) (model.Result, error) {
+	return m.record("device %d xy %d/%d", id, x, y)
+}
+func (m *mockClient) PutDeviceColorRGB(id int, rgb string) (model.Result, error) {
+	return m.record("device %d rgb %s", id, rgb)
+}
+func (m *mockClient) PutDeviceDimming(id int, dimming int) (model.Result, error) {
+	return m.record("device %d dimmer %d", id, dimming)
+}
+func (m *mockClient) PutDevicePower(id int, power int) (model.Result, error) {
+	return m.record("device %d power %d", id, power)
+}
+func (m *mockClient) PutDeviceState(id int, power int, dimmer int) (model.Result, error) {
+	return m.record("device %d state %d/%d", id, power, dimmer)
+}
+func (m *mockClient) PutDevicePositioning(id int, positioning float32) (model.Result, error) {
+	return m.record("device %d position %v", id, positioning)
+}
+func (m *mockClient) PutGroupPower(id int, power int) (model.Result, error) {
+	return m.record("group %d power %d", id, power)
+}
+func (m *mockClient) PutGroupDimming(id int, dimming int) (model.Result, error) {
+	return m.record("group %d dimmer %d", id, dimming)
+}
+func (m *mockClient) PutGroupScene(id int, sceneId int) (model.Result, error) {
+	return m.record("group %d scene %d", id, sceneId)
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestValidate(t *testing.T) {
+	for name, ops := range map[string][]model.BatchOperation{
+		"empty":           {},
+		"no target":       {{Power: ptr(1)}},
+		"two targets":     {{DeviceId: 1, GroupId: 2, Power: ptr(1)}},
+		"no action":       {{DeviceId: 1}},
+		"power":           {{DeviceId: 1, Power: ptr(2)}},
+		"dimmer":          {{DeviceId: 1, Dimmer: ptr(255)}},
+		"rgb":             {{DeviceId: 1, RGBcolor: "red"}},
+		"x without y":     {{DeviceId: 1, X: ptr(1)}},
+		"x":               {{DeviceId: 1, X: ptr(65536), Y: ptr(0)}},
+		"positioning":     {{DeviceId: 1, Positioning: ptr[float32](101)}},
+		"scene on device": {{DeviceId: 1, SceneId: ptr(1)}},
+	} {
+		if err := Validate(model.BatchRequest{Operations: ops}); !errors.As(err, &ValidationError{}) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+	var ve ValidationError
+	if err := Validate(model.BatchRequest{Operations: []model.BatchOperation{{DeviceId: 1}, {DeviceId: 1, Dimmer: ptr(255)}}}); !errors.As(err, &ve) || ve.Index != 0 {
+		t.Errorf("expected the first operation to be rejected, got %v", err)
+	}
+	if err := Validate(model.BatchRequest{Operations: []model.BatchOperation{{DeviceId: 1, Dimmer: ptr(255)}}}); !errors.As(err, &ve) || ve.Msg != "dimmer must be at most 254, got 255" {
+		t.Errorf("expected the message of the dimmer tag, got %v", err)
+	}
+	ok := model.BatchRequest{Operations: []model.BatchOperation{{DeviceId: 1, Power: ptr(1), Dimmer: ptr(254)}, {GroupId: 2, SceneId: ptr(3)}}}
+	if err := Validate(ok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	req := model.BatchRequest{Operations: []model.BatchOperation{{DeviceId: 1, Power: ptr(1)}, {GroupId: 2, Power: ptr(1)}}}
+	if err := Authorize(nil, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range []*auth.Principal{
+		{Scope: auth.ScopeRead},
+		{Scope: auth.ScopeControl, Devices: []int{3}},
+		{Scope: auth.ScopeControl, Groups: []int{3}},
+	} {
+		if err := Authorize(p, req); !errors.Is(err, ErrForbidden) {
+			t.Errorf("expected ErrForbidden for %+v, got %v", p, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	mc := &mockClient{}
+	mc.group.Content.DeviceList.DeviceIds = []int{10, 11}
+	res := Run(context.Background(), mc, model.BatchRequest{Operations: []model.BatchOperation{
+		{DeviceId: 1, Power: ptr(1), Dimmer: ptr(100)},
+		{GroupId: 2, Dimmer: ptr(20), RGBcolor: "f1e0b5"},
+		{GroupId: 3, SceneId: ptr(7)},
+	}})
+
+	for i, r := range res.Results {
+		if r.Index != i || r.Status != model.BatchStatusOK || r.Result != "Changed" {
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+	slices.Sort(mc.calls)
+	expected := []string{"device 1 state 1/100", "device 10 rgb f1e0b5", "device 11 rgb f1e0b5", "group 2 dimmer 20", "group 3 scene 7"}
+	if !slices.Equal(mc.calls, expected) {
+		t.Fatalf("expected calls %v, got %v", expected, mc.calls)
+	}
+}
+
+func TestRun_StopOnError(t *testing.T) {
+	mc := &mockClient{err: errors.New("gateway unreachable"), delay: 10 * time.Millisecond}
+	req := model.BatchRequest{StopOnError: true}
+	for i := 1; i <= 20; i++ {
+		req.Operations = append(req.Operations, model.BatchOperation{DeviceId: i, Power: ptr(1)})
+	}
+	res := Run(context.Background(), mc, req)
+
+	counts := map[string]int{}
+	for _, r := range res.Results {
+		counts[r.Status]++
+	}
+	if counts[model.BatchStatusFailed] < 1 || counts[model.BatchStatusFailed] > concurrency || counts[model.BatchStatusSkipped] < 20-concurrency {
+		t.Fatalf("expected the operations after the first failure to be skipped, got %v", counts)
+	}
+}
+
+func TestRun_ContinueOnError(t *testing.T) {
+	mc := &mockClient{err: errors.New("gateway unreachable")}
+	res := Run(context.Background(), mc, model.BatchRequest{Operations: []model.BatchOperation{
+		{DeviceId: 1, Power: ptr(1)}, {DeviceId: 2, Power: ptr(1)},
+	}})
+	for _, r := range res.Results {
+		if r.Status != model.BatchStatusFailed || r.Error != "gateway unreachable" {
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+}
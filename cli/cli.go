@@ -0,0 +1,406 @@
+// Package cli implements the subcommands of the command line client, e.g. "tradfri-go devices list", which
+// print their result as a table, JSON, YAML or through a Go template.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// Client defines the gateway operations used by the commands.
+type Client interface {
+	ListDevices() ([]model.Device, error)
+	GetDevice(deviceId int) (model.Device, error)
+	ListGroups() ([]model.Group, error)
+	GetGroup(groupId int) (model.Group, error)
+	GetGatewayInfo() (model.GatewayInfo, error)
+	PutDevicePower(deviceId int, power int) (model.Result, error)
+	PutDeviceDimming(deviceId int, dimming int) (model.Result, error)
+	PutDeviceColorTimed(deviceId int, x, y int, transitionTimeMS int) (model.Result, error)
+	PutDeviceColorRGBTimed(deviceId int, rgb string, transitionTimeMS int) (model.Result, error)
+	PutDeviceColorTemperatureTimed(deviceId int, kelvin int, transitionTimeMS int) (model.Result, error)
+	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+	PutGroupPower(groupId int, power int) (model.Result, error)
+	PutGroupDimming(groupId int, dimming int) (model.Result, error)
+	PutGroupScene(groupId int, sceneId int) (model.Result, error)
+}
+
+// Exit codes of the commands.
+const (
+	ExitOK = 0
+	// ExitError is returned for failures not covered by the other codes, e.g. the gateway refusing a change.
+	ExitError = 1
+	// ExitUsage is returned for unknown commands and invalid arguments.
+	ExitUsage = 2
+	// ExitNotFound is returned if the device, group or scene does not exist, or no gateway was discovered.
+	ExitNotFound = 3
+	// ExitUnavailable is returned if the gateway could not be reached or did not respond in time.
+	ExitUnavailable = 4
+)
+
+// Options controls how the commands print their result.
+type Options struct {
+	// Output is the output format, one of "table", "json", "yaml" and "template".
+	Output string
+	// Template is the Go template used by the template output format.
+	Template string
+	// Transition is the duration of color changes, 0 uses the gateway default.
+	Transition time.Duration
+	// DiscoveryTimeout is how long the discover command waits for gateways, 0 uses the discovery default.
+	DiscoveryTimeout time.Duration
+}
+
+// UsageError is returned for unknown commands and invalid arguments.
+type UsageError struct {
+	msg string
+}
+
+func (e UsageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...any) error {
+	return UsageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// action performs a command whose arguments have been parsed.
+type action func(c Client) (any, error)
+
+type command struct {
+	name  string
+	args  []string
+	help  string
+	parse func(args []string, opts Options) (action, error)
+	// local commands don't connect to the gateway, their action is passed a nil client.
+	local bool
+}
+
+var commands = []command{
+	{"devices list", nil, "List all devices", listDevices, false},
+	{"device show", []string{"device-id"}, "Show a device", showDevice, false},
+	{"light on", []string{"device-id"}, "Switch a light on", lightPower(1), false},
+	{"light off", []string{"device-id"}, "Switch a light off", lightPower(0), false},
+	{"light dim", []string{"device-id", "percent"}, "Set the brightness of a light", lightDim, false},
+	{"light color", []string{"device-id", "color"}, "Set the color of a light as hex RGB (f1e0b5), CIE x,y (0.45,0.41) or Kelvin (2700K)", lightColor, false},
+	{"blind set", []string{"device-id", "position"}, "Set the position of a blind, from 0 (open) to 100 (closed)", blindSet, false},
+	{"groups list", nil, "List all groups", listGroups, false},
+	{"group on", []string{"group-id"}, "Switch all devices of a group on", groupPower(1), false},
+	{"group off", []string{"group-id"}, "Switch all devices of a group off", groupPower(0), false},
+	{"group dim", []string{"group-id", "percent"}, "Set the brightness of all devices of a group", groupDim, false},
+	{"scenes activate", []string{"group-id", "scene-id"}, "Activate a scene of a group", activateScene, false},
+	{"gateway info", nil, "Show the details of the gateway", gatewayInfo, false},
+	{"discover", nil, "Find gateways on the local network", discover, true},
+}
+
+// discoverGateways browses for gateways, replaced in tests.
+var discoverGateways = discovery.Discover
+
+// errNoGateway is returned by the discover command if no gateway answered.
+var errNoGateway = errors.New("no gateway found on the local network")
+
+// Run executes the command of args, connecting to the gateway with newClient once the arguments are valid,
+// and prints its result to out.
+func Run(newClient func() (Client, error), args []string, out io.Writer, opts Options) error {
+	cmd, params, err := lookup(args)
+	if err != nil {
+		return err
+	}
+	if len(params) != len(cmd.args) {
+		return usageErrorf("usage: %s", usageLine(cmd))
+	}
+	if err := checkOutput(opts); err != nil {
+		return err
+	}
+	act, err := cmd.parse(params, opts)
+	if err != nil {
+		return err
+	}
+	var c Client
+	if !cmd.local {
+		if c, err = newClient(); err != nil {
+			return err
+		}
+	}
+	res, err := act(c)
+	if err != nil {
+		return err
+	}
+	return render(out, res, opts)
+}
+
+func lookup(args []string) (command, []string, error) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && slices.Equal(args[:len(words)], words) {
+			return cmd, args[len(words):], nil
+		}
+	}
+	return command{}, nil, usageErrorf("unknown command %q\n%s", strings.Join(args, " "), Usage())
+}
+
+// Usage lists the commands.
+func Usage() string {
+	b := strings.Builder{}
+	b.WriteString("Commands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-42s %s\n", usageLine(cmd), cmd.help)
+	}
+	return b.String()
+}
+
+func usageLine(cmd command) string {
+	line := cmd.name
+	for _, arg := range cmd.args {
+		line += " <" + arg + ">"
+	}
+	return line
+}
+
+// ExitCode returns the exit code for the error returned by Run.
+func ExitCode(err error) int {
+	var usageErr UsageError
+	var gwErr tradfri.GatewayError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, errNoGateway):
+		return ExitNotFound
+	case errors.As(err, &gwErr) && gwErr.Code == coap.NotFound:
+		return ExitNotFound
+	case errors.As(err, &gwErr) && (gwErr.Code == coap.ServiceUnavailable || gwErr.Code == coap.GatewayTimeout):
+		return ExitUnavailable
+	case errors.Is(err, dtlscoap.ErrUnavailable), errors.Is(err, dtlscoap.ErrTimeout), errors.Is(err, dtlscoap.ErrClosed):
+		return ExitUnavailable
+	}
+	return ExitError
+}
+
+// result is printed by the commands changing state.
+type result struct {
+	Id     int    `json:"id"`
+	Result string `json:"result"`
+}
+
+func listDevices(_ []string, _ Options) (action, error) {
+	return func(c Client) (any, error) {
+		devices, err := c.ListDevices()
+		if err != nil {
+			return nil, err
+		}
+		res := make(deviceList, 0, len(devices))
+		for _, d := range devices {
+			res = append(res, model.ToDeviceV2(d))
+		}
+		return res, nil
+	}, nil
+}
+
+func showDevice(args []string, _ Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return func(c Client) (any, error) {
+		device, err := c.GetDevice(id)
+		if err != nil {
+			return nil, err
+		}
+		return deviceDetail(model.ToDeviceV2(device)), nil
+	}, nil
+}
+
+func lightPower(power int) func([]string, Options) (action, error) {
+	return func(args []string, _ Options) (action, error) {
+		id, err := parseId(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return changed(id, func(c Client) (model.Result, error) { return c.PutDevicePower(id, power) }), nil
+	}
+}
+
+func lightDim(args []string, _ Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	percent, err := parsePercent(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return changed(id, func(c Client) (model.Result, error) {
+		return c.PutDeviceDimming(id, model.PercentToDimmer(percent))
+	}), nil
+}
+
+func lightColor(args []string, opts Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	color := strings.ToLower(args[1])
+	transition := 500
+	if opts.Transition > 0 {
+		transition = int(opts.Transition.Milliseconds())
+	}
+	switch {
+	case strings.HasSuffix(color, "k"):
+		kelvin, err := strconv.Atoi(strings.TrimSuffix(color, "k"))
+		if err != nil || kelvin <= 0 {
+			return nil, usageErrorf("invalid color temperature %q, e.g. 2700K", args[1])
+		}
+		return changed(id, func(c Client) (model.Result, error) {
+			return c.PutDeviceColorTemperatureTimed(id, kelvin, transition)
+		}), nil
+	case strings.Contains(color, ","):
+		xy := strings.SplitN(color, ",", 2)
+		x, xErr := strconv.ParseFloat(strings.TrimSpace(xy[0]), 64)
+		y, yErr := strconv.ParseFloat(strings.TrimSpace(xy[1]), 64)
+		if xErr != nil || yErr != nil || x < 0 || x > 1 || y < 0 || y > 1 {
+			return nil, usageErrorf("invalid CIE 1931 color %q, x and y must be between 0 and 1", args[1])
+		}
+		return changed(id, func(c Client) (model.Result, error) {
+			return c.PutDeviceColorTimed(id, model.UnitToCIE(x), model.UnitToCIE(y), transition)
+		}), nil
+	}
+	hex := strings.TrimPrefix(color, "#")
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+		return nil, usageErrorf("invalid color %q, use hex RGB (f1e0b5), CIE x,y (0.45,0.41) or Kelvin (2700K)", args[1])
+	}
+	return changed(id, func(c Client) (model.Result, error) {
+		return c.PutDeviceColorRGBTimed(id, hex, transition)
+	}), nil
+}
+
+func blindSet(args []string, _ Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	position, err := strconv.ParseFloat(args[1], 32)
+	if err != nil || position < 0 || position > 100 {
+		return nil, usageErrorf("invalid position %q, must be between 0 and 100", args[1])
+	}
+	return changed(id, func(c Client) (model.Result, error) {
+		return c.PutDevicePositioning(id, float32(position))
+	}), nil
+}
+
+func listGroups(_ []string, _ Options) (action, error) {
+	return func(c Client) (any, error) {
+		groups, err := c.ListGroups()
+		if err != nil {
+			return nil, err
+		}
+		res := make(groupList, 0, len(groups))
+		for _, g := range groups {
+			res = append(res, model.ToGroupV2(g))
+		}
+		return res, nil
+	}, nil
+}
+
+func groupPower(power int) func([]string, Options) (action, error) {
+	return func(args []string, _ Options) (action, error) {
+		id, err := parseId(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return changed(id, func(c Client) (model.Result, error) { return c.PutGroupPower(id, power) }), nil
+	}
+}
+
+func groupDim(args []string, _ Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	percent, err := parsePercent(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return changed(id, func(c Client) (model.Result, error) {
+		return c.PutGroupDimming(id, model.PercentToDimmer(percent))
+	}), nil
+}
+
+func activateScene(args []string, _ Options) (action, error) {
+	id, err := parseId(args[0])
+	if err != nil {
+		return nil, err
+	}
+	sceneId, err := parseId(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return changed(id, func(c Client) (model.Result, error) { return c.PutGroupScene(id, sceneId) }), nil
+}
+
+func gatewayInfo(_ []string, _ Options) (action, error) {
+	return func(c Client) (any, error) {
+		info, err := c.GetGatewayInfo()
+		if err != nil {
+			return nil, err
+		}
+		return gateway{
+			Id:              info.GatewayId,
+			FirmwareVersion: info.FirmwareVersion,
+			NTPServer:       info.NTPServer,
+			CurrentTime:     time.Unix(int64(info.CurrentTime), 0).UTC(),
+		}, nil
+	}, nil
+}
+
+func discover(_ []string, opts Options) (action, error) {
+	return func(Client) (any, error) {
+		gateways, err := discoverGateways(context.Background(), discovery.Options{Timeout: opts.DiscoveryTimeout})
+		if err != nil {
+			return nil, err
+		}
+		if len(gateways) == 0 {
+			return nil, errNoGateway
+		}
+		return discoveredList(gateways), nil
+	}, nil
+}
+
+// changed returns the action of a command changing the device or group id, which prints the response code.
+func changed(id int, change func(c Client) (model.Result, error)) action {
+	return func(c Client) (any, error) {
+		res, err := change(c)
+		if err != nil {
+			return nil, err
+		}
+		return result{Id: id, Result: res.Msg}, nil
+	}
+}
+
+func parseId(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 1 {
+		return 0, usageErrorf("invalid id %q, must be a positive number", arg)
+	}
+	return id, nil
+}
+
+func parsePercent(arg string) (int, error) {
+	percent, err := strconv.Atoi(strings.TrimSuffix(arg, "%"))
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, usageErrorf("invalid percentage %q, must be between 0 and 100", arg)
+	}
+	return percent, nil
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// mockClient implements Client, recording the last change.
+type mockClient struct {
+	devices []model.Device
+	groups  []model.Group
+	info    model.GatewayInfo
+	err     error
+
+	call string
+}
+
+func (m *mockClient) ListDevices() ([]model.Device, error) { return m.devices, m.err }
+func (m *mockClient) GetDevice(id int) (model.Device, error) {
+	for _, d := range m.devices {
+		if d.DeviceId == id {
+			return d, m.err
+		}
+	}
+	return model.Device{}, tradfri.GatewayError{Code: coap.NotFound, Path: fmt.Sprintf("/15001/%d", id)}
+}
+func (m *mockClient) ListGroups() ([]model.Group, error)         { return m.groups, m.err }
+func (m *mockClient) GetGroup(_ int) (model.Group, error)        { return model.Group{}, m.err }
+func (m *mockClient) GetGatewayInfo() (model.GatewayInfo, error) { return m.info, m.err }
+
+func (m *mockClient) record(format string, args ...any) (model.Result, error) {
+	m.call = fmt.Sprintf(format, args...)
+	return model.Result{Msg: "Changed"}, m.err
+}
+
+func (m *mockClient) PutDevicePower(id int, power int) (model.Result, error) {
+	return m.record("power %d %d", id, power)
+}
+func (m *mockClient) PutDeviceDimming(id int, dimming int) (model.Result, error) {
+	return m.record("dimming %d %d", id, dimming)
+}
+func (m *mockClient) PutDeviceColorTimed(id int, x, y int, ms int) (model.Result, error) {
+	return m.record("xy %d %d %d %d", id, x, y, ms)
+}
+func (m *mockClient) PutDeviceColorRGBTimed(id int, rgb string, ms int) (model.Result, error) {
+	return m.record("rgb %d %s %d", id, rgb, ms)
+}
+func (m *mockClient) PutDeviceColorTemperatureTimed(id int, kelvin int, ms int) (model.Result, error) {
+	return m.record("kelvin %d %d %d", id, kelvin, ms)
+}
+func (m *mockClient) PutDevicePositioning(id int, position float32) (model.Result, error) {
+	return m.record("position %d %g", id, position)
+}
+func (m *mockClient) PutGroupPower(id int, power int) (model.Result, error) {
+	return m.record("group power %d %d", id, power)
+}
+func (m *mockClient) PutGroupDimming(id int, dimming int) (model.Result, error) {
+	return m.record("group dimming %d %d", id, dimming)
+}
+func (m *mockClient) PutGroupScene(id int, sceneId int) (model.Result, error) {
+	return m.record("scene %d %d", id, sceneId)
+}
+
+func testDevice() model.Device {
+	d := model.Device{DeviceId: 65537, Name: "Bulb", Type: tradfri.DeviceTypeLightbulb}
+	d.Metadata.Vendor = "IKEA of Sweden"
+	d.Metadata.TypeName = "TRADFRI bulb E27 CWS opal 600lm"
+	d.LightControl = make([]struct {
+		RGBHex           string  `json:"5706"`
+		Hue              int     `json:"5707"`
+		Saturation       int     `json:"5708"`
+		CIE_1931_X       int     `json:"5709"`
+		CIE_1931_Y       int     `json:"5710"`
+		ColorTemperature int     `json:"5711"`
+		TransitionTime   float64 `json:"5712"`
+		Power            int     `json:"5850"`
+		Dimmer           int     `json:"5851"`
+		DeviceId         int     `json:"9003"`
+	}, 1)
+	d.LightControl[0].Power = 1
+	d.LightControl[0].Dimmer = 127
+	d.LightControl[0].RGBHex = "f1e0b5"
+	return d
+}
+
+func run(mc *mockClient, opts Options, args ...string) (string, error) {
+	if opts.Output == "" {
+		opts.Output = OutputTable
+	}
+	out := bytes.Buffer{}
+	err := Run(func() (Client, error) { return mc, nil }, args, &out, opts)
+	return out.String(), err
+}
+
+func TestDevicesList(t *testing.T) {
+	mc := &mockClient{devices: []model.Device{testDevice()}}
+	out, err := run(mc, Options{}, "devices", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "65537") || !strings.Contains(lines[1], "on 50% #f1e0b5") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	mc := &mockClient{devices: []model.Device{testDevice()}}
+	out, err := run(mc, Options{Output: OutputJSON}, "device", "show", "65537")
+	if err != nil || !strings.Contains(out, `"kind": "light"`) || !strings.Contains(out, `"id": 65537`) {
+		t.Fatalf("unexpected JSON, err %v:\n%s", err, out)
+	}
+	out, err = run(mc, Options{Output: OutputYAML}, "device", "show", "65537")
+	if err != nil || !strings.Contains(out, "kind: light\n") || !strings.Contains(out, "  vendor: IKEA of Sweden\n") {
+		t.Fatalf("unexpected YAML, err %v:\n%s", err, out)
+	}
+	out, err = run(mc, Options{Output: OutputTemplate, Template: `{{range .}}{{.id}} {{.light.brightness}}{{end}}`}, "devices", "list")
+	if err != nil || out != "65537 50\n" {
+		t.Fatalf("unexpected template output, err %v: %q", err, out)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	tests := []struct {
+		args []string
+		call string
+	}{
+		{[]string{"light", "on", "65537"}, "power 65537 1"},
+		{[]string{"light", "off", "65537"}, "power 65537 0"},
+		{[]string{"light", "dim", "65537", "50%"}, "dimming 65537 127"},
+		{[]string{"light", "color", "65537", "#F1E0B5"}, "rgb 65537 f1e0b5 500"},
+		{[]string{"light", "color", "65537", "0.5,0.25"}, "xy 65537 32768 16384 500"},
+		{[]string{"light", "color", "65537", "2700K"}, "kelvin 65537 2700 500"},
+		{[]string{"blind", "set", "65538", "75"}, "position 65538 75"},
+		{[]string{"group", "on", "131073"}, "group power 131073 1"},
+		{[]string{"group", "dim", "131073", "100"}, "group dimming 131073 254"},
+		{[]string{"scenes", "activate", "131073", "196608"}, "scene 131073 196608"},
+	}
+	for _, tt := range tests {
+		mc := &mockClient{}
+		out, err := run(mc, Options{}, tt.args...)
+		if err != nil || mc.call != tt.call || !strings.Contains(out, "Changed") {
+			t.Errorf("%v: expected %q, got %q, err %v", tt.args, tt.call, mc.call, err)
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	mc := &mockClient{}
+	if _, err := run(mc, Options{Transition: 2 * time.Second}, "light", "color", "65537", "4000k"); err != nil {
+		t.Fatal(err)
+	}
+	if mc.call != "kelvin 65537 4000 2000" {
+		t.Fatalf("unexpected call %q", mc.call)
+	}
+}
+
+func TestGatewayInfo(t *testing.T) {
+	mc := &mockClient{info: model.GatewayInfo{GatewayId: "gw-123", FirmwareVersion: "1.19.26", CurrentTime: 1700000000}}
+	out, err := run(mc, Options{Output: OutputJSON}, "gateway", "info")
+	if err != nil || !strings.Contains(out, `"firmwareVersion": "1.19.26"`) || !strings.Contains(out, `"currentTime": "2023-11-14T22:13:20Z"`) {
+		t.Fatalf("unexpected output, err %v:\n%s", err, out)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  error
+		code int
+	}{
+		{[]string{"gateway", "info"}, nil, ExitOK},
+		{[]string{"lights", "list"}, nil, ExitUsage},
+		{[]string{"light", "on"}, nil, ExitUsage},
+		{[]string{"light", "dim", "65537", "150"}, nil, ExitUsage},
+		{[]string{"light", "color", "65537", "purple"}, nil, ExitUsage},
+		{[]string{"device", "show", "1"}, nil, ExitNotFound},
+		{[]string{"gateway", "info"}, dtlscoap.ErrTimeout, ExitUnavailable},
+		{[]string{"gateway", "info"}, fmt.Errorf("dial: %w", dtlscoap.ErrUnavailable), ExitUnavailable},
+		{[]string{"light", "on", "65537"}, errors.New("boom"), ExitError},
+	}
+	for _, tt := range tests {
+		_, err := run(&mockClient{err: tt.err}, Options{}, tt.args...)
+		if code := ExitCode(err); code != tt.code {
+			t.Errorf("%v: expected exit code %d, got %d (%v)", tt.args, tt.code, code, err)
+		}
+	}
+	if _, err := run(&mockClient{}, Options{Output: "xml"}, "gateway", "info"); ExitCode(err) != ExitUsage {
+		t.Errorf("expected usage error for invalid output, got %v", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	found := []discovery.Gateway{{ID: "gw-b072bf257a41", Host: "TRADFRI-Gateway-b072bf257a41.local.", IP: net.IPv4(192, 168, 1, 19), Port: 5684}}
+	defer func(d func(context.Context, discovery.Options) ([]discovery.Gateway, error)) { discoverGateways = d }(discoverGateways)
+	discoverGateways = func(_ context.Context, opts discovery.Options) ([]discovery.Gateway, error) {
+		if opts.Timeout != time.Second {
+			t.Errorf("expected the timeout to be passed on, got %v", opts.Timeout)
+		}
+		return found, nil
+	}
+
+	out := bytes.Buffer{}
+	newClient := func() (Client, error) { return nil, errors.New("discover must not connect to the gateway") }
+	if err := Run(newClient, []string{"discover"}, &out, Options{Output: OutputTable, DiscoveryTimeout: time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "gw-b072bf257a41") || !strings.Contains(out.String(), "192.168.1.19:5684") {
+		t.Fatalf("unexpected table:\n%s", out.String())
+	}
+
+	found = nil
+	if err := Run(newClient, []string{"discover"}, &out, Options{Output: OutputTable, DiscoveryTimeout: time.Second}); ExitCode(err) != ExitNotFound {
+		t.Fatalf("expected not found without gateways, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats.
+const (
+	OutputTable    = "table"
+	OutputJSON     = "json"
+	OutputYAML     = "yaml"
+	OutputTemplate = "template"
+)
+
+// tabular is implemented by results with a table representation, the other formats use their JSON encoding.
+type tabular interface {
+	table() (header []string, rows [][]string)
+}
+
+type deviceList []model.DeviceV2
+
+func (l deviceList) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, d := range l {
+		rows = append(rows, []string{strconv.Itoa(d.Id), d.Name, d.Kind, deviceState(d), strconv.FormatBool(d.Metadata.Alive)})
+	}
+	return []string{"ID", "NAME", "KIND", "STATE", "ALIVE"}, rows
+}
+
+// deviceDetail is a single device, shown as a list of properties.
+type deviceDetail model.DeviceV2
+
+func (d deviceDetail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(model.DeviceV2(d))
+}
+
+func (d deviceDetail) table() ([]string, [][]string) {
+	m := d.Metadata
+	rows := [][]string{
+		{"ID", strconv.Itoa(d.Id)},
+		{"NAME", d.Name},
+		{"KIND", d.Kind},
+		{"STATE", deviceState(model.DeviceV2(d))},
+		{"VENDOR", m.Vendor},
+		{"MODEL", m.Model},
+		{"SERIAL NUMBER", m.SerialNumber},
+		{"FIRMWARE", m.FirmwareVersion},
+		{"POWER SOURCE", m.PowerSource},
+		{"ALIVE", strconv.FormatBool(m.Alive)},
+	}
+	if m.BatteryLevel != nil {
+		rows = append(rows, []string{"BATTERY", fmt.Sprintf("%d%%", *m.BatteryLevel)})
+	}
+	if m.LastSeen != nil {
+		rows = append(rows, []string{"LAST SEEN", m.LastSeen.Format(time.RFC3339)})
+	}
+	return nil, rows
+}
+
+// deviceState summarizes the state of a device in the table format.
+func deviceState(d model.DeviceV2) string {
+	switch {
+	case d.Light != nil:
+		if !d.Light.On {
+			return "off"
+		}
+		state := fmt.Sprintf("on %d%%", d.Light.Brightness)
+		if d.Light.Color.Hex != "" {
+			state += " #" + d.Light.Color.Hex
+		}
+		return state
+	case d.Outlet != nil:
+		if d.Outlet.On {
+			return "on"
+		}
+		return "off"
+	case d.Blind != nil:
+		return fmt.Sprintf("%g%% closed", d.Blind.Position)
+	}
+	return ""
+}
+
+type groupList []model.GroupV2
+
+func (l groupList) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, g := range l {
+		state := "off"
+		if g.On {
+			state = fmt.Sprintf("on %d%%", g.Brightness)
+		}
+		ids := make([]string, 0, len(g.DeviceIds))
+		for _, id := range g.DeviceIds {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		rows = append(rows, []string{strconv.Itoa(g.Id), g.Name, state, strconv.Itoa(g.SceneId), strings.Join(ids, ",")})
+	}
+	return []string{"ID", "NAME", "STATE", "SCENE", "DEVICES"}, rows
+}
+
+type gateway struct {
+	Id              string    `json:"id"`
+	FirmwareVersion string    `json:"firmwareVersion"`
+	NTPServer       string    `json:"ntpServer"`
+	CurrentTime     time.Time `json:"currentTime"`
+}
+
+func (g gateway) table() ([]string, [][]string) {
+	return nil, [][]string{
+		{"ID", g.Id},
+		{"FIRMWARE", g.FirmwareVersion},
+		{"NTP SERVER", g.NTPServer},
+		{"CURRENT TIME", g.CurrentTime.Format(time.RFC3339)},
+	}
+}
+
+type discoveredList []discovery.Gateway
+
+func (l discoveredList) table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, g := range l {
+		rows = append(rows, []string{g.ID, g.Address(), g.Host})
+	}
+	return []string{"ID", "ADDRESS", "HOST"}, rows
+}
+
+func (r result) table() ([]string, [][]string) {
+	return []string{"ID", "RESULT"}, [][]string{{strconv.Itoa(r.Id), r.Result}}
+}
+
+func checkOutput(opts Options) error {
+	switch opts.Output {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	case OutputTemplate:
+		if opts.Template == "" {
+			return usageErrorf("the template output requires --template")
+		}
+		_, err := template.New("output").Parse(opts.Template)
+		if err != nil {
+			return usageErrorf("invalid template: %v", err)
+		}
+		return nil
+	}
+	return usageErrorf("invalid output %q, must be one of table, json, yaml and template", opts.Output)
+}
+
+// render prints res in the output format of opts. YAML and the data passed to templates use the JSON field
+// names, so that all formats agree with the REST API.
+func render(out io.Writer, res any, opts Options) error {
+	switch opts.Output {
+	case OutputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	case OutputYAML:
+		var node yaml.Node
+		if err := decodeJSON(res, &node); err != nil {
+			return err
+		}
+		clearStyle(&node)
+		enc := yaml.NewEncoder(out)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return err
+		}
+		return enc.Close()
+	case OutputTemplate:
+		var data any
+		if err := decodeJSON(res, &data); err != nil {
+			return err
+		}
+		tmpl, err := template.New("output").Parse(opts.Template)
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(out, data); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out)
+		return err
+	}
+	t, ok := res.(tabular)
+	if !ok {
+		return fmt.Errorf("no table output for %T", res)
+	}
+	header, rows := t.table()
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// decodeJSON converts v to its JSON encoding and decodes that into target. YAML is a superset of JSON, so a
+// yaml.Node target gets the JSON representation of v.
+func decodeJSON(v any, target any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if node, ok := target.(*yaml.Node); ok {
+		return yaml.Unmarshal(data, node)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(target)
+}
+
+// clearStyle switches the flow style of the decoded JSON to the block style of YAML.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		clearStyle(n)
+	}
+}
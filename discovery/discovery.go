@@ -0,0 +1,195 @@
+// Package discovery finds IKEA Trådfri gateways on the local network by browsing their mDNS (DNS-SD)
+// announcements of the _coap._udp service.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// Service is the DNS-SD service type announced by the gateways.
+	Service = "_coap._udp.local."
+	// MulticastAddress is the IPv4 mDNS group the queries are sent to.
+	MulticastAddress = "224.0.0.251:5353"
+	// DefaultTimeout is how long Discover waits for answers unless told otherwise.
+	DefaultTimeout = 3 * time.Second
+)
+
+// instancePrefix starts the service instance names of IKEA gateways, followed by the MAC address, e.g.
+// "gw-b072bf257a41". Other CoAP devices announcing the same service are ignored.
+const instancePrefix = "gw-"
+
+// Gateway is a discovered gateway.
+type Gateway struct {
+	// ID is the service instance name of the gateway, e.g. "gw-b072bf257a41".
+	ID string `json:"id"`
+	// Host is the host name of the gateway, e.g. "TRADFRI-Gateway-b072bf257a41.local.".
+	Host string `json:"host"`
+	IP   net.IP `json:"ip"`
+	Port int    `json:"port"`
+}
+
+// Address returns the IP and port of the gateway, as used for gateway_address.
+func (g Gateway) Address() string {
+	return net.JoinHostPort(g.IP.String(), strconv.Itoa(g.Port))
+}
+
+// Options controls Discover.
+type Options struct {
+	// Timeout is how long answers are collected, defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Address is where the queries are sent, defaults to MulticastAddress. A unicast address makes Discover
+	// query a single responder, e.g. a stand-in in tests.
+	Address string
+}
+
+// Discover browses for gateways until the timeout expires and returns those that could be resolved to an
+// IPv4 address and port, ordered by ID. The queries are sent from an ephemeral port, which makes responders
+// answer by unicast so that no other mDNS responder on the host needs to give up port 5353.
+func Discover(ctx context.Context, opts Options) ([]Gateway, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Address == "" {
+		opts.Address = MulticastAddress
+	}
+	dst, err := net.ResolveUDPAddr("udp4", opts.Address)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(opts.Timeout))
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	b := &browser{conn: conn, dst: dst, instances: map[string]bool{}, srv: map[string]*dns.SRV{}, ips: map[string]net.IP{}, asked: map[string]bool{}}
+	if err := b.query(Service, dns.TypePTR); err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("discovery: %w", err)
+		}
+		msg := new(dns.Msg)
+		if msg.Unpack(buf[:n]) != nil || !msg.Response {
+			continue
+		}
+		b.add(msg)
+		if err := b.resolve(); err != nil {
+			return nil, fmt.Errorf("discovery: %w", err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.gateways(), nil
+}
+
+// browser collects the records of the answers, asking for the service (SRV) and address (A) records
+// responders left out.
+type browser struct {
+	conn *net.UDPConn
+	dst  *net.UDPAddr
+
+	// instances are the gateway instance names, srv their service records and ips the addresses by host.
+	instances map[string]bool
+	srv       map[string]*dns.SRV
+	ips       map[string]net.IP
+	// asked records the queries sent, each is only sent once.
+	asked map[string]bool
+}
+
+func (b *browser) query(name string, qtype uint16) error {
+	key := dns.Type(qtype).String() + " " + name
+	if b.asked[key] {
+		return nil
+	}
+	b.asked[key] = true
+	m := new(dns.Msg)
+	m.SetQuestion(name, qtype)
+	m.Id = 0
+	m.RecursionDesired = false
+	// ask for a unicast response (the QU bit)
+	m.Question[0].Qclass |= 1 << 15
+	packed, err := m.Pack()
+	if err != nil {
+		return err
+	}
+	_, err = b.conn.WriteToUDP(packed, b.dst)
+	return err
+}
+
+func (b *browser) add(msg *dns.Msg) {
+	for _, rr := range append(msg.Answer, msg.Extra...) {
+		name := canonical(rr.Header().Name)
+		switch rr := rr.(type) {
+		case *dns.PTR:
+			if instance := canonical(rr.Ptr); name == Service && strings.HasPrefix(instance, instancePrefix) {
+				b.instances[instance] = true
+			}
+		case *dns.SRV:
+			b.srv[name] = rr
+		case *dns.A:
+			b.ips[name] = rr.A
+		}
+	}
+}
+
+// resolve asks for the records still missing to resolve the gateways found so far.
+func (b *browser) resolve() error {
+	for instance := range b.instances {
+		srv, ok := b.srv[instance]
+		var err error
+		switch {
+		case !ok:
+			err = b.query(instance, dns.TypeSRV)
+		case b.ips[canonical(srv.Target)] == nil:
+			err = b.query(srv.Target, dns.TypeA)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *browser) gateways() []Gateway {
+	res := make([]Gateway, 0, len(b.instances))
+	for instance := range b.instances {
+		srv, ok := b.srv[instance]
+		if !ok {
+			continue
+		}
+		ip := b.ips[canonical(srv.Target)]
+		if ip == nil {
+			continue
+		}
+		res = append(res, Gateway{ID: strings.TrimSuffix(instance, "."+Service), Host: srv.Target, IP: ip, Port: int(srv.Port)})
+	}
+	slices.SortFunc(res, func(a, b Gateway) int { return strings.Compare(a.ID, b.ID) })
+	return res
+}
+
+// canonical returns the fully qualified lower case form of a name, as names are compared case-insensitively.
+func canonical(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
@@ -0,0 +1,93 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	instance = "gw-b072bf257a41._coap._udp.local."
+	host     = "TRADFRI-Gateway-b072bf257a41.local."
+)
+
+// startResponder runs a stand-in for the mDNS responder of a gateway, answering each question on its own
+// unless complete is set, in which case the PTR answer carries all records.
+func startResponder(t *testing.T, complete bool) string {
+	t.Helper()
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	hdr := func(name string, rrtype uint16) dns.RR_Header {
+		return dns.RR_Header{Name: name, Rrtype: rrtype, Class: dns.ClassINET, Ttl: 120}
+	}
+	ptr := []dns.RR{
+		&dns.PTR{Hdr: hdr(Service, dns.TypePTR), Ptr: instance},
+		&dns.PTR{Hdr: hdr(Service, dns.TypePTR), Ptr: "printer._coap._udp.local."},
+	}
+	srv := &dns.SRV{Hdr: hdr(instance, dns.TypeSRV), Target: host, Port: 5684}
+	a := &dns.A{Hdr: hdr(host, dns.TypeA), A: net.IPv4(192, 168, 1, 19)}
+
+	go func() {
+		buf := make([]byte, 9000)
+		for {
+			n, src, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			query := new(dns.Msg)
+			if query.Unpack(buf[:n]) != nil || len(query.Question) != 1 {
+				continue
+			}
+			res := new(dns.Msg)
+			res.Response = true
+			switch query.Question[0].Qtype {
+			case dns.TypePTR:
+				res.Answer = ptr
+				if complete {
+					res.Extra = []dns.RR{srv, a}
+				}
+			case dns.TypeSRV:
+				res.Answer = []dns.RR{srv}
+			case dns.TypeA:
+				res.Answer = []dns.RR{a}
+			}
+			packed, _ := res.Pack()
+			_, _ = conn.WriteToUDP(packed, src)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestDiscover(t *testing.T) {
+	for name, complete := range map[string]bool{"follow-up queries": false, "additional records": true} {
+		gateways, err := Discover(context.Background(), Options{Address: startResponder(t, complete), Timeout: 300 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(gateways) != 1 {
+			t.Fatalf("%s: expected one gateway, got %+v", name, gateways)
+		}
+		if g := gateways[0]; g.ID != "gw-b072bf257a41" || g.Host != host || g.Address() != "192.168.1.19:5684" {
+			t.Errorf("%s: unexpected gateway %+v", name, g)
+		}
+	}
+}
+
+func TestDiscover_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if _, err := Discover(ctx, Options{Address: startResponder(t, true), Timeout: 5 * time.Second}); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("expected the cancellation to end the discovery early")
+	}
+}
@@ -1,40 +1,122 @@
 package dtlscoap
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/dustin/go-coap"
 	"github.com/eriklupander/dtls"
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/eriklupander/tradfri-go/metrics"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DtlsClient provides an domain-agnostic CoAP-client with DTLS transport.
+//
+// A DtlsClient is safe for concurrent use. Calls are serialized since the gateway
+// session is a single request/response channel.
 type DtlsClient struct {
+	mu             sync.Mutex
+	listener       *dtls.Listener
 	peer           *dtls.Peer
 	msgID          uint16
 	gatewayAddress string
 	clientID       string
 	psk            string
+	opts           Options
+	closed         bool
 }
 
-// NewDtlsClient acts as factory function, returns a pointer to a connected (or will panic) DtlsClient.
+// Options are the optional settings of a DtlsClient.
+type Options struct {
+	// Gateway names the gateway in the metrics, for processes connected to several gateways.
+	Gateway string
+	// Health is fed with the state of the DTLS session and the outcome of the calls, unless nil.
+	Health *health.Tracker
+}
+
+// Errors returned by calls, possibly wrapped.
+var (
+	// ErrClosed is returned by calls made after Close.
+	ErrClosed = errors.New("dtls client is closed")
+	// ErrUnavailable is returned if the request could not be sent and the DTLS session could not be re-established.
+	ErrUnavailable = errors.New("gateway unavailable")
+	// ErrTimeout is returned if the gateway did not respond in time.
+	ErrTimeout = errors.New("gateway did not respond in time")
+)
+
+// NewDtlsClient acts as factory function, returns a pointer to a connected DtlsClient or exits if the gateway is unreachable.
 func NewDtlsClient(gatewayAddress, clientID, psk string) *DtlsClient {
-	client := &DtlsClient{
+	client, err := DialDtlsClient(gatewayAddress, clientID, psk)
+	if err != nil {
+		slog.Error("Unable to connect to Gateway", slog.String("address", gatewayAddress), slog.Any("error", err))
+		os.Exit(1)
+	}
+	return client
+}
+
+// DialDtlsClient does the same as NewDtlsClient, but returns an error wrapping ErrUnavailable instead of
+// exiting if the DTLS session cannot be established.
+func DialDtlsClient(gatewayAddress, clientID, psk string) (*DtlsClient, error) {
+	return DialDtlsClientWithOptions(gatewayAddress, clientID, psk, Options{})
+}
+
+// DialDtlsClientWithOptions does the same as DialDtlsClient, with the passed options.
+func DialDtlsClientWithOptions(gatewayAddress, clientID, psk string, opts Options) (*DtlsClient, error) {
+	client := OpenDtlsClientWithOptions(gatewayAddress, clientID, psk, opts)
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// OpenDtlsClientWithOptions returns a client that is not connected yet. The DTLS session is established by
+// Connect, or by the first call.
+func OpenDtlsClientWithOptions(gatewayAddress, clientID, psk string, opts Options) *DtlsClient {
+	return &DtlsClient{
 		gatewayAddress: gatewayAddress,
 		clientID:       clientID,
 		psk:            psk,
+		opts:           opts,
 	}
-	client.connect()
-	return client
 }
 
-func (dc *DtlsClient) connect() {
-	dc.setupKeystore()
+// Connect establishes the DTLS session unless it is already up. The returned error wraps ErrUnavailable, or is
+// ErrClosed after Close.
+func (dc *DtlsClient) Connect() error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.closed {
+		return ErrClosed
+	}
+	if dc.peer != nil {
+		return nil
+	}
+	if err := dc.connect(); err != nil {
+		dc.setSession(health.SessionDown)
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return nil
+}
+
+func (dc *DtlsClient) connect() error {
+	if err := dc.setupKeystore(); err != nil {
+		return err
+	}
 
 	listener, err := dtls.NewUdpListener(":0", time.Second*900)
 	if err != nil {
-		panic(err.Error())
+		return err
 	}
 
 	peerParams := &dtls.PeerParams{
@@ -43,36 +125,148 @@ func (dc *DtlsClient) connect() {
 		HandshakeTimeout: time.Second * 15}
 	slog.Info("Connecting to peer", slog.String("address", dc.gatewayAddress))
 
-	dc.peer, err = listener.AddPeerWithParams(peerParams)
+	peer, err := listener.AddPeerWithParams(peerParams)
 	if err != nil {
-		slog.Error("Unable to connect to Gateway", slog.String("address", dc.gatewayAddress), slog.Any("error", err))
-		os.Exit(1)
+		_ = listener.Shutdown()
+		return err
 	}
-	dc.peer.UseQueue(true)
+	peer.UseQueue(true)
+	dc.listener = listener
+	dc.peer = peer
+	dc.setSession(health.SessionUp)
 	slog.Info("DTLS connection established", slog.String("address", dc.gatewayAddress))
+	return nil
+}
+
+// reconnect tears down the current DTLS session and establishes a new one. Must be called with dc.mu held.
+func (dc *DtlsClient) reconnect() error {
+	metrics.DTLSReconnected(dc.opts.Gateway)
+	if dc.listener != nil {
+		_ = dc.listener.Shutdown()
+	}
+	if err := dc.connect(); err != nil {
+		dc.setSession(health.SessionDown)
+		return err
+	}
+	return nil
 }
 
-// Call writes the supplied coap.Message to the peer
+// Close ends the DTLS session, notifying the gateway, and releases the UDP socket. Calls made after Close
+// fail with ErrClosed.
+func (dc *DtlsClient) Close() error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.closed {
+		return nil
+	}
+	dc.closed = true
+	dc.setSession(health.SessionClosed)
+	if dc.listener == nil {
+		return nil
+	}
+	_ = dc.listener.RemovePeer(dc.peer, dtls.AlertDesc_CloseNotify)
+	err := dc.listener.Shutdown()
+	dc.listener, dc.peer = nil, nil
+	slog.Info("DTLS connection closed", slog.String("address", dc.gatewayAddress))
+	return err
+}
+
+type pathLabelKey struct{}
+
+// WithPathLabel returns a context that makes calls report label as the path in the gateway call metrics instead
+// of the template of the requested path, e.g. metrics.RawPath for requests to arbitrary paths.
+func WithPathLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, pathLabelKey{}, label)
+}
+
+// Call writes the supplied coap.Message to the peer. If the gateway does not answer, the DTLS session is
+// re-established and the message is sent once more, except for POST messages which are not idempotent.
 func (dc *DtlsClient) Call(req coap.Message) (coap.Message, error) {
+	return dc.CallContext(context.Background(), req)
+}
+
+// CallContext does the same as Call, recording the exchange as a span that is a child of any span in ctx.
+func (dc *DtlsClient) CallContext(ctx context.Context, req coap.Message) (coap.Message, error) {
+	_, span := tracing.Tracer().Start(ctx, "CoAP "+req.Code.String(), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("coap.method", req.Code.String()),
+			attribute.String("coap.path", req.PathString()),
+			attribute.Int("coap.message_id", int(req.MessageID)),
+			attribute.Int("coap.request.payload_size", len(req.Payload)),
+		))
+	defer span.End()
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
 	slog.Info("Calling", slog.String("code", req.Code.String()), slog.String("path", req.PathString()))
-	data, err := req.MarshalBinary()
+	if len(req.Payload) > 0 && slog.Default().Enabled(ctx, slog.LevelDebug) {
+		slog.Debug("Request payload", slog.String("path", req.PathString()), slog.String("readable", model.ReadableOrRaw(req.Payload)))
+	}
+	start := time.Now()
+	msg, retransmissions, err := dc.call(req)
+	path, ok := ctx.Value(pathLabelKey{}).(string)
+	if !ok {
+		path = metrics.PathTemplate(req.PathString())
+	}
+	metrics.ObserveGatewayCall(dc.opts.Gateway, req.Code.String(), path, time.Since(start), err != nil || msg.Code >= coap.BadRequest)
+	if dc.opts.Health != nil {
+		dc.opts.Health.ObserveCall(err)
+	}
+
+	span.SetAttributes(attribute.Int("coap.retransmissions", retransmissions))
 	if err != nil {
-		return coap.Message{}, err
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return msg, err
 	}
-	err = dc.peer.Write(data)
+	span.SetAttributes(
+		attribute.String("coap.code", msg.Code.String()),
+		attribute.Int("coap.response.payload_size", len(msg.Payload)),
+	)
+	if msg.Code >= coap.BadRequest {
+		span.SetStatus(codes.Error, msg.Code.String())
+	}
+	return msg, nil
+}
 
+// call performs the exchange and returns the response along with the number of times the request was resent.
+func (dc *DtlsClient) call(req coap.Message) (coap.Message, int, error) {
+	if dc.closed {
+		return coap.Message{}, 0, ErrClosed
+	}
+	if dc.peer == nil {
+		// the gateway could not be reached so far
+		if err := dc.connect(); err != nil {
+			dc.setSession(health.SessionDown)
+			return coap.Message{}, 0, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+	}
+	data, err := req.MarshalBinary()
 	if err != nil {
-		return coap.Message{}, err
+		return coap.Message{}, 0, err
 	}
 
-	respData, err := dc.peer.Read(time.Second)
+	retransmissions := 0
+	respData, err := dc.exchange(data)
 	if err != nil {
-		return coap.Message{}, err
+		slog.Warn("Call to gateway failed, reconnecting", slog.String("path", req.PathString()), slog.Any("error", err))
+		if rerr := dc.reconnect(); rerr != nil {
+			return coap.Message{}, 0, fmt.Errorf("%w: %v (reconnect failed: %v)", ErrUnavailable, err, rerr)
+		}
+		if req.Code == coap.POST {
+			return coap.Message{}, 0, err
+		}
+		retransmissions++
+		respData, err = dc.exchange(data)
+		if err != nil {
+			return coap.Message{}, retransmissions, err
+		}
 	}
 
 	msg, err := coap.ParseMessage(respData)
 	if err != nil {
-		return coap.Message{}, err
+		return coap.Message{}, retransmissions, err
 	}
 
 	slog.Info("Response",
@@ -82,17 +276,31 @@ func (dc *DtlsClient) Call(req coap.Message) (coap.Message, error) {
 		slog.Any("token", msg.Token),
 		slog.String("payload", string(msg.Payload)),
 	)
+	if len(msg.Payload) > 0 && slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		slog.Debug("Response payload", slog.Any("messageID", msg.MessageID), slog.String("readable", model.ReadableOrRaw(msg.Payload)))
+	}
 
-	return msg, nil
+	return msg, retransmissions, nil
+}
+
+// exchange writes a marshalled message to the peer and waits for the response.
+func (dc *DtlsClient) exchange(data []byte) ([]byte, error) {
+	if err := dc.peer.Write(data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	resp, err := dc.peer.Read(time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+	return resp, nil
 }
 
 // BuildGETMessage produces a CoAP GET message with the next msgID set.
 func (dc *DtlsClient) BuildGETMessage(path string) coap.Message {
-	dc.msgID++
 	req := coap.Message{
 		Type:      coap.Confirmable,
 		Code:      coap.GET,
-		MessageID: dc.msgID,
+		MessageID: dc.nextMessageID(),
 	}
 	req.SetPathString(path)
 	return req
@@ -103,12 +311,10 @@ func (dc *DtlsClient) BuildGETMessage(path string) coap.Message {
 
 // BuildPUTMessage produces a CoAP PUT message with the next msgID set.
 func (dc *DtlsClient) BuildPUTMessage(path string, payload string) coap.Message {
-	dc.msgID++
-
 	req := coap.Message{
 		Type:      coap.Confirmable,
 		Code:      coap.PUT,
-		MessageID: dc.msgID,
+		MessageID: dc.nextMessageID(),
 		Payload:   []byte(payload),
 	}
 	req.SetPathString(path)
@@ -118,12 +324,10 @@ func (dc *DtlsClient) BuildPUTMessage(path string, payload string) coap.Message
 
 // BuildPOSTMessage produces a CoAP POST message with the next msgID set.
 func (dc *DtlsClient) BuildPOSTMessage(path string, payload string) coap.Message {
-	dc.msgID++
-
 	req := coap.Message{
 		Type:      coap.Confirmable,
 		Code:      coap.POST,
-		MessageID: dc.msgID,
+		MessageID: dc.nextMessageID(),
 		Payload:   []byte(payload),
 	}
 	req.SetPathString(path)
@@ -131,8 +335,67 @@ func (dc *DtlsClient) BuildPOSTMessage(path string, payload string) coap.Message
 	return req
 }
 
-func (dc *DtlsClient) setupKeystore() {
-	mks := dtls.NewKeystoreInMemory()
-	dtls.SetKeyStores([]dtls.Keystore{mks})
-	mks.AddKey(dc.clientID, []byte(dc.psk))
+// BuildMessage is a convenience method for creating a coap.Message with any method code and payload.
+func (dc *DtlsClient) BuildMessage(code coap.COAPCode, path string, payload []byte) coap.Message {
+	req := coap.Message{
+		Type:      coap.Confirmable,
+		Code:      code,
+		MessageID: dc.nextMessageID(),
+		Payload:   payload,
+	}
+	req.SetPathString(path)
+	return req
+}
+
+func (dc *DtlsClient) nextMessageID() uint16 {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.msgID++
+	return dc.msgID
+}
+
+func (dc *DtlsClient) setSession(state string) {
+	if dc.opts.Health != nil {
+		dc.opts.Health.SetSession(state)
+	}
+}
+
+// setupKeystore adds the PSK of the client to the keystore, which is installed on first use.
+func (dc *DtlsClient) setupKeystore() error {
+	// the dtls package looks the PSK up by the resolved address of the peer
+	addr, err := net.ResolveUDPAddr("udp", dc.gatewayAddress)
+	if err != nil {
+		return err
+	}
+	keys.add(dc.clientID, addr.String(), []byte(dc.psk))
+	installKeystore.Do(func() {
+		dtls.SetKeyStores([]dtls.Keystore{keys})
+	})
+	return nil
+}
+
+// keystore holds the PSKs of all gateways the process connects to. The dtls package has a single, global list
+// of keystores, so one keystore serves all clients. The PSKs are kept by client ID and gateway address, since
+// a client ID may be registered with several gateways.
+type keystore struct {
+	mu   sync.Mutex
+	psks map[string][]byte
+}
+
+var (
+	keys            = &keystore{psks: map[string][]byte{}}
+	installKeystore sync.Once
+)
+
+func (ks *keystore) add(identity, addr string, psk []byte) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.psks[identity+"@"+addr] = psk
+}
+
+// GetPsk implements dtls.Keystore.
+func (ks *keystore) GetPsk(identity string, remoteAddr string) ([]byte, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	return ks.psks[identity+"@"+remoteAddr], nil
 }
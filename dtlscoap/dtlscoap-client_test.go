@@ -0,0 +1,27 @@
+package dtlscoap
+
+import (
+	"testing"
+
+	"github.com/eriklupander/dtls"
+)
+
+func TestSetupKeystore(t *testing.T) {
+	house := &DtlsClient{gatewayAddress: "127.0.0.1:5684", clientID: "tradfri-go", psk: "house-psk"}
+	garage := &DtlsClient{gatewayAddress: "127.0.0.2:5684", clientID: "tradfri-go", psk: "garage-psk"}
+	for _, c := range []*DtlsClient{house, garage, house} {
+		if err := c.setupKeystore(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// reconnecting one gateway must not replace the PSK of the other
+	if psk := dtls.GetPskFromKeystore("tradfri-go", "127.0.0.1:5684"); string(psk) != "house-psk" {
+		t.Errorf("expected the PSK of the house, got %q", psk)
+	}
+	if psk := dtls.GetPskFromKeystore("tradfri-go", "127.0.0.2:5684"); string(psk) != "garage-psk" {
+		t.Errorf("expected the PSK of the garage, got %q", psk)
+	}
+	if psk := dtls.GetPskFromKeystore("other", "127.0.0.1:5684"); psk != nil {
+		t.Errorf("expected no PSK for an unknown client, got %q", psk)
+	}
+}
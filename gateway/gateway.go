@@ -0,0 +1,251 @@
+// Package gateway resolves the named gateways served by one process from the configuration and keeps a
+// registry of their clients.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// DefaultName is the name of the gateway configured by the top-level gateway_address, client_id and psk
+// settings, which is used when no "gateways" list is configured.
+const DefaultName = "default"
+
+// defaultPort is the CoAPS port of the gateway, appended to gateway_ip.
+const defaultPort = "5684"
+
+// validName restricts names to what can be used as a path segment and a header value without escaping.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Config is an entry of the "gateways" list of the configuration.
+type Config struct {
+	Name           string `mapstructure:"name"`
+	GatewayAddress string `mapstructure:"gateway_address"`
+	GatewayIP      string `mapstructure:"gateway_ip"`
+	ClientID       string `mapstructure:"client_id"`
+	PSK            string `mapstructure:"psk"`
+}
+
+// Address returns the address of the gateway including the port, derived from GatewayIP unless
+// GatewayAddress is set.
+func (c Config) Address() string {
+	if c.GatewayAddress != "" || c.GatewayIP == "" {
+		return c.GatewayAddress
+	}
+	return c.GatewayIP + ":" + defaultPort
+}
+
+// Validate checks that every gateway has a unique, valid name, an address and credentials.
+func Validate(configs []Config) error {
+	if len(configs) == 0 {
+		return errors.New("no gateway is configured")
+	}
+	seen := map[string]bool{}
+	for i, c := range configs {
+		switch {
+		case !validName.MatchString(c.Name):
+			return fmt.Errorf("gateway %d: invalid name %q, use up to 32 lower case letters, digits, '-' and '_'", i+1, c.Name)
+		case seen[c.Name]:
+			return fmt.Errorf("gateway %q is configured more than once", c.Name)
+		case c.Address() == "":
+			return fmt.Errorf("gateway %q: gateway_address or gateway_ip is required", c.Name)
+		case c.ClientID == "" || c.PSK == "":
+			return fmt.Errorf("gateway %q: client_id and psk are required, run --authenticate for it first", c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+// Find returns the configuration of the named gateway, or of the first one if name is empty.
+func Find(configs []Config, name string) (Config, error) {
+	for _, c := range configs {
+		if name == "" || c.Name == name {
+			return c, nil
+		}
+	}
+	return Config{}, fmt.Errorf("unknown gateway %q", name)
+}
+
+// Gateway is a configured gateway, whose client may not be connected yet.
+type Gateway struct {
+	Name   string
+	Client *tradfri.Client
+	// Watcher polls the gateway for state changes, see Registry.Run.
+	Watcher *tradfri.Watcher
+	// Health tracks the connectivity of the gateway.
+	Health *health.Tracker
+}
+
+// Registry holds the connected gateways by name. The first gateway is the default one, which serves the
+// requests that do not name a gateway.
+type Registry struct {
+	mu       sync.Mutex
+	gateways []*Gateway
+	// unreachable are the gateways Open could not connect to, which Run keeps trying to connect.
+	unreachable   []*Gateway
+	retryInterval time.Duration
+}
+
+// DefaultRetryInterval is how often a gateway that could not be reached at startup is tried again.
+const DefaultRetryInterval = 30 * time.Second
+
+// Options configures the gateways connected by Open.
+type Options struct {
+	// WatchInterval is how often the watchers poll the gateways.
+	WatchInterval time.Duration
+	// RetryInterval is how often Run tries to connect the gateways that could not be reached, defaults to
+	// DefaultRetryInterval.
+	RetryInterval time.Duration
+	// NewTracker returns the health tracker of a gateway, defaults to health.NewTracker.
+	NewTracker func() *health.Tracker
+}
+
+// connect establishes the DTLS session with a gateway, replaced in tests.
+var connect = func(g *Gateway) error {
+	return g.Client.Connect()
+}
+
+// Open registers all configured gateways, each with its own client, watcher and health tracker, and connects to
+// them. A gateway that cannot be reached is registered anyway and reported as not ready by its tracker, Run
+// connects it in the background. Only an invalid configuration is an error.
+func Open(configs []Config, opts Options) (*Registry, error) {
+	if err := Validate(configs); err != nil {
+		return nil, err
+	}
+	if opts.NewTracker == nil {
+		opts.NewTracker = health.NewTracker
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultRetryInterval
+	}
+	r := &Registry{retryInterval: opts.RetryInterval}
+	for _, c := range configs {
+		tracker := opts.NewTracker()
+		client := tradfri.OpenTradfriClientWithOptions(c.Address(), c.ClientID, c.PSK, dtlscoap.Options{Gateway: c.Name, Health: tracker})
+		g := &Gateway{Name: c.Name, Client: client, Watcher: tradfri.NewWatcher(client, opts.WatchInterval), Health: tracker}
+		if err := r.Add(g); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		if err := connect(g); err != nil {
+			slog.Warn("Unable to connect to gateway, retrying in the background", slog.String("gateway", c.Name),
+				slog.Duration("interval", opts.RetryInterval), slog.Any("error", err))
+			r.unreachable = append(r.unreachable, g)
+		}
+	}
+	return r, nil
+}
+
+// Add registers a gateway, whose name must be unique.
+func (r *Registry) Add(g *Gateway) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.gateways {
+		if existing.Name == g.Name {
+			return fmt.Errorf("gateway %q is registered more than once", g.Name)
+		}
+	}
+	r.gateways = append(r.gateways, g)
+	return nil
+}
+
+// Get returns the named gateway.
+func (r *Registry) Get(name string) (*Gateway, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, g := range r.gateways {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// Default returns the first gateway, nil if there is none.
+func (r *Registry) Default() *Gateway {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.gateways) == 0 {
+		return nil
+	}
+	return r.gateways[0]
+}
+
+// All returns the gateways in the configured order.
+func (r *Registry) All() []*Gateway {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Gateway(nil), r.gateways...)
+}
+
+// Run runs the watchers of all gateways and connects the gateways Open could not reach until ctx is cancelled.
+func (r *Registry) Run(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	for _, g := range r.All() {
+		if g.Watcher != nil {
+			wg.Go(func() { g.Watcher.Run(ctx) })
+		}
+	}
+	r.mu.Lock()
+	unreachable := r.unreachable
+	r.unreachable = nil
+	r.mu.Unlock()
+	for _, g := range unreachable {
+		wg.Go(func() { r.retry(ctx, g) })
+	}
+	wg.Wait()
+}
+
+// retry tries to connect g every retryInterval until it succeeds or ctx is cancelled. Calls made in the
+// meantime try to connect as well, so the gateway may already be connected.
+func (r *Registry) retry(ctx context.Context, g *Gateway) {
+	ticker := time.NewTicker(r.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := connect(g); err != nil {
+				slog.Debug("Gateway still unreachable", slog.String("gateway", g.Name), slog.Any("error", err))
+				continue
+			}
+			slog.Info("Connected to gateway", slog.String("gateway", g.Name))
+			return
+		}
+	}
+}
+
+// Close ends the DTLS sessions with all gateways.
+func (r *Registry) Close() error {
+	var errs []error
+	for _, g := range r.All() {
+		if g.Client != nil {
+			if err := g.Client.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("gateway %q: %w", g.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Health returns the health trackers of the gateways by name, leaving out gateways without one.
+func (r *Registry) Health() map[string]*health.Tracker {
+	trackers := map[string]*health.Tracker{}
+	for _, g := range r.All() {
+		if g.Health != nil {
+			trackers[g.Name] = g.Health
+		}
+	}
+	return trackers
+}
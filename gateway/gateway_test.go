@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/health"
+)
+
+func TestAddress(t *testing.T) {
+	if a := (Config{GatewayIP: "192.168.1.10"}).Address(); a != "192.168.1.10:5684" {
+		t.Errorf("expected the default port to be appended, got %q", a)
+	}
+	if a := (Config{GatewayIP: "192.168.1.10", GatewayAddress: "10.0.0.2:5685"}).Address(); a != "10.0.0.2:5685" {
+		t.Errorf("expected gateway_address to take precedence, got %q", a)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Config{Name: "house", GatewayIP: "192.168.1.10", ClientID: "client", PSK: "secret"}
+	garage := valid
+	garage.Name = "garage"
+	if err := Validate([]Config{valid, garage}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		configs []Config
+		err     string
+	}{
+		{nil, "no gateway"},
+		{[]Config{valid, valid}, "more than once"},
+		{[]Config{{Name: "House", GatewayIP: "a", ClientID: "c", PSK: "p"}}, "invalid name"},
+		{[]Config{{Name: "gw/1", GatewayIP: "a", ClientID: "c", PSK: "p"}}, "invalid name"},
+		{[]Config{{Name: "house", ClientID: "c", PSK: "p"}}, "gateway_address or gateway_ip"},
+		{[]Config{{Name: "house", GatewayIP: "a", ClientID: "c"}}, "client_id and psk"},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.configs); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%+v: expected error containing %q, got %v", tt.configs, tt.err, err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	configs := []Config{{Name: "house"}, {Name: "garage"}}
+	if c, err := Find(configs, ""); err != nil || c.Name != "house" {
+		t.Errorf("expected the first gateway by default, got %+v, %v", c, err)
+	}
+	if c, err := Find(configs, "garage"); err != nil || c.Name != "garage" {
+		t.Errorf("expected garage, got %+v, %v", c, err)
+	}
+	if _, err := Find(configs, "shed"); err == nil {
+		t.Error("expected an error for an unknown gateway")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := &Registry{}
+	if r.Default() != nil {
+		t.Fatal("expected no default gateway")
+	}
+	if err := r.Add(&Gateway{Name: "house"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(&Gateway{Name: "garage", Health: health.NewTracker()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(&Gateway{Name: "house"}); err == nil {
+		t.Fatal("expected an error registering a name twice")
+	}
+	if g := r.Default(); g.Name != "house" {
+		t.Fatalf("expected house to be the default, got %s", g.Name)
+	}
+	if g, ok := r.Get("garage"); !ok || g.Name != "garage" {
+		t.Fatalf("expected garage, got %+v", g)
+	}
+	if _, ok := r.Get("shed"); ok {
+		t.Fatal("expected no shed")
+	}
+	if all := r.All(); len(all) != 2 || all[1].Name != "garage" {
+		t.Fatalf("unexpected gateways %+v", all)
+	}
+	if trackers := r.Health(); len(trackers) != 1 || trackers["garage"] == nil {
+		t.Fatalf("expected the tracker of the garage, got %v", trackers)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// withConnect replaces the DTLS handshake, failing it for the named gateways until they are removed from down.
+func withConnect(t *testing.T, down map[string]bool) *sync.Mutex {
+	t.Helper()
+	mu := &sync.Mutex{}
+	c := connect
+	connect = func(g *Gateway) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if down[g.Name] {
+			return dtlscoap.ErrUnavailable
+		}
+		g.Health.SetSession(health.SessionUp)
+		return nil
+	}
+	t.Cleanup(func() { connect = c })
+	return mu
+}
+
+func TestOpen_UnreachableGateway(t *testing.T) {
+	down := map[string]bool{"garage": true}
+	mu := withConnect(t, down)
+	configs := []Config{
+		{Name: "house", GatewayAddress: "127.0.0.1:5684", ClientID: "client", PSK: "secret"},
+		{Name: "garage", GatewayAddress: "127.0.0.2:5684", ClientID: "client", PSK: "secret"},
+	}
+	r, err := Open(configs, Options{WatchInterval: time.Hour, RetryInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("expected the reachable gateway to be served, got %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if all := r.All(); len(all) != 2 {
+		t.Fatalf("expected both gateways to be registered, got %d", len(all))
+	}
+	trackers := r.Health()
+	if !trackers["house"].Check().Ready {
+		t.Fatal("expected the house to be ready")
+	}
+	if trackers["garage"].Check().Ready {
+		t.Fatal("expected the garage not to be ready")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+	time.Sleep(50 * time.Millisecond)
+	if trackers["garage"].Check().Ready {
+		t.Fatal("expected the garage to stay not ready while it is unreachable")
+	}
+	mu.Lock()
+	delete(down, "garage")
+	mu.Unlock()
+	deadline := time.Now().Add(time.Second)
+	for !trackers["garage"].Check().Ready {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the garage to be connected in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	if _, err := Open(nil, Options{}); err == nil {
+		t.Fatal("expected an error without gateways")
+	}
+}
@@ -0,0 +1,155 @@
+package grpc_server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type target int
+
+const (
+	noTarget target = iota
+	deviceTarget
+	groupTarget
+)
+
+// methodRule defines the scope required for a method and which kind of ID in the request is subject to
+// the device and group allow-lists of the API key.
+type methodRule struct {
+	scope  auth.Scope
+	target target
+}
+
+// methodRules covers all TradfriService methods. Methods of TradfriService missing here require the admin
+// scope, methods of other services such as reflection the read scope, except for the public health service.
+var methodRules = map[string]methodRule{
+	pb.TradfriService_ListGroups_FullMethodName:                   {auth.ScopeRead, noTarget},
+	pb.TradfriService_GetGroup_FullMethodName:                     {auth.ScopeRead, groupTarget},
+	pb.TradfriService_ListDevices_FullMethodName:                  {auth.ScopeRead, groupTarget},
+	pb.TradfriService_ListDeviceIDs_FullMethodName:                {auth.ScopeRead, groupTarget},
+	pb.TradfriService_ListAllDevices_FullMethodName:               {auth.ScopeRead, noTarget},
+	pb.TradfriService_GetDevice_FullMethodName:                    {auth.ScopeRead, deviceTarget},
+	pb.TradfriService_ListGateways_FullMethodName:                 {auth.ScopeRead, noTarget},
+	pb.TradfriService_WatchDevices_FullMethodName:                 {auth.ScopeRead, noTarget},
+	pb.TradfriService_WatchGroups_FullMethodName:                  {auth.ScopeRead, noTarget},
+	pb.TradfriService_ChangeDeviceColor_FullMethodName:            {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_ChangeDeviceDimming_FullMethodName:          {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_TurnDeviceOn_FullMethodName:                 {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_TurnDeviceOff_FullMethodName:                {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_ChangeDeviceState_FullMethodName:            {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_ChangeDeviceColorHSL_FullMethodName:         {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_ChangeDeviceColorTemperature_FullMethodName: {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_TurnOutletOn_FullMethodName:                 {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_TurnOutletOff_FullMethodName:                {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_ChangeDevicePositioning_FullMethodName:      {auth.ScopeControl, deviceTarget},
+	pb.TradfriService_TurnGroupOn_FullMethodName:                  {auth.ScopeControl, groupTarget},
+	pb.TradfriService_TurnGroupOff_FullMethodName:                 {auth.ScopeControl, groupTarget},
+	pb.TradfriService_ChangeGroupDimming_FullMethodName:           {auth.ScopeControl, groupTarget},
+	pb.TradfriService_ActivateGroupScene_FullMethodName:           {auth.ScopeControl, groupTarget},
+	pb.TradfriService_Batch_FullMethodName:                        {auth.ScopeControl, noTarget},
+	pb.TradfriService_RawRequest_FullMethodName:                   {auth.ScopeAdmin, noTarget},
+}
+
+// isPublic reports whether the method may be called without an API key, which is the case for the health
+// service used by probes.
+func isPublic(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+func ruleFor(fullMethod string) methodRule {
+	if rule, ok := methodRules[fullMethod]; ok {
+		return rule
+	}
+	if strings.HasPrefix(fullMethod, "/"+pb.TradfriService_ServiceDesc.ServiceName+"/") {
+		return methodRule{scope: auth.ScopeAdmin}
+	}
+	return methodRule{scope: auth.ScopeRead}
+}
+
+// AuthUnaryInterceptor authenticates unary calls using the API key passed in the "authorization" (as
+// "Bearer <key>") or "x-api-key" metadata. A nil authenticator disables authentication.
+func AuthUnaryInterceptor(a *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if a == nil || isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		principal, err := authorize(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTarget(principal, ruleFor(info.FullMethod).target, req); err != nil {
+			return nil, err
+		}
+		return handler(auth.NewContext(ctx, principal), req)
+	}
+}
+
+// AuthStreamInterceptor authenticates streaming calls like AuthUnaryInterceptor. The allow-lists of the API key
+// are applied by the streaming methods themselves.
+func AuthStreamInterceptor(a *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil || isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		principal, err := authorize(ss.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		wrapped := middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = auth.NewContext(ss.Context(), principal)
+		return handler(srv, wrapped)
+	}
+}
+
+func authorize(ctx context.Context, a *auth.Authenticator, fullMethod string) (*auth.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	key := ""
+	if values := md.Get("authorization"); len(values) > 0 {
+		key = auth.TokenFromHeader(values[0])
+	}
+	if values := md.Get("x-api-key"); key == "" && len(values) > 0 {
+		key = values[0]
+	}
+	return authorizeKey(a, key, fullMethod)
+}
+
+// authorizeKey resolves the API key and checks that it has the scope required by the method.
+func authorizeKey(a *auth.Authenticator, key, fullMethod string) (*auth.Principal, error) {
+	principal, err := a.Authenticate(key)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if scope := ruleFor(fullMethod).scope; !principal.Allows(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key lacks the %s scope", scope)
+	}
+	return principal, nil
+}
+
+func checkTarget(principal *auth.Principal, t target, req any) error {
+	switch t {
+	case deviceTarget:
+		if r, ok := req.(interface{ GetId() int32 }); ok && !principal.AllowsDevice(int(r.GetId())) {
+			return status.Error(codes.PermissionDenied, "API key may not access this device")
+		}
+	case groupTarget:
+		id := int32(0)
+		if r, ok := req.(interface{ GetGroupId() int32 }); ok {
+			id = r.GetGroupId()
+		} else if r, ok := req.(interface{ GetId() int32 }); ok {
+			id = r.GetId()
+		}
+		if !principal.AllowsGroup(int(id)) {
+			return status.Error(codes.PermissionDenied, "API key may not access this group")
+		}
+	}
+	return nil
+}
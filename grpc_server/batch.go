@@ -0,0 +1,70 @@
+package grpc_server
+
+import (
+	"context"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/batch"
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var batchStatuses = map[string]pb.BatchResult_Status{
+	model.BatchStatusOK:      pb.BatchResult_OK,
+	model.BatchStatusFailed:  pb.BatchResult_FAILED,
+	model.BatchStatusSkipped: pb.BatchResult_SKIPPED,
+}
+
+func (s *server) Batch(ctx context.Context, r *pb.BatchRequest) (*pb.BatchResponse, error) {
+	req := model.BatchRequest{StopOnError: r.GetStopOnError()}
+	for _, op := range r.GetOperations() {
+		req.Operations = append(req.Operations, toBatchOperation(op))
+	}
+	if err := batch.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := batch.Authorize(auth.FromContext(ctx), req); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := batch.Run(ctx, client, req)
+	results := make([]*pb.BatchResult, 0, len(res.Results))
+	for _, r := range res.Results {
+		results = append(results, &pb.BatchResult{
+			Index:      int32(r.Index),
+			Status:     batchStatuses[r.Status],
+			Result:     r.Result,
+			Error:      r.Error,
+			DurationMs: r.DurationMs,
+		})
+	}
+	return &pb.BatchResponse{Results: results, DurationMs: res.DurationMs}, nil
+}
+
+func toBatchOperation(op *pb.BatchOperation) model.BatchOperation {
+	return model.BatchOperation{
+		DeviceId:    int(op.GetDeviceId()),
+		GroupId:     int(op.GetGroupId()),
+		Power:       toIntPtr(op.Power),
+		Dimmer:      toIntPtr(op.Dimmer),
+		RGBcolor:    op.GetRgbColor(),
+		X:           toIntPtr(op.X),
+		Y:           toIntPtr(op.Y),
+		Positioning: op.Positioning,
+		SceneId:     toIntPtr(op.SceneId),
+	}
+}
+
+func toIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
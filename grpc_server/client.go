@@ -0,0 +1,274 @@
+package grpc_server
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client is a Go client of the tradfri gRPC API. Its methods mirror those of tradfri.Client, so that programs
+// can talk to a tradfri-go server instead of the gateway. Reads return the protobuf messages of the API.
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.TradfriServiceClient
+	apiKey  string
+	gateway string
+	ctx     context.Context
+}
+
+// Dial connects to the gRPC server at target. The API key is sent with every call unless empty. Without
+// options the connection is unencrypted, pass grpc.WithTransportCredentials to use TLS.
+func Dial(target, apiKey string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := NewClient(conn, apiKey)
+	c.conn = conn
+	return c, nil
+}
+
+// NewClient returns a client using an existing connection, which is not closed by Close.
+func NewClient(conn grpc.ClientConnInterface, apiKey string) *Client {
+	return &Client{service: pb.NewTradfriServiceClient(conn), apiKey: apiKey, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the client whose calls use ctx, e.g. for deadlines and cancellation.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// WithGateway returns a copy of the client whose calls go to the named gateway of a server serving several
+// gateways, instead of the default one.
+func (c *Client) WithGateway(name string) *Client {
+	cc := *c
+	cc.gateway = name
+	return &cc
+}
+
+// Service returns the generated client, e.g. for the Watch and Batch RPCs.
+func (c *Client) Service() pb.TradfriServiceClient {
+	return c.service
+}
+
+// Close closes the connection opened by Dial.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (c *Client) context() context.Context {
+	ctx := c.ctx
+	if c.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.apiKey)
+	}
+	if c.gateway != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, GatewayMetadata, c.gateway)
+	}
+	return ctx
+}
+
+// ListGateways lists the gateways served by the server.
+func (c *Client) ListGateways() ([]*pb.Gateway, error) {
+	res, err := c.service.ListGateways(c.context(), &pb.ListGatewaysRequest{})
+	return res.GetGateways(), err
+}
+
+// ListGroups lists all groups.
+func (c *Client) ListGroups() ([]*pb.Group, error) {
+	res, err := c.service.ListGroups(c.context(), &pb.ListGroupsRequest{})
+	return res.GetGroups(), err
+}
+
+// GetGroup gets the specified group.
+func (c *Client) GetGroup(groupId int) (*pb.Group, error) {
+	res, err := c.service.GetGroup(c.context(), &pb.GetGroupRequest{Id: int32(groupId)})
+	return res.GetGroup(), err
+}
+
+// GetDevice gets the specified device.
+func (c *Client) GetDevice(deviceId int) (*pb.Device, error) {
+	res, err := c.service.GetDevice(c.context(), &pb.GetDeviceRequest{Id: int32(deviceId)})
+	return res.GetDevice(), err
+}
+
+// ListDevices lists all devices.
+func (c *Client) ListDevices() ([]*pb.Device, error) {
+	res, err := c.service.ListAllDevices(c.context(), &pb.ListAllDevicesRequest{})
+	return res.GetDevices(), err
+}
+
+// ListDeviceIds lists the ids of all devices.
+func (c *Client) ListDeviceIds() ([]int, error) {
+	devices, err := c.ListDevices()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(devices))
+	for _, d := range devices {
+		ids = append(ids, int(d.GetMetadata().GetId()))
+	}
+	return ids, nil
+}
+
+// ListGroupDevices lists the devices of the specified group.
+func (c *Client) ListGroupDevices(groupId int) ([]*pb.Device, error) {
+	res, err := c.service.ListDevices(c.context(), &pb.ListDevicesRequest{GroupId: int32(groupId)})
+	return res.GetDevices(), err
+}
+
+// PutDeviceDimming sets the dimming property (0-254) of the specified device.
+func (c *Client) PutDeviceDimming(deviceId int, dimming int) error {
+	_, err := c.service.ChangeDeviceDimming(c.context(), &pb.ChangeDeviceDimmingRequest{Id: int32(deviceId), Value: int32(dimming)})
+	return err
+}
+
+// PutDevicePower switches the power state of the specified device to on (1) or off (0).
+func (c *Client) PutDevicePower(deviceId int, power int) error {
+	var err error
+	switch power {
+	case 1:
+		_, err = c.service.TurnDeviceOn(c.context(), &pb.TurnDeviceOnRequest{Id: int32(deviceId)})
+	case 0:
+		_, err = c.service.TurnDeviceOff(c.context(), &pb.TurnDeviceOffRequest{Id: int32(deviceId)})
+	default:
+		err = fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	return err
+}
+
+// PutOutletPower switches the power state of the specified power outlet to on (1) or off (0).
+func (c *Client) PutOutletPower(deviceId int, power int) error {
+	var err error
+	switch power {
+	case 1:
+		_, err = c.service.TurnOutletOn(c.context(), &pb.TurnOutletOnRequest{Id: int32(deviceId)})
+	case 0:
+		_, err = c.service.TurnOutletOff(c.context(), &pb.TurnOutletOffRequest{Id: int32(deviceId)})
+	default:
+		err = fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	return err
+}
+
+// PutDeviceState changes both power (1 or 0) and dimmer (0-254) of the specified device with one call.
+func (c *Client) PutDeviceState(deviceId int, power int, dimmer int) error {
+	if !(power == 1 || power == 0) {
+		return fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	_, err := c.service.ChangeDeviceState(c.context(), &pb.ChangeDeviceStateRequest{Id: int32(deviceId), Power: power == 1, Dimmer: int32(dimmer)})
+	return err
+}
+
+// PutDeviceColor sets the CIE 1931 color space x/y color, x and y must be between 0-65535.
+func (c *Client) PutDeviceColor(deviceId int, x, y int) error {
+	return c.PutDeviceColorTimed(deviceId, x, y, 0)
+}
+
+// PutDeviceColorTimed does the same as PutDeviceColor with a transition time, 0 selects the default.
+func (c *Client) PutDeviceColorTimed(deviceId int, x, y int, transitionTimeMS int) error {
+	_, err := c.service.ChangeDeviceColor(c.context(), &pb.ChangeDeviceColorRequest{
+		Id: int32(deviceId), Xcolor: int32(x), Ycolor: int32(y), TransitionTimeMs: int32(transitionTimeMS),
+	})
+	return err
+}
+
+// PutDeviceColorRGB sets the color of the bulb using an RGB hex string such as 8f2686.
+func (c *Client) PutDeviceColorRGB(deviceId int, rgb string) error {
+	return c.PutDeviceColorRGBTimed(deviceId, rgb, 0)
+}
+
+// PutDeviceColorRGBTimed does the same as PutDeviceColorRGB with a transition time, 0 selects the default.
+func (c *Client) PutDeviceColorRGBTimed(deviceId int, rgb string, transitionTimeMS int) error {
+	_, err := c.service.ChangeDeviceColor(c.context(), &pb.ChangeDeviceColorRequest{
+		Id: int32(deviceId), Rgb: rgb, TransitionTimeMs: int32(transitionTimeMS),
+	})
+	return err
+}
+
+// PutDeviceColorRGBInt does the same as PutDeviceColorRGB with the red, green and blue values from 0 to 255.
+func (c *Client) PutDeviceColorRGBInt(deviceId int, r, g, b int) error {
+	return c.PutDeviceColorRGBIntTimed(deviceId, r, g, b, 0)
+}
+
+// PutDeviceColorRGBIntTimed does the same as PutDeviceColorRGBInt with a transition time, 0 selects the default.
+func (c *Client) PutDeviceColorRGBIntTimed(deviceId int, r, g, b int, transitionTimeMS int) error {
+	return c.PutDeviceColorRGBTimed(deviceId, fmt.Sprintf("%02x%02x%02x", r, g, b), transitionTimeMS)
+}
+
+// PutDeviceColorHSL sets the color of the bulb using the HSL color notation, hue in degrees and saturation
+// and lightness in percent.
+func (c *Client) PutDeviceColorHSL(deviceId int, hue, saturation, lightness float64) error {
+	return c.PutDeviceColorHSLTimed(deviceId, hue, saturation, lightness, 0)
+}
+
+// PutDeviceColorHSLTimed does the same as PutDeviceColorHSL with a transition time, 0 selects the default.
+func (c *Client) PutDeviceColorHSLTimed(deviceId int, hue, saturation, lightness float64, transitionTimeMS int) error {
+	_, err := c.service.ChangeDeviceColorHSL(c.context(), &pb.ChangeDeviceColorHSLRequest{
+		Id: int32(deviceId), Hue: hue, Saturation: saturation, Lightness: lightness, TransitionTimeMs: int32(transitionTimeMS),
+	})
+	return err
+}
+
+// PutDeviceColorTemperature sets the color temperature of a white spectrum bulb in Kelvin.
+func (c *Client) PutDeviceColorTemperature(deviceId int, kelvin int) error {
+	return c.PutDeviceColorTemperatureTimed(deviceId, kelvin, 0)
+}
+
+// PutDeviceColorTemperatureTimed does the same as PutDeviceColorTemperature with a transition time, 0 selects
+// the default.
+func (c *Client) PutDeviceColorTemperatureTimed(deviceId int, kelvin int, transitionTimeMS int) error {
+	_, err := c.service.ChangeDeviceColorTemperature(c.context(), &pb.ChangeDeviceColorTemperatureRequest{
+		Id: int32(deviceId), Kelvin: int32(kelvin), TransitionTimeMs: int32(transitionTimeMS),
+	})
+	return err
+}
+
+// PutDevicePositioning sets the position (0-100) of the specified blind.
+func (c *Client) PutDevicePositioning(deviceId int, positioning float32) error {
+	_, err := c.service.ChangeDevicePositioning(c.context(), &pb.ChangeDevicePositioningRequest{Id: int32(deviceId), Position: &positioning})
+	return err
+}
+
+// PutGroupPower switches all devices of the specified group on (1) or off (0).
+func (c *Client) PutGroupPower(groupId int, power int) error {
+	var err error
+	switch power {
+	case 1:
+		_, err = c.service.TurnGroupOn(c.context(), &pb.TurnGroupOnRequest{Id: int32(groupId)})
+	case 0:
+		_, err = c.service.TurnGroupOff(c.context(), &pb.TurnGroupOffRequest{Id: int32(groupId)})
+	default:
+		err = fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	return err
+}
+
+// PutGroupDimming sets the dimming property (0-254) of all devices of the specified group.
+func (c *Client) PutGroupDimming(groupId int, dimming int) error {
+	_, err := c.service.ChangeGroupDimming(c.context(), &pb.ChangeGroupDimmingRequest{Id: int32(groupId), Value: int32(dimming)})
+	return err
+}
+
+// PutGroupScene activates a scene of the specified group, switching it on.
+func (c *Client) PutGroupScene(groupId int, sceneId int) error {
+	_, err := c.service.ActivateGroupScene(c.context(), &pb.ActivateGroupSceneRequest{Id: int32(groupId), SceneId: int32(sceneId)})
+	return err
+}
+
+// RawRequest forwards a CoAP request to an arbitrary gateway path, which requires an API key with the admin
+// scope.
+func (c *Client) RawRequest(method, path string, payload []byte) (*pb.RawRequestResponse, error) {
+	return c.service.RawRequest(c.context(), &pb.RawRequestRequest{Method: method, Path: path, Payload: payload})
+}
@@ -0,0 +1,143 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/gateway"
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/grpc_server/golang/golangconnect"
+	"github.com/eriklupander/tradfri-go/metrics"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ConnectPath is the path prefix of the TradfriService procedures served by NewConnectHandler.
+const ConnectPath = "/" + golangconnect.TradfriServiceName + "/"
+
+// maxConnectMessage limits the size of request messages, like the body limit of the REST API.
+const maxConnectMessage = 1 << 20
+
+// NewConnectHandler returns an HTTP handler serving TradfriService over the Connect, gRPC-Web and gRPC
+// protocols at ConnectPath, so that browsers and curl can call it without a proxy. It shares the
+// implementation of the gRPC server and applies the same authentication and metrics. A nil authenticator
+// disables authentication.
+func NewConnectHandler(tradfriClient *tradfri.Client, watcher *tradfri.Watcher, a *auth.Authenticator) http.Handler {
+	return NewGatewaysConnectHandler([]Gateway{{Name: gateway.DefaultName, Client: tradfriClient, Watcher: watcher}}, a)
+}
+
+// NewGatewaysConnectHandler is NewConnectHandler for several gateways, selected by the Tradfri-Gateway header.
+func NewGatewaysConnectHandler(gateways []Gateway, a *auth.Authenticator) http.Handler {
+	return newConnectHandler(&server{gateways: gateways}, a)
+}
+
+func newConnectHandler(s *server, a *auth.Authenticator) http.Handler {
+	_, handler := golangconnect.NewTradfriServiceHandler(connectServer{s},
+		connect.WithInterceptors(connectInterceptor{authenticator: a}),
+		connect.WithReadMaxBytes(maxConnectMessage),
+	)
+	return handler
+}
+
+// connectServer adapts the streaming methods of server to the Connect handler interface, the unary methods
+// have the same signature.
+type connectServer struct {
+	*server
+}
+
+func (s connectServer) WatchDevices(ctx context.Context, r *pb.WatchDevicesRequest, stream *connect.ServerStream[pb.Device]) error {
+	return s.server.WatchDevices(r, connectStream[pb.Device]{ctx: ctx, stream: stream})
+}
+
+func (s connectServer) WatchGroups(ctx context.Context, r *pb.WatchGroupsRequest, stream *connect.ServerStream[pb.Group]) error {
+	return s.server.WatchGroups(r, connectStream[pb.Group]{ctx: ctx, stream: stream})
+}
+
+// connectStream passes the messages sent to a gRPC server stream on to a Connect stream.
+type connectStream[T any] struct {
+	grpc.ServerStream
+	ctx    context.Context
+	stream *connect.ServerStream[T]
+}
+
+func (s connectStream[T]) Context() context.Context { return s.ctx }
+func (s connectStream[T]) Send(msg *T) error        { return s.stream.Send(msg) }
+
+// connectInterceptor does for Connect what the auth and metrics interceptors do for gRPC, and converts the
+// gRPC status errors of server into Connect errors.
+type connectInterceptor struct {
+	authenticator *auth.Authenticator
+}
+
+func (i connectInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		start := time.Now()
+		procedure := req.Spec().Procedure
+		var res connect.AnyResponse
+		principal, err := i.authorize(procedure, req.Header())
+		if err == nil && principal != nil {
+			err = checkTarget(principal, ruleFor(procedure).target, req.Any())
+			ctx = auth.NewContext(ctx, principal)
+		}
+		if err == nil {
+			res, err = next(withGatewayMetadata(ctx, req.Header()), req)
+		}
+		metrics.ObserveGRPCRequest(procedure, status.Code(err).String(), time.Since(start))
+		return res, toConnectError(err)
+	}
+}
+
+func (i connectInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i connectInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		procedure := conn.Spec().Procedure
+		principal, err := i.authorize(procedure, conn.RequestHeader())
+		if err == nil {
+			if principal != nil {
+				ctx = auth.NewContext(ctx, principal)
+			}
+			err = next(withGatewayMetadata(ctx, conn.RequestHeader()), conn)
+		}
+		metrics.ObserveGRPCRequest(procedure, status.Code(err).String(), time.Since(start))
+		return toConnectError(err)
+	}
+}
+
+// authorize resolves the API key of the request headers, returning no principal if authentication is
+// disabled.
+func (i connectInterceptor) authorize(procedure string, header http.Header) (*auth.Principal, error) {
+	if i.authenticator == nil {
+		return nil, nil
+	}
+	key := auth.TokenFromHeader(header.Get("Authorization"))
+	if key == "" {
+		key = header.Get("X-API-Key")
+	}
+	return authorizeKey(i.authenticator, key, procedure)
+}
+
+// withGatewayMetadata passes the gateway header on as the metadata read by server.gateway.
+func withGatewayMetadata(ctx context.Context, header http.Header) context.Context {
+	if name := header.Get(GatewayMetadata); name != "" {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(GatewayMetadata, name))
+	}
+	return ctx
+}
+
+// toConnectError converts a gRPC status error, the codes of both protocols are the same.
+func toConnectError(err error) error {
+	if st, ok := status.FromError(err); ok && err != nil {
+		return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	}
+	return err
+}
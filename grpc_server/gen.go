@@ -1,2 +1,2 @@
-//go:generate protoc --go_out=golang/ --go_opt=paths=source_relative --go-grpc_out=golang/ --go-grpc_opt=require_unimplemented_servers=false,paths=source_relative tradfri.proto
+//go:generate protoc --go_out=golang/ --go_opt=paths=source_relative --go-grpc_out=golang/ --go-grpc_opt=require_unimplemented_servers=false,paths=source_relative --connect-go_out=golang/ --connect-go_opt=paths=source_relative,simple=true tradfri.proto
 package grpc_server
@@ -0,0 +1,915 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: tradfri.proto
+
+package golangconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	golang "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TradfriServiceName is the fully-qualified name of the TradfriService service.
+	TradfriServiceName = "grpc_server.TradfriService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TradfriServiceListGroupsProcedure is the fully-qualified name of the TradfriService's ListGroups
+	// RPC.
+	TradfriServiceListGroupsProcedure = "/grpc_server.TradfriService/ListGroups"
+	// TradfriServiceGetGroupProcedure is the fully-qualified name of the TradfriService's GetGroup RPC.
+	TradfriServiceGetGroupProcedure = "/grpc_server.TradfriService/GetGroup"
+	// TradfriServiceListDevicesProcedure is the fully-qualified name of the TradfriService's
+	// ListDevices RPC.
+	TradfriServiceListDevicesProcedure = "/grpc_server.TradfriService/ListDevices"
+	// TradfriServiceListDeviceIDsProcedure is the fully-qualified name of the TradfriService's
+	// ListDeviceIDs RPC.
+	TradfriServiceListDeviceIDsProcedure = "/grpc_server.TradfriService/ListDeviceIDs"
+	// TradfriServiceListAllDevicesProcedure is the fully-qualified name of the TradfriService's
+	// ListAllDevices RPC.
+	TradfriServiceListAllDevicesProcedure = "/grpc_server.TradfriService/ListAllDevices"
+	// TradfriServiceGetDeviceProcedure is the fully-qualified name of the TradfriService's GetDevice
+	// RPC.
+	TradfriServiceGetDeviceProcedure = "/grpc_server.TradfriService/GetDevice"
+	// TradfriServiceChangeDeviceColorProcedure is the fully-qualified name of the TradfriService's
+	// ChangeDeviceColor RPC.
+	TradfriServiceChangeDeviceColorProcedure = "/grpc_server.TradfriService/ChangeDeviceColor"
+	// TradfriServiceChangeDeviceDimmingProcedure is the fully-qualified name of the TradfriService's
+	// ChangeDeviceDimming RPC.
+	TradfriServiceChangeDeviceDimmingProcedure = "/grpc_server.TradfriService/ChangeDeviceDimming"
+	// TradfriServiceTurnDeviceOnProcedure is the fully-qualified name of the TradfriService's
+	// TurnDeviceOn RPC.
+	TradfriServiceTurnDeviceOnProcedure = "/grpc_server.TradfriService/TurnDeviceOn"
+	// TradfriServiceTurnDeviceOffProcedure is the fully-qualified name of the TradfriService's
+	// TurnDeviceOff RPC.
+	TradfriServiceTurnDeviceOffProcedure = "/grpc_server.TradfriService/TurnDeviceOff"
+	// TradfriServiceChangeDeviceStateProcedure is the fully-qualified name of the TradfriService's
+	// ChangeDeviceState RPC.
+	TradfriServiceChangeDeviceStateProcedure = "/grpc_server.TradfriService/ChangeDeviceState"
+	// TradfriServiceChangeDeviceColorHSLProcedure is the fully-qualified name of the TradfriService's
+	// ChangeDeviceColorHSL RPC.
+	TradfriServiceChangeDeviceColorHSLProcedure = "/grpc_server.TradfriService/ChangeDeviceColorHSL"
+	// TradfriServiceChangeDeviceColorTemperatureProcedure is the fully-qualified name of the
+	// TradfriService's ChangeDeviceColorTemperature RPC.
+	TradfriServiceChangeDeviceColorTemperatureProcedure = "/grpc_server.TradfriService/ChangeDeviceColorTemperature"
+	// TradfriServiceTurnOutletOnProcedure is the fully-qualified name of the TradfriService's
+	// TurnOutletOn RPC.
+	TradfriServiceTurnOutletOnProcedure = "/grpc_server.TradfriService/TurnOutletOn"
+	// TradfriServiceTurnOutletOffProcedure is the fully-qualified name of the TradfriService's
+	// TurnOutletOff RPC.
+	TradfriServiceTurnOutletOffProcedure = "/grpc_server.TradfriService/TurnOutletOff"
+	// TradfriServiceChangeDevicePositioningProcedure is the fully-qualified name of the
+	// TradfriService's ChangeDevicePositioning RPC.
+	TradfriServiceChangeDevicePositioningProcedure = "/grpc_server.TradfriService/ChangeDevicePositioning"
+	// TradfriServiceTurnGroupOnProcedure is the fully-qualified name of the TradfriService's
+	// TurnGroupOn RPC.
+	TradfriServiceTurnGroupOnProcedure = "/grpc_server.TradfriService/TurnGroupOn"
+	// TradfriServiceTurnGroupOffProcedure is the fully-qualified name of the TradfriService's
+	// TurnGroupOff RPC.
+	TradfriServiceTurnGroupOffProcedure = "/grpc_server.TradfriService/TurnGroupOff"
+	// TradfriServiceChangeGroupDimmingProcedure is the fully-qualified name of the TradfriService's
+	// ChangeGroupDimming RPC.
+	TradfriServiceChangeGroupDimmingProcedure = "/grpc_server.TradfriService/ChangeGroupDimming"
+	// TradfriServiceActivateGroupSceneProcedure is the fully-qualified name of the TradfriService's
+	// ActivateGroupScene RPC.
+	TradfriServiceActivateGroupSceneProcedure = "/grpc_server.TradfriService/ActivateGroupScene"
+	// TradfriServiceWatchDevicesProcedure is the fully-qualified name of the TradfriService's
+	// WatchDevices RPC.
+	TradfriServiceWatchDevicesProcedure = "/grpc_server.TradfriService/WatchDevices"
+	// TradfriServiceWatchGroupsProcedure is the fully-qualified name of the TradfriService's
+	// WatchGroups RPC.
+	TradfriServiceWatchGroupsProcedure = "/grpc_server.TradfriService/WatchGroups"
+	// TradfriServiceBatchProcedure is the fully-qualified name of the TradfriService's Batch RPC.
+	TradfriServiceBatchProcedure = "/grpc_server.TradfriService/Batch"
+	// TradfriServiceRawRequestProcedure is the fully-qualified name of the TradfriService's RawRequest
+	// RPC.
+	TradfriServiceRawRequestProcedure = "/grpc_server.TradfriService/RawRequest"
+	// TradfriServiceListGatewaysProcedure is the fully-qualified name of the TradfriService's
+	// ListGateways RPC.
+	TradfriServiceListGatewaysProcedure = "/grpc_server.TradfriService/ListGateways"
+)
+
+// TradfriServiceClient is a client for the grpc_server.TradfriService service.
+type TradfriServiceClient interface {
+	ListGroups(context.Context, *golang.ListGroupsRequest) (*golang.ListGroupsResponse, error)
+	GetGroup(context.Context, *golang.GetGroupRequest) (*golang.GetGroupResponse, error)
+	ListDevices(context.Context, *golang.ListDevicesRequest) (*golang.ListDevicesResponse, error)
+	ListDeviceIDs(context.Context, *golang.ListDeviceIDsRequest) (*golang.ListDeviceIDsResponse, error)
+	// ListAllDevices lists the devices of all groups, including those not part of any group.
+	ListAllDevices(context.Context, *golang.ListAllDevicesRequest) (*golang.ListDevicesResponse, error)
+	GetDevice(context.Context, *golang.GetDeviceRequest) (*golang.GetDeviceResponse, error)
+	ChangeDeviceColor(context.Context, *golang.ChangeDeviceColorRequest) (*golang.ChangeDeviceColorResponse, error)
+	ChangeDeviceDimming(context.Context, *golang.ChangeDeviceDimmingRequest) (*golang.ChangeDeviceDimmingResponse, error)
+	TurnDeviceOn(context.Context, *golang.TurnDeviceOnRequest) (*golang.TurnDeviceOnResponse, error)
+	TurnDeviceOff(context.Context, *golang.TurnDeviceOffRequest) (*golang.TurnDeviceOffResponse, error)
+	// ChangeDeviceState switches a bulb on or off and sets its dimmer level in one call.
+	ChangeDeviceState(context.Context, *golang.ChangeDeviceStateRequest) (*golang.ChangeDeviceStateResponse, error)
+	ChangeDeviceColorHSL(context.Context, *golang.ChangeDeviceColorHSLRequest) (*golang.ChangeDeviceColorResponse, error)
+	ChangeDeviceColorTemperature(context.Context, *golang.ChangeDeviceColorTemperatureRequest) (*golang.ChangeDeviceColorResponse, error)
+	TurnOutletOn(context.Context, *golang.TurnOutletOnRequest) (*golang.TurnOutletOnResponse, error)
+	TurnOutletOff(context.Context, *golang.TurnOutletOffRequest) (*golang.TurnOutletOffResponse, error)
+	ChangeDevicePositioning(context.Context, *golang.ChangeDevicePositioningRequest) (*golang.ChangeDevicePositioningResponse, error)
+	TurnGroupOn(context.Context, *golang.TurnGroupOnRequest) (*golang.TurnGroupOnResponse, error)
+	TurnGroupOff(context.Context, *golang.TurnGroupOffRequest) (*golang.TurnGroupOffResponse, error)
+	ChangeGroupDimming(context.Context, *golang.ChangeGroupDimmingRequest) (*golang.ChangeGroupDimmingResponse, error)
+	// ActivateGroupScene activates a scene (mood) of a group, switching it on.
+	ActivateGroupScene(context.Context, *golang.ActivateGroupSceneRequest) (*golang.ActivateGroupSceneResponse, error)
+	// WatchDevices streams the current state of the matching devices followed by every change to them.
+	WatchDevices(context.Context, *golang.WatchDevicesRequest) (*connect.ServerStreamForClient[golang.Device], error)
+	// WatchGroups streams the current state of the matching groups followed by every change to them.
+	WatchGroups(context.Context, *golang.WatchGroupsRequest) (*connect.ServerStreamForClient[golang.Group], error)
+	// Batch executes a list of device and group operations concurrently and returns the result of each.
+	Batch(context.Context, *golang.BatchRequest) (*golang.BatchResponse, error)
+	// RawRequest forwards a CoAP request to an arbitrary gateway path and returns the response as is.
+	// Requires the admin scope.
+	RawRequest(context.Context, *golang.RawRequestRequest) (*golang.RawRequestResponse, error)
+	// ListGateways lists the gateways served by this server. The other calls are sent to the gateway named
+	// by the "tradfri-gateway" metadata, or to the default gateway without it.
+	ListGateways(context.Context, *golang.ListGatewaysRequest) (*golang.ListGatewaysResponse, error)
+}
+
+// NewTradfriServiceClient constructs a client for the grpc_server.TradfriService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTradfriServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TradfriServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	tradfriServiceMethods := golang.File_tradfri_proto.Services().ByName("TradfriService").Methods()
+	return &tradfriServiceClient{
+		listGroups: connect.NewClient[golang.ListGroupsRequest, golang.ListGroupsResponse](
+			httpClient,
+			baseURL+TradfriServiceListGroupsProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ListGroups")),
+			connect.WithClientOptions(opts...),
+		),
+		getGroup: connect.NewClient[golang.GetGroupRequest, golang.GetGroupResponse](
+			httpClient,
+			baseURL+TradfriServiceGetGroupProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("GetGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		listDevices: connect.NewClient[golang.ListDevicesRequest, golang.ListDevicesResponse](
+			httpClient,
+			baseURL+TradfriServiceListDevicesProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ListDevices")),
+			connect.WithClientOptions(opts...),
+		),
+		listDeviceIDs: connect.NewClient[golang.ListDeviceIDsRequest, golang.ListDeviceIDsResponse](
+			httpClient,
+			baseURL+TradfriServiceListDeviceIDsProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ListDeviceIDs")),
+			connect.WithClientOptions(opts...),
+		),
+		listAllDevices: connect.NewClient[golang.ListAllDevicesRequest, golang.ListDevicesResponse](
+			httpClient,
+			baseURL+TradfriServiceListAllDevicesProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ListAllDevices")),
+			connect.WithClientOptions(opts...),
+		),
+		getDevice: connect.NewClient[golang.GetDeviceRequest, golang.GetDeviceResponse](
+			httpClient,
+			baseURL+TradfriServiceGetDeviceProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("GetDevice")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDeviceColor: connect.NewClient[golang.ChangeDeviceColorRequest, golang.ChangeDeviceColorResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDeviceColorProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColor")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDeviceDimming: connect.NewClient[golang.ChangeDeviceDimmingRequest, golang.ChangeDeviceDimmingResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDeviceDimmingProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceDimming")),
+			connect.WithClientOptions(opts...),
+		),
+		turnDeviceOn: connect.NewClient[golang.TurnDeviceOnRequest, golang.TurnDeviceOnResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnDeviceOnProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnDeviceOn")),
+			connect.WithClientOptions(opts...),
+		),
+		turnDeviceOff: connect.NewClient[golang.TurnDeviceOffRequest, golang.TurnDeviceOffResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnDeviceOffProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnDeviceOff")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDeviceState: connect.NewClient[golang.ChangeDeviceStateRequest, golang.ChangeDeviceStateResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDeviceStateProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceState")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDeviceColorHSL: connect.NewClient[golang.ChangeDeviceColorHSLRequest, golang.ChangeDeviceColorResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDeviceColorHSLProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColorHSL")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDeviceColorTemperature: connect.NewClient[golang.ChangeDeviceColorTemperatureRequest, golang.ChangeDeviceColorResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDeviceColorTemperatureProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColorTemperature")),
+			connect.WithClientOptions(opts...),
+		),
+		turnOutletOn: connect.NewClient[golang.TurnOutletOnRequest, golang.TurnOutletOnResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnOutletOnProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnOutletOn")),
+			connect.WithClientOptions(opts...),
+		),
+		turnOutletOff: connect.NewClient[golang.TurnOutletOffRequest, golang.TurnOutletOffResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnOutletOffProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnOutletOff")),
+			connect.WithClientOptions(opts...),
+		),
+		changeDevicePositioning: connect.NewClient[golang.ChangeDevicePositioningRequest, golang.ChangeDevicePositioningResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeDevicePositioningProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeDevicePositioning")),
+			connect.WithClientOptions(opts...),
+		),
+		turnGroupOn: connect.NewClient[golang.TurnGroupOnRequest, golang.TurnGroupOnResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnGroupOnProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnGroupOn")),
+			connect.WithClientOptions(opts...),
+		),
+		turnGroupOff: connect.NewClient[golang.TurnGroupOffRequest, golang.TurnGroupOffResponse](
+			httpClient,
+			baseURL+TradfriServiceTurnGroupOffProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("TurnGroupOff")),
+			connect.WithClientOptions(opts...),
+		),
+		changeGroupDimming: connect.NewClient[golang.ChangeGroupDimmingRequest, golang.ChangeGroupDimmingResponse](
+			httpClient,
+			baseURL+TradfriServiceChangeGroupDimmingProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ChangeGroupDimming")),
+			connect.WithClientOptions(opts...),
+		),
+		activateGroupScene: connect.NewClient[golang.ActivateGroupSceneRequest, golang.ActivateGroupSceneResponse](
+			httpClient,
+			baseURL+TradfriServiceActivateGroupSceneProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ActivateGroupScene")),
+			connect.WithClientOptions(opts...),
+		),
+		watchDevices: connect.NewClient[golang.WatchDevicesRequest, golang.Device](
+			httpClient,
+			baseURL+TradfriServiceWatchDevicesProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("WatchDevices")),
+			connect.WithClientOptions(opts...),
+		),
+		watchGroups: connect.NewClient[golang.WatchGroupsRequest, golang.Group](
+			httpClient,
+			baseURL+TradfriServiceWatchGroupsProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("WatchGroups")),
+			connect.WithClientOptions(opts...),
+		),
+		batch: connect.NewClient[golang.BatchRequest, golang.BatchResponse](
+			httpClient,
+			baseURL+TradfriServiceBatchProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("Batch")),
+			connect.WithClientOptions(opts...),
+		),
+		rawRequest: connect.NewClient[golang.RawRequestRequest, golang.RawRequestResponse](
+			httpClient,
+			baseURL+TradfriServiceRawRequestProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("RawRequest")),
+			connect.WithClientOptions(opts...),
+		),
+		listGateways: connect.NewClient[golang.ListGatewaysRequest, golang.ListGatewaysResponse](
+			httpClient,
+			baseURL+TradfriServiceListGatewaysProcedure,
+			connect.WithSchema(tradfriServiceMethods.ByName("ListGateways")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// tradfriServiceClient implements TradfriServiceClient.
+type tradfriServiceClient struct {
+	listGroups                   *connect.Client[golang.ListGroupsRequest, golang.ListGroupsResponse]
+	getGroup                     *connect.Client[golang.GetGroupRequest, golang.GetGroupResponse]
+	listDevices                  *connect.Client[golang.ListDevicesRequest, golang.ListDevicesResponse]
+	listDeviceIDs                *connect.Client[golang.ListDeviceIDsRequest, golang.ListDeviceIDsResponse]
+	listAllDevices               *connect.Client[golang.ListAllDevicesRequest, golang.ListDevicesResponse]
+	getDevice                    *connect.Client[golang.GetDeviceRequest, golang.GetDeviceResponse]
+	changeDeviceColor            *connect.Client[golang.ChangeDeviceColorRequest, golang.ChangeDeviceColorResponse]
+	changeDeviceDimming          *connect.Client[golang.ChangeDeviceDimmingRequest, golang.ChangeDeviceDimmingResponse]
+	turnDeviceOn                 *connect.Client[golang.TurnDeviceOnRequest, golang.TurnDeviceOnResponse]
+	turnDeviceOff                *connect.Client[golang.TurnDeviceOffRequest, golang.TurnDeviceOffResponse]
+	changeDeviceState            *connect.Client[golang.ChangeDeviceStateRequest, golang.ChangeDeviceStateResponse]
+	changeDeviceColorHSL         *connect.Client[golang.ChangeDeviceColorHSLRequest, golang.ChangeDeviceColorResponse]
+	changeDeviceColorTemperature *connect.Client[golang.ChangeDeviceColorTemperatureRequest, golang.ChangeDeviceColorResponse]
+	turnOutletOn                 *connect.Client[golang.TurnOutletOnRequest, golang.TurnOutletOnResponse]
+	turnOutletOff                *connect.Client[golang.TurnOutletOffRequest, golang.TurnOutletOffResponse]
+	changeDevicePositioning      *connect.Client[golang.ChangeDevicePositioningRequest, golang.ChangeDevicePositioningResponse]
+	turnGroupOn                  *connect.Client[golang.TurnGroupOnRequest, golang.TurnGroupOnResponse]
+	turnGroupOff                 *connect.Client[golang.TurnGroupOffRequest, golang.TurnGroupOffResponse]
+	changeGroupDimming           *connect.Client[golang.ChangeGroupDimmingRequest, golang.ChangeGroupDimmingResponse]
+	activateGroupScene           *connect.Client[golang.ActivateGroupSceneRequest, golang.ActivateGroupSceneResponse]
+	watchDevices                 *connect.Client[golang.WatchDevicesRequest, golang.Device]
+	watchGroups                  *connect.Client[golang.WatchGroupsRequest, golang.Group]
+	batch                        *connect.Client[golang.BatchRequest, golang.BatchResponse]
+	rawRequest                   *connect.Client[golang.RawRequestRequest, golang.RawRequestResponse]
+	listGateways                 *connect.Client[golang.ListGatewaysRequest, golang.ListGatewaysResponse]
+}
+
+// ListGroups calls grpc_server.TradfriService.ListGroups.
+func (c *tradfriServiceClient) ListGroups(ctx context.Context, req *golang.ListGroupsRequest) (*golang.ListGroupsResponse, error) {
+	response, err := c.listGroups.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// GetGroup calls grpc_server.TradfriService.GetGroup.
+func (c *tradfriServiceClient) GetGroup(ctx context.Context, req *golang.GetGroupRequest) (*golang.GetGroupResponse, error) {
+	response, err := c.getGroup.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListDevices calls grpc_server.TradfriService.ListDevices.
+func (c *tradfriServiceClient) ListDevices(ctx context.Context, req *golang.ListDevicesRequest) (*golang.ListDevicesResponse, error) {
+	response, err := c.listDevices.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListDeviceIDs calls grpc_server.TradfriService.ListDeviceIDs.
+func (c *tradfriServiceClient) ListDeviceIDs(ctx context.Context, req *golang.ListDeviceIDsRequest) (*golang.ListDeviceIDsResponse, error) {
+	response, err := c.listDeviceIDs.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListAllDevices calls grpc_server.TradfriService.ListAllDevices.
+func (c *tradfriServiceClient) ListAllDevices(ctx context.Context, req *golang.ListAllDevicesRequest) (*golang.ListDevicesResponse, error) {
+	response, err := c.listAllDevices.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// GetDevice calls grpc_server.TradfriService.GetDevice.
+func (c *tradfriServiceClient) GetDevice(ctx context.Context, req *golang.GetDeviceRequest) (*golang.GetDeviceResponse, error) {
+	response, err := c.getDevice.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDeviceColor calls grpc_server.TradfriService.ChangeDeviceColor.
+func (c *tradfriServiceClient) ChangeDeviceColor(ctx context.Context, req *golang.ChangeDeviceColorRequest) (*golang.ChangeDeviceColorResponse, error) {
+	response, err := c.changeDeviceColor.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDeviceDimming calls grpc_server.TradfriService.ChangeDeviceDimming.
+func (c *tradfriServiceClient) ChangeDeviceDimming(ctx context.Context, req *golang.ChangeDeviceDimmingRequest) (*golang.ChangeDeviceDimmingResponse, error) {
+	response, err := c.changeDeviceDimming.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnDeviceOn calls grpc_server.TradfriService.TurnDeviceOn.
+func (c *tradfriServiceClient) TurnDeviceOn(ctx context.Context, req *golang.TurnDeviceOnRequest) (*golang.TurnDeviceOnResponse, error) {
+	response, err := c.turnDeviceOn.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnDeviceOff calls grpc_server.TradfriService.TurnDeviceOff.
+func (c *tradfriServiceClient) TurnDeviceOff(ctx context.Context, req *golang.TurnDeviceOffRequest) (*golang.TurnDeviceOffResponse, error) {
+	response, err := c.turnDeviceOff.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDeviceState calls grpc_server.TradfriService.ChangeDeviceState.
+func (c *tradfriServiceClient) ChangeDeviceState(ctx context.Context, req *golang.ChangeDeviceStateRequest) (*golang.ChangeDeviceStateResponse, error) {
+	response, err := c.changeDeviceState.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDeviceColorHSL calls grpc_server.TradfriService.ChangeDeviceColorHSL.
+func (c *tradfriServiceClient) ChangeDeviceColorHSL(ctx context.Context, req *golang.ChangeDeviceColorHSLRequest) (*golang.ChangeDeviceColorResponse, error) {
+	response, err := c.changeDeviceColorHSL.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDeviceColorTemperature calls grpc_server.TradfriService.ChangeDeviceColorTemperature.
+func (c *tradfriServiceClient) ChangeDeviceColorTemperature(ctx context.Context, req *golang.ChangeDeviceColorTemperatureRequest) (*golang.ChangeDeviceColorResponse, error) {
+	response, err := c.changeDeviceColorTemperature.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnOutletOn calls grpc_server.TradfriService.TurnOutletOn.
+func (c *tradfriServiceClient) TurnOutletOn(ctx context.Context, req *golang.TurnOutletOnRequest) (*golang.TurnOutletOnResponse, error) {
+	response, err := c.turnOutletOn.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnOutletOff calls grpc_server.TradfriService.TurnOutletOff.
+func (c *tradfriServiceClient) TurnOutletOff(ctx context.Context, req *golang.TurnOutletOffRequest) (*golang.TurnOutletOffResponse, error) {
+	response, err := c.turnOutletOff.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeDevicePositioning calls grpc_server.TradfriService.ChangeDevicePositioning.
+func (c *tradfriServiceClient) ChangeDevicePositioning(ctx context.Context, req *golang.ChangeDevicePositioningRequest) (*golang.ChangeDevicePositioningResponse, error) {
+	response, err := c.changeDevicePositioning.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnGroupOn calls grpc_server.TradfriService.TurnGroupOn.
+func (c *tradfriServiceClient) TurnGroupOn(ctx context.Context, req *golang.TurnGroupOnRequest) (*golang.TurnGroupOnResponse, error) {
+	response, err := c.turnGroupOn.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TurnGroupOff calls grpc_server.TradfriService.TurnGroupOff.
+func (c *tradfriServiceClient) TurnGroupOff(ctx context.Context, req *golang.TurnGroupOffRequest) (*golang.TurnGroupOffResponse, error) {
+	response, err := c.turnGroupOff.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ChangeGroupDimming calls grpc_server.TradfriService.ChangeGroupDimming.
+func (c *tradfriServiceClient) ChangeGroupDimming(ctx context.Context, req *golang.ChangeGroupDimmingRequest) (*golang.ChangeGroupDimmingResponse, error) {
+	response, err := c.changeGroupDimming.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ActivateGroupScene calls grpc_server.TradfriService.ActivateGroupScene.
+func (c *tradfriServiceClient) ActivateGroupScene(ctx context.Context, req *golang.ActivateGroupSceneRequest) (*golang.ActivateGroupSceneResponse, error) {
+	response, err := c.activateGroupScene.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// WatchDevices calls grpc_server.TradfriService.WatchDevices.
+func (c *tradfriServiceClient) WatchDevices(ctx context.Context, req *golang.WatchDevicesRequest) (*connect.ServerStreamForClient[golang.Device], error) {
+	return c.watchDevices.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// WatchGroups calls grpc_server.TradfriService.WatchGroups.
+func (c *tradfriServiceClient) WatchGroups(ctx context.Context, req *golang.WatchGroupsRequest) (*connect.ServerStreamForClient[golang.Group], error) {
+	return c.watchGroups.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// Batch calls grpc_server.TradfriService.Batch.
+func (c *tradfriServiceClient) Batch(ctx context.Context, req *golang.BatchRequest) (*golang.BatchResponse, error) {
+	response, err := c.batch.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RawRequest calls grpc_server.TradfriService.RawRequest.
+func (c *tradfriServiceClient) RawRequest(ctx context.Context, req *golang.RawRequestRequest) (*golang.RawRequestResponse, error) {
+	response, err := c.rawRequest.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListGateways calls grpc_server.TradfriService.ListGateways.
+func (c *tradfriServiceClient) ListGateways(ctx context.Context, req *golang.ListGatewaysRequest) (*golang.ListGatewaysResponse, error) {
+	response, err := c.listGateways.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TradfriServiceHandler is an implementation of the grpc_server.TradfriService service.
+type TradfriServiceHandler interface {
+	ListGroups(context.Context, *golang.ListGroupsRequest) (*golang.ListGroupsResponse, error)
+	GetGroup(context.Context, *golang.GetGroupRequest) (*golang.GetGroupResponse, error)
+	ListDevices(context.Context, *golang.ListDevicesRequest) (*golang.ListDevicesResponse, error)
+	ListDeviceIDs(context.Context, *golang.ListDeviceIDsRequest) (*golang.ListDeviceIDsResponse, error)
+	// ListAllDevices lists the devices of all groups, including those not part of any group.
+	ListAllDevices(context.Context, *golang.ListAllDevicesRequest) (*golang.ListDevicesResponse, error)
+	GetDevice(context.Context, *golang.GetDeviceRequest) (*golang.GetDeviceResponse, error)
+	ChangeDeviceColor(context.Context, *golang.ChangeDeviceColorRequest) (*golang.ChangeDeviceColorResponse, error)
+	ChangeDeviceDimming(context.Context, *golang.ChangeDeviceDimmingRequest) (*golang.ChangeDeviceDimmingResponse, error)
+	TurnDeviceOn(context.Context, *golang.TurnDeviceOnRequest) (*golang.TurnDeviceOnResponse, error)
+	TurnDeviceOff(context.Context, *golang.TurnDeviceOffRequest) (*golang.TurnDeviceOffResponse, error)
+	// ChangeDeviceState switches a bulb on or off and sets its dimmer level in one call.
+	ChangeDeviceState(context.Context, *golang.ChangeDeviceStateRequest) (*golang.ChangeDeviceStateResponse, error)
+	ChangeDeviceColorHSL(context.Context, *golang.ChangeDeviceColorHSLRequest) (*golang.ChangeDeviceColorResponse, error)
+	ChangeDeviceColorTemperature(context.Context, *golang.ChangeDeviceColorTemperatureRequest) (*golang.ChangeDeviceColorResponse, error)
+	TurnOutletOn(context.Context, *golang.TurnOutletOnRequest) (*golang.TurnOutletOnResponse, error)
+	TurnOutletOff(context.Context, *golang.TurnOutletOffRequest) (*golang.TurnOutletOffResponse, error)
+	ChangeDevicePositioning(context.Context, *golang.ChangeDevicePositioningRequest) (*golang.ChangeDevicePositioningResponse, error)
+	TurnGroupOn(context.Context, *golang.TurnGroupOnRequest) (*golang.TurnGroupOnResponse, error)
+	TurnGroupOff(context.Context, *golang.TurnGroupOffRequest) (*golang.TurnGroupOffResponse, error)
+	ChangeGroupDimming(context.Context, *golang.ChangeGroupDimmingRequest) (*golang.ChangeGroupDimmingResponse, error)
+	// ActivateGroupScene activates a scene (mood) of a group, switching it on.
+	ActivateGroupScene(context.Context, *golang.ActivateGroupSceneRequest) (*golang.ActivateGroupSceneResponse, error)
+	// WatchDevices streams the current state of the matching devices followed by every change to them.
+	WatchDevices(context.Context, *golang.WatchDevicesRequest, *connect.ServerStream[golang.Device]) error
+	// WatchGroups streams the current state of the matching groups followed by every change to them.
+	WatchGroups(context.Context, *golang.WatchGroupsRequest, *connect.ServerStream[golang.Group]) error
+	// Batch executes a list of device and group operations concurrently and returns the result of each.
+	Batch(context.Context, *golang.BatchRequest) (*golang.BatchResponse, error)
+	// RawRequest forwards a CoAP request to an arbitrary gateway path and returns the response as is.
+	// Requires the admin scope.
+	RawRequest(context.Context, *golang.RawRequestRequest) (*golang.RawRequestResponse, error)
+	// ListGateways lists the gateways served by this server. The other calls are sent to the gateway named
+	// by the "tradfri-gateway" metadata, or to the default gateway without it.
+	ListGateways(context.Context, *golang.ListGatewaysRequest) (*golang.ListGatewaysResponse, error)
+}
+
+// NewTradfriServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTradfriServiceHandler(svc TradfriServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	tradfriServiceMethods := golang.File_tradfri_proto.Services().ByName("TradfriService").Methods()
+	tradfriServiceListGroupsHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceListGroupsProcedure,
+		svc.ListGroups,
+		connect.WithSchema(tradfriServiceMethods.ByName("ListGroups")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceGetGroupHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceGetGroupProcedure,
+		svc.GetGroup,
+		connect.WithSchema(tradfriServiceMethods.ByName("GetGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceListDevicesHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceListDevicesProcedure,
+		svc.ListDevices,
+		connect.WithSchema(tradfriServiceMethods.ByName("ListDevices")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceListDeviceIDsHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceListDeviceIDsProcedure,
+		svc.ListDeviceIDs,
+		connect.WithSchema(tradfriServiceMethods.ByName("ListDeviceIDs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceListAllDevicesHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceListAllDevicesProcedure,
+		svc.ListAllDevices,
+		connect.WithSchema(tradfriServiceMethods.ByName("ListAllDevices")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceGetDeviceHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceGetDeviceProcedure,
+		svc.GetDevice,
+		connect.WithSchema(tradfriServiceMethods.ByName("GetDevice")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDeviceColorHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDeviceColorProcedure,
+		svc.ChangeDeviceColor,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColor")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDeviceDimmingHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDeviceDimmingProcedure,
+		svc.ChangeDeviceDimming,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceDimming")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnDeviceOnHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnDeviceOnProcedure,
+		svc.TurnDeviceOn,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnDeviceOn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnDeviceOffHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnDeviceOffProcedure,
+		svc.TurnDeviceOff,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnDeviceOff")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDeviceStateHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDeviceStateProcedure,
+		svc.ChangeDeviceState,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceState")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDeviceColorHSLHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDeviceColorHSLProcedure,
+		svc.ChangeDeviceColorHSL,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColorHSL")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDeviceColorTemperatureHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDeviceColorTemperatureProcedure,
+		svc.ChangeDeviceColorTemperature,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDeviceColorTemperature")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnOutletOnHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnOutletOnProcedure,
+		svc.TurnOutletOn,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnOutletOn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnOutletOffHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnOutletOffProcedure,
+		svc.TurnOutletOff,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnOutletOff")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeDevicePositioningHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeDevicePositioningProcedure,
+		svc.ChangeDevicePositioning,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeDevicePositioning")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnGroupOnHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnGroupOnProcedure,
+		svc.TurnGroupOn,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnGroupOn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceTurnGroupOffHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceTurnGroupOffProcedure,
+		svc.TurnGroupOff,
+		connect.WithSchema(tradfriServiceMethods.ByName("TurnGroupOff")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceChangeGroupDimmingHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceChangeGroupDimmingProcedure,
+		svc.ChangeGroupDimming,
+		connect.WithSchema(tradfriServiceMethods.ByName("ChangeGroupDimming")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceActivateGroupSceneHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceActivateGroupSceneProcedure,
+		svc.ActivateGroupScene,
+		connect.WithSchema(tradfriServiceMethods.ByName("ActivateGroupScene")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceWatchDevicesHandler := connect.NewServerStreamHandlerSimple(
+		TradfriServiceWatchDevicesProcedure,
+		svc.WatchDevices,
+		connect.WithSchema(tradfriServiceMethods.ByName("WatchDevices")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceWatchGroupsHandler := connect.NewServerStreamHandlerSimple(
+		TradfriServiceWatchGroupsProcedure,
+		svc.WatchGroups,
+		connect.WithSchema(tradfriServiceMethods.ByName("WatchGroups")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceBatchHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceBatchProcedure,
+		svc.Batch,
+		connect.WithSchema(tradfriServiceMethods.ByName("Batch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceRawRequestHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceRawRequestProcedure,
+		svc.RawRequest,
+		connect.WithSchema(tradfriServiceMethods.ByName("RawRequest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tradfriServiceListGatewaysHandler := connect.NewUnaryHandlerSimple(
+		TradfriServiceListGatewaysProcedure,
+		svc.ListGateways,
+		connect.WithSchema(tradfriServiceMethods.ByName("ListGateways")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/grpc_server.TradfriService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TradfriServiceListGroupsProcedure:
+			tradfriServiceListGroupsHandler.ServeHTTP(w, r)
+		case TradfriServiceGetGroupProcedure:
+			tradfriServiceGetGroupHandler.ServeHTTP(w, r)
+		case TradfriServiceListDevicesProcedure:
+			tradfriServiceListDevicesHandler.ServeHTTP(w, r)
+		case TradfriServiceListDeviceIDsProcedure:
+			tradfriServiceListDeviceIDsHandler.ServeHTTP(w, r)
+		case TradfriServiceListAllDevicesProcedure:
+			tradfriServiceListAllDevicesHandler.ServeHTTP(w, r)
+		case TradfriServiceGetDeviceProcedure:
+			tradfriServiceGetDeviceHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDeviceColorProcedure:
+			tradfriServiceChangeDeviceColorHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDeviceDimmingProcedure:
+			tradfriServiceChangeDeviceDimmingHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnDeviceOnProcedure:
+			tradfriServiceTurnDeviceOnHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnDeviceOffProcedure:
+			tradfriServiceTurnDeviceOffHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDeviceStateProcedure:
+			tradfriServiceChangeDeviceStateHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDeviceColorHSLProcedure:
+			tradfriServiceChangeDeviceColorHSLHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDeviceColorTemperatureProcedure:
+			tradfriServiceChangeDeviceColorTemperatureHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnOutletOnProcedure:
+			tradfriServiceTurnOutletOnHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnOutletOffProcedure:
+			tradfriServiceTurnOutletOffHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeDevicePositioningProcedure:
+			tradfriServiceChangeDevicePositioningHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnGroupOnProcedure:
+			tradfriServiceTurnGroupOnHandler.ServeHTTP(w, r)
+		case TradfriServiceTurnGroupOffProcedure:
+			tradfriServiceTurnGroupOffHandler.ServeHTTP(w, r)
+		case TradfriServiceChangeGroupDimmingProcedure:
+			tradfriServiceChangeGroupDimmingHandler.ServeHTTP(w, r)
+		case TradfriServiceActivateGroupSceneProcedure:
+			tradfriServiceActivateGroupSceneHandler.ServeHTTP(w, r)
+		case TradfriServiceWatchDevicesProcedure:
+			tradfriServiceWatchDevicesHandler.ServeHTTP(w, r)
+		case TradfriServiceWatchGroupsProcedure:
+			tradfriServiceWatchGroupsHandler.ServeHTTP(w, r)
+		case TradfriServiceBatchProcedure:
+			tradfriServiceBatchHandler.ServeHTTP(w, r)
+		case TradfriServiceRawRequestProcedure:
+			tradfriServiceRawRequestHandler.ServeHTTP(w, r)
+		case TradfriServiceListGatewaysProcedure:
+			tradfriServiceListGatewaysHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTradfriServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTradfriServiceHandler struct{}
+
+func (UnimplementedTradfriServiceHandler) ListGroups(context.Context, *golang.ListGroupsRequest) (*golang.ListGroupsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ListGroups is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) GetGroup(context.Context, *golang.GetGroupRequest) (*golang.GetGroupResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.GetGroup is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ListDevices(context.Context, *golang.ListDevicesRequest) (*golang.ListDevicesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ListDevices is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ListDeviceIDs(context.Context, *golang.ListDeviceIDsRequest) (*golang.ListDeviceIDsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ListDeviceIDs is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ListAllDevices(context.Context, *golang.ListAllDevicesRequest) (*golang.ListDevicesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ListAllDevices is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) GetDevice(context.Context, *golang.GetDeviceRequest) (*golang.GetDeviceResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.GetDevice is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDeviceColor(context.Context, *golang.ChangeDeviceColorRequest) (*golang.ChangeDeviceColorResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDeviceColor is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDeviceDimming(context.Context, *golang.ChangeDeviceDimmingRequest) (*golang.ChangeDeviceDimmingResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDeviceDimming is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnDeviceOn(context.Context, *golang.TurnDeviceOnRequest) (*golang.TurnDeviceOnResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnDeviceOn is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnDeviceOff(context.Context, *golang.TurnDeviceOffRequest) (*golang.TurnDeviceOffResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnDeviceOff is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDeviceState(context.Context, *golang.ChangeDeviceStateRequest) (*golang.ChangeDeviceStateResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDeviceState is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDeviceColorHSL(context.Context, *golang.ChangeDeviceColorHSLRequest) (*golang.ChangeDeviceColorResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDeviceColorHSL is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDeviceColorTemperature(context.Context, *golang.ChangeDeviceColorTemperatureRequest) (*golang.ChangeDeviceColorResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDeviceColorTemperature is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnOutletOn(context.Context, *golang.TurnOutletOnRequest) (*golang.TurnOutletOnResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnOutletOn is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnOutletOff(context.Context, *golang.TurnOutletOffRequest) (*golang.TurnOutletOffResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnOutletOff is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeDevicePositioning(context.Context, *golang.ChangeDevicePositioningRequest) (*golang.ChangeDevicePositioningResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeDevicePositioning is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnGroupOn(context.Context, *golang.TurnGroupOnRequest) (*golang.TurnGroupOnResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnGroupOn is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) TurnGroupOff(context.Context, *golang.TurnGroupOffRequest) (*golang.TurnGroupOffResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.TurnGroupOff is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ChangeGroupDimming(context.Context, *golang.ChangeGroupDimmingRequest) (*golang.ChangeGroupDimmingResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ChangeGroupDimming is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ActivateGroupScene(context.Context, *golang.ActivateGroupSceneRequest) (*golang.ActivateGroupSceneResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ActivateGroupScene is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) WatchDevices(context.Context, *golang.WatchDevicesRequest, *connect.ServerStream[golang.Device]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.WatchDevices is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) WatchGroups(context.Context, *golang.WatchGroupsRequest, *connect.ServerStream[golang.Group]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.WatchGroups is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) Batch(context.Context, *golang.BatchRequest) (*golang.BatchResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.Batch is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) RawRequest(context.Context, *golang.RawRequestRequest) (*golang.RawRequestResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.RawRequest is not implemented"))
+}
+
+func (UnimplementedTradfriServiceHandler) ListGateways(context.Context, *golang.ListGatewaysRequest) (*golang.ListGatewaysResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("grpc_server.TradfriService.ListGateways is not implemented"))
+}
@@ -9,6 +9,7 @@ package golang
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,126 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PowerSource is the LWM2M power source of a device.
+type PowerSource int32
+
+const (
+	PowerSource_POWER_SOURCE_UNSPECIFIED      PowerSource = 0
+	PowerSource_POWER_SOURCE_DC               PowerSource = 1
+	PowerSource_POWER_SOURCE_INTERNAL_BATTERY PowerSource = 2
+	PowerSource_POWER_SOURCE_EXTERNAL_BATTERY PowerSource = 3
+	PowerSource_POWER_SOURCE_BATTERY          PowerSource = 4
+	PowerSource_POWER_SOURCE_POE              PowerSource = 5
+	PowerSource_POWER_SOURCE_USB              PowerSource = 6
+	PowerSource_POWER_SOURCE_MAINS            PowerSource = 7
+	PowerSource_POWER_SOURCE_SOLAR            PowerSource = 8
+)
+
+// Enum value maps for PowerSource.
+var (
+	PowerSource_name = map[int32]string{
+		0: "POWER_SOURCE_UNSPECIFIED",
+		1: "POWER_SOURCE_DC",
+		2: "POWER_SOURCE_INTERNAL_BATTERY",
+		3: "POWER_SOURCE_EXTERNAL_BATTERY",
+		4: "POWER_SOURCE_BATTERY",
+		5: "POWER_SOURCE_POE",
+		6: "POWER_SOURCE_USB",
+		7: "POWER_SOURCE_MAINS",
+		8: "POWER_SOURCE_SOLAR",
+	}
+	PowerSource_value = map[string]int32{
+		"POWER_SOURCE_UNSPECIFIED":      0,
+		"POWER_SOURCE_DC":               1,
+		"POWER_SOURCE_INTERNAL_BATTERY": 2,
+		"POWER_SOURCE_EXTERNAL_BATTERY": 3,
+		"POWER_SOURCE_BATTERY":          4,
+		"POWER_SOURCE_POE":              5,
+		"POWER_SOURCE_USB":              6,
+		"POWER_SOURCE_MAINS":            7,
+		"POWER_SOURCE_SOLAR":            8,
+	}
+)
+
+func (x PowerSource) Enum() *PowerSource {
+	p := new(PowerSource)
+	*p = x
+	return p
+}
+
+func (x PowerSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_tradfri_proto_enumTypes[0].Descriptor()
+}
+
+func (PowerSource) Type() protoreflect.EnumType {
+	return &file_tradfri_proto_enumTypes[0]
+}
+
+func (x PowerSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerSource.Descriptor instead.
+func (PowerSource) EnumDescriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{0}
+}
+
+type BatchResult_Status int32
+
+const (
+	BatchResult_STATUS_UNSPECIFIED BatchResult_Status = 0
+	BatchResult_OK                 BatchResult_Status = 1
+	BatchResult_FAILED             BatchResult_Status = 2
+	BatchResult_SKIPPED            BatchResult_Status = 3
+)
+
+// Enum value maps for BatchResult_Status.
+var (
+	BatchResult_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "OK",
+		2: "FAILED",
+		3: "SKIPPED",
+	}
+	BatchResult_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"OK":                 1,
+		"FAILED":             2,
+		"SKIPPED":            3,
+	}
+)
+
+func (x BatchResult_Status) Enum() *BatchResult_Status {
+	p := new(BatchResult_Status)
+	*p = x
+	return p
+}
+
+func (x BatchResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_tradfri_proto_enumTypes[1].Descriptor()
+}
+
+func (BatchResult_Status) Type() protoreflect.EnumType {
+	return &file_tradfri_proto_enumTypes[1]
+}
+
+func (x BatchResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchResult_Status.Descriptor instead.
+func (BatchResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{54, 0}
+}
+
 type DeviceMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -28,8 +149,17 @@ type DeviceMetadata struct {
 	Id     int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Vendor string `protobuf:"bytes,3,opt,name=vendor,proto3" json:"vendor,omitempty"`
-	Type   string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Bat    int32  `protobuf:"varint,10,opt,name=bat,proto3" json:"bat,omitempty"`
+	// The model name, e.g. "TRADFRI bulb E27 CWS opal 600lm".
+	Type            string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	SerialNumber    string                 `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	FirmwareVersion string                 `protobuf:"bytes,6,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	PowerSource     PowerSource            `protobuf:"varint,7,opt,name=power_source,json=powerSource,proto3,enum=grpc_server.PowerSource" json:"power_source,omitempty"`
+	Alive           bool                   `protobuf:"varint,8,opt,name=alive,proto3" json:"alive,omitempty"`
+	LastSeen        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Battery level in percent, only set for battery powered devices.
+	Bat            int32                  `protobuf:"varint,10,opt,name=bat,proto3" json:"bat,omitempty"`
+	Created        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created,proto3" json:"created,omitempty"`
+	OtaUpdateState int32                  `protobuf:"varint,12,opt,name=ota_update_state,json=otaUpdateState,proto3" json:"ota_update_state,omitempty"`
 }
 
 func (x *DeviceMetadata) Reset() {
@@ -92,6 +222,41 @@ func (x *DeviceMetadata) GetType() string {
 	return ""
 }
 
+func (x *DeviceMetadata) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *DeviceMetadata) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *DeviceMetadata) GetPowerSource() PowerSource {
+	if x != nil {
+		return x.PowerSource
+	}
+	return PowerSource_POWER_SOURCE_UNSPECIFIED
+}
+
+func (x *DeviceMetadata) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *DeviceMetadata) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
 func (x *DeviceMetadata) GetBat() int32 {
 	if x != nil {
 		return x.Bat
@@ -99,18 +264,53 @@ func (x *DeviceMetadata) GetBat() int32 {
 	return 0
 }
 
+func (x *DeviceMetadata) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *DeviceMetadata) GetOtaUpdateState() int32 {
+	if x != nil {
+		return x.OtaUpdateState
+	}
+	return 0
+}
+
 type Device struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Metadata *DeviceMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Dimmer   int32           `protobuf:"varint,2,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
-	Xcolor   int32           `protobuf:"varint,3,opt,name=xcolor,proto3" json:"xcolor,omitempty"`
-	Ycolor   int32           `protobuf:"varint,4,opt,name=ycolor,proto3" json:"ycolor,omitempty"`
-	Rgb      string          `protobuf:"bytes,5,opt,name=rgb,proto3" json:"rgb,omitempty"`
-	Power    bool            `protobuf:"varint,6,opt,name=power,proto3" json:"power,omitempty"`
-	Position float32         `protobuf:"fixed32,10,opt,name=position,proto3" json:"position,omitempty"`
+	// The state of the device, depending on its kind.
+	//
+	// Types that are assignable to Kind:
+	//	*Device_Light
+	//	*Device_Plug
+	//	*Device_Blind
+	//	*Device_Remote
+	//	*Device_Sensor
+	//	*Device_Repeater
+	//	*Device_AirPurifier
+	Kind isDevice_Kind `protobuf_oneof:"kind"`
+	// Superseded by light and blind, still set for lights and blinds.
+	//
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Dimmer int32 `protobuf:"varint,2,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Xcolor int32 `protobuf:"varint,3,opt,name=xcolor,proto3" json:"xcolor,omitempty"`
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Ycolor int32 `protobuf:"varint,4,opt,name=ycolor,proto3" json:"ycolor,omitempty"`
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Rgb string `protobuf:"bytes,5,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Power bool `protobuf:"varint,6,opt,name=power,proto3" json:"power,omitempty"`
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Position float32 `protobuf:"fixed32,10,opt,name=position,proto3" json:"position,omitempty"`
+	// The gateway of the device, only set when listing the devices of all gateways.
+	Gateway string `protobuf:"bytes,30,opt,name=gateway,proto3" json:"gateway,omitempty"`
 }
 
 func (x *Device) Reset() {
@@ -152,6 +352,63 @@ func (x *Device) GetMetadata() *DeviceMetadata {
 	return nil
 }
 
+func (m *Device) GetKind() isDevice_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *Device) GetLight() *Light {
+	if x, ok := x.GetKind().(*Device_Light); ok {
+		return x.Light
+	}
+	return nil
+}
+
+func (x *Device) GetPlug() *Plug {
+	if x, ok := x.GetKind().(*Device_Plug); ok {
+		return x.Plug
+	}
+	return nil
+}
+
+func (x *Device) GetBlind() *Blind {
+	if x, ok := x.GetKind().(*Device_Blind); ok {
+		return x.Blind
+	}
+	return nil
+}
+
+func (x *Device) GetRemote() *Remote {
+	if x, ok := x.GetKind().(*Device_Remote); ok {
+		return x.Remote
+	}
+	return nil
+}
+
+func (x *Device) GetSensor() *Sensor {
+	if x, ok := x.GetKind().(*Device_Sensor); ok {
+		return x.Sensor
+	}
+	return nil
+}
+
+func (x *Device) GetRepeater() *Repeater {
+	if x, ok := x.GetKind().(*Device_Repeater); ok {
+		return x.Repeater
+	}
+	return nil
+}
+
+func (x *Device) GetAirPurifier() *AirPurifier {
+	if x, ok := x.GetKind().(*Device_AirPurifier); ok {
+		return x.AirPurifier
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetDimmer() int32 {
 	if x != nil {
 		return x.Dimmer
@@ -159,6 +416,7 @@ func (x *Device) GetDimmer() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetXcolor() int32 {
 	if x != nil {
 		return x.Xcolor
@@ -166,6 +424,7 @@ func (x *Device) GetXcolor() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetYcolor() int32 {
 	if x != nil {
 		return x.Ycolor
@@ -173,6 +432,7 @@ func (x *Device) GetYcolor() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetRgb() string {
 	if x != nil {
 		return x.Rgb
@@ -180,6 +440,7 @@ func (x *Device) GetRgb() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetPower() bool {
 	if x != nil {
 		return x.Power
@@ -187,6 +448,7 @@ func (x *Device) GetPower() bool {
 	return false
 }
 
+// Deprecated: Marked as deprecated in tradfri.proto.
 func (x *Device) GetPosition() float32 {
 	if x != nil {
 		return x.Position
@@ -194,19 +456,79 @@ func (x *Device) GetPosition() float32 {
 	return 0
 }
 
-type Group struct {
+func (x *Device) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+type isDevice_Kind interface {
+	isDevice_Kind()
+}
+
+type Device_Light struct {
+	Light *Light `protobuf:"bytes,20,opt,name=light,proto3,oneof"`
+}
+
+type Device_Plug struct {
+	Plug *Plug `protobuf:"bytes,21,opt,name=plug,proto3,oneof"`
+}
+
+type Device_Blind struct {
+	Blind *Blind `protobuf:"bytes,22,opt,name=blind,proto3,oneof"`
+}
+
+type Device_Remote struct {
+	Remote *Remote `protobuf:"bytes,23,opt,name=remote,proto3,oneof"`
+}
+
+type Device_Sensor struct {
+	Sensor *Sensor `protobuf:"bytes,24,opt,name=sensor,proto3,oneof"`
+}
+
+type Device_Repeater struct {
+	Repeater *Repeater `protobuf:"bytes,25,opt,name=repeater,proto3,oneof"`
+}
+
+type Device_AirPurifier struct {
+	AirPurifier *AirPurifier `protobuf:"bytes,26,opt,name=air_purifier,json=airPurifier,proto3,oneof"`
+}
+
+func (*Device_Light) isDevice_Kind() {}
+
+func (*Device_Plug) isDevice_Kind() {}
+
+func (*Device_Blind) isDevice_Kind() {}
+
+func (*Device_Remote) isDevice_Kind() {}
+
+func (*Device_Sensor) isDevice_Kind() {}
+
+func (*Device_Repeater) isDevice_Kind() {}
+
+func (*Device_AirPurifier) isDevice_Kind() {}
+
+type Light struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      int32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Power   int32   `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
-	Created string  `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
-	Devices []int32 `protobuf:"varint,4,rep,packed,name=devices,proto3" json:"devices,omitempty"`
-}
-
-func (x *Group) Reset() {
-	*x = Group{}
+	Power bool `protobuf:"varint,1,opt,name=power,proto3" json:"power,omitempty"`
+	// Dimmer level from 0 to 254.
+	Dimmer int32 `protobuf:"varint,2,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
+	// CIE 1931 color coordinates from 0 to 65535.
+	Xcolor     int32  `protobuf:"varint,3,opt,name=xcolor,proto3" json:"xcolor,omitempty"`
+	Ycolor     int32  `protobuf:"varint,4,opt,name=ycolor,proto3" json:"ycolor,omitempty"`
+	Rgb        string `protobuf:"bytes,5,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	Hue        int32  `protobuf:"varint,6,opt,name=hue,proto3" json:"hue,omitempty"`
+	Saturation int32  `protobuf:"varint,7,opt,name=saturation,proto3" json:"saturation,omitempty"`
+	// Color temperature in mireds, 0 for bulbs that don't report it.
+	ColorTemperature int32 `protobuf:"varint,8,opt,name=color_temperature,json=colorTemperature,proto3" json:"color_temperature,omitempty"`
+}
+
+func (x *Light) Reset() {
+	*x = Light{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -214,13 +536,13 @@ func (x *Group) Reset() {
 	}
 }
 
-func (x *Group) String() string {
+func (x *Light) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Group) ProtoMessage() {}
+func (*Light) ProtoMessage() {}
 
-func (x *Group) ProtoReflect() protoreflect.Message {
+func (x *Light) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -232,47 +554,78 @@ func (x *Group) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Group.ProtoReflect.Descriptor instead.
-func (*Group) Descriptor() ([]byte, []int) {
+// Deprecated: Use Light.ProtoReflect.Descriptor instead.
+func (*Light) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Group) GetId() int32 {
+func (x *Light) GetPower() bool {
 	if x != nil {
-		return x.Id
+		return x.Power
+	}
+	return false
+}
+
+func (x *Light) GetDimmer() int32 {
+	if x != nil {
+		return x.Dimmer
 	}
 	return 0
 }
 
-func (x *Group) GetPower() int32 {
+func (x *Light) GetXcolor() int32 {
 	if x != nil {
-		return x.Power
+		return x.Xcolor
 	}
 	return 0
 }
 
-func (x *Group) GetCreated() string {
+func (x *Light) GetYcolor() int32 {
 	if x != nil {
-		return x.Created
+		return x.Ycolor
+	}
+	return 0
+}
+
+func (x *Light) GetRgb() string {
+	if x != nil {
+		return x.Rgb
 	}
 	return ""
 }
 
-func (x *Group) GetDevices() []int32 {
+func (x *Light) GetHue() int32 {
 	if x != nil {
-		return x.Devices
+		return x.Hue
 	}
-	return nil
+	return 0
 }
 
-type ListGroupsRequest struct {
+func (x *Light) GetSaturation() int32 {
+	if x != nil {
+		return x.Saturation
+	}
+	return 0
+}
+
+func (x *Light) GetColorTemperature() int32 {
+	if x != nil {
+		return x.ColorTemperature
+	}
+	return 0
+}
+
+type Plug struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Power  bool  `protobuf:"varint,1,opt,name=power,proto3" json:"power,omitempty"`
+	Dimmer int32 `protobuf:"varint,2,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
 }
 
-func (x *ListGroupsRequest) Reset() {
-	*x = ListGroupsRequest{}
+func (x *Plug) Reset() {
+	*x = Plug{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -280,13 +633,13 @@ func (x *ListGroupsRequest) Reset() {
 	}
 }
 
-func (x *ListGroupsRequest) String() string {
+func (x *Plug) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGroupsRequest) ProtoMessage() {}
+func (*Plug) ProtoMessage() {}
 
-func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+func (x *Plug) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -298,21 +651,36 @@ func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
-func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Plug.ProtoReflect.Descriptor instead.
+func (*Plug) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{3}
 }
 
-type ListGroupsResponse struct {
+func (x *Plug) GetPower() bool {
+	if x != nil {
+		return x.Power
+	}
+	return false
+}
+
+func (x *Plug) GetDimmer() int32 {
+	if x != nil {
+		return x.Dimmer
+	}
+	return 0
+}
+
+type Blind struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Groups []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Position from 0 (open) to 100 (closed).
+	Position float32 `protobuf:"fixed32,1,opt,name=position,proto3" json:"position,omitempty"`
 }
 
-func (x *ListGroupsResponse) Reset() {
-	*x = ListGroupsResponse{}
+func (x *Blind) Reset() {
+	*x = Blind{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -320,13 +688,13 @@ func (x *ListGroupsResponse) Reset() {
 	}
 }
 
-func (x *ListGroupsResponse) String() string {
+func (x *Blind) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGroupsResponse) ProtoMessage() {}
+func (*Blind) ProtoMessage() {}
 
-func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+func (x *Blind) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -338,28 +706,26 @@ func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
-func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Blind.ProtoReflect.Descriptor instead.
+func (*Blind) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListGroupsResponse) GetGroups() []*Group {
+func (x *Blind) GetPosition() float32 {
 	if x != nil {
-		return x.Groups
+		return x.Position
 	}
-	return nil
+	return 0
 }
 
-type GetGroupRequest struct {
+type Remote struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *GetGroupRequest) Reset() {
-	*x = GetGroupRequest{}
+func (x *Remote) Reset() {
+	*x = Remote{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -367,13 +733,13 @@ func (x *GetGroupRequest) Reset() {
 	}
 }
 
-func (x *GetGroupRequest) String() string {
+func (x *Remote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGroupRequest) ProtoMessage() {}
+func (*Remote) ProtoMessage() {}
 
-func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
+func (x *Remote) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -385,28 +751,19 @@ func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
-func (*GetGroupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Remote.ProtoReflect.Descriptor instead.
+func (*Remote) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetGroupRequest) GetId() int32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-type GetGroupResponse struct {
+type Sensor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Group *Group `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
 }
 
-func (x *GetGroupResponse) Reset() {
-	*x = GetGroupResponse{}
+func (x *Sensor) Reset() {
+	*x = Sensor{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,13 +771,13 @@ func (x *GetGroupResponse) Reset() {
 	}
 }
 
-func (x *GetGroupResponse) String() string {
+func (x *Sensor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGroupResponse) ProtoMessage() {}
+func (*Sensor) ProtoMessage() {}
 
-func (x *GetGroupResponse) ProtoReflect() protoreflect.Message {
+func (x *Sensor) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -432,28 +789,19 @@ func (x *GetGroupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGroupResponse.ProtoReflect.Descriptor instead.
-func (*GetGroupResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Sensor.ProtoReflect.Descriptor instead.
+func (*Sensor) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetGroupResponse) GetGroup() *Group {
-	if x != nil {
-		return x.Group
-	}
-	return nil
-}
-
-type ListDevicesRequest struct {
+type Repeater struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	GroupId int32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 }
 
-func (x *ListDevicesRequest) Reset() {
-	*x = ListDevicesRequest{}
+func (x *Repeater) Reset() {
+	*x = Repeater{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -461,13 +809,13 @@ func (x *ListDevicesRequest) Reset() {
 	}
 }
 
-func (x *ListDevicesRequest) String() string {
+func (x *Repeater) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDevicesRequest) ProtoMessage() {}
+func (*Repeater) ProtoMessage() {}
 
-func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+func (x *Repeater) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -479,28 +827,30 @@ func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
-func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Repeater.ProtoReflect.Descriptor instead.
+func (*Repeater) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListDevicesRequest) GetGroupId() int32 {
-	if x != nil {
-		return x.GroupId
-	}
-	return 0
-}
-
-type ListDevicesResponse struct {
+type AirPurifier struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Fan mode, 0 is off, 1 automatic and 10 to 50 the manual levels.
+	Mode     int32 `protobuf:"varint,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	FanSpeed int32 `protobuf:"varint,2,opt,name=fan_speed,json=fanSpeed,proto3" json:"fan_speed,omitempty"`
+	// PM2.5 concentration in µg/m³.
+	AirQuality                     int32 `protobuf:"varint,3,opt,name=air_quality,json=airQuality,proto3" json:"air_quality,omitempty"`
+	ControlsLocked                 bool  `protobuf:"varint,4,opt,name=controls_locked,json=controlsLocked,proto3" json:"controls_locked,omitempty"`
+	LedsOff                        bool  `protobuf:"varint,5,opt,name=leds_off,json=ledsOff,proto3" json:"leds_off,omitempty"`
+	FilterRuntimeMinutes           int32 `protobuf:"varint,6,opt,name=filter_runtime_minutes,json=filterRuntimeMinutes,proto3" json:"filter_runtime_minutes,omitempty"`
+	FilterLifetimeRemainingMinutes int32 `protobuf:"varint,7,opt,name=filter_lifetime_remaining_minutes,json=filterLifetimeRemainingMinutes,proto3" json:"filter_lifetime_remaining_minutes,omitempty"`
+	MotorRuntimeMinutes            int32 `protobuf:"varint,8,opt,name=motor_runtime_minutes,json=motorRuntimeMinutes,proto3" json:"motor_runtime_minutes,omitempty"`
 }
 
-func (x *ListDevicesResponse) Reset() {
-	*x = ListDevicesResponse{}
+func (x *AirPurifier) Reset() {
+	*x = AirPurifier{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +858,13 @@ func (x *ListDevicesResponse) Reset() {
 	}
 }
 
-func (x *ListDevicesResponse) String() string {
+func (x *AirPurifier) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDevicesResponse) ProtoMessage() {}
+func (*AirPurifier) ProtoMessage() {}
 
-func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+func (x *AirPurifier) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,28 +876,88 @@ func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
-func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AirPurifier.ProtoReflect.Descriptor instead.
+func (*AirPurifier) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListDevicesResponse) GetDevices() []*Device {
+func (x *AirPurifier) GetMode() int32 {
 	if x != nil {
-		return x.Devices
+		return x.Mode
 	}
-	return nil
+	return 0
 }
 
-type ListDeviceIDsRequest struct {
+func (x *AirPurifier) GetFanSpeed() int32 {
+	if x != nil {
+		return x.FanSpeed
+	}
+	return 0
+}
+
+func (x *AirPurifier) GetAirQuality() int32 {
+	if x != nil {
+		return x.AirQuality
+	}
+	return 0
+}
+
+func (x *AirPurifier) GetControlsLocked() bool {
+	if x != nil {
+		return x.ControlsLocked
+	}
+	return false
+}
+
+func (x *AirPurifier) GetLedsOff() bool {
+	if x != nil {
+		return x.LedsOff
+	}
+	return false
+}
+
+func (x *AirPurifier) GetFilterRuntimeMinutes() int32 {
+	if x != nil {
+		return x.FilterRuntimeMinutes
+	}
+	return 0
+}
+
+func (x *AirPurifier) GetFilterLifetimeRemainingMinutes() int32 {
+	if x != nil {
+		return x.FilterLifetimeRemainingMinutes
+	}
+	return 0
+}
+
+func (x *AirPurifier) GetMotorRuntimeMinutes() int32 {
+	if x != nil {
+		return x.MotorRuntimeMinutes
+	}
+	return 0
+}
+
+type Group struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GroupId int32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Id      int32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Power   int32   `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	Created string  `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	Devices []int32 `protobuf:"varint,4,rep,packed,name=devices,proto3" json:"devices,omitempty"`
+	Name    string  `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Dimmer level from 0 to 254.
+	Dimmer int32 `protobuf:"varint,6,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
+	// The scene (mood) last activated.
+	SceneId   int32 `protobuf:"varint,7,opt,name=scene_id,json=sceneId,proto3" json:"scene_id,omitempty"`
+	GroupType int32 `protobuf:"varint,8,opt,name=group_type,json=groupType,proto3" json:"group_type,omitempty"`
+	// The gateway of the group, only set when listing the groups of all gateways.
+	Gateway string `protobuf:"bytes,9,opt,name=gateway,proto3" json:"gateway,omitempty"`
 }
 
-func (x *ListDeviceIDsRequest) Reset() {
-	*x = ListDeviceIDsRequest{}
+func (x *Group) Reset() {
+	*x = Group{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -555,13 +965,13 @@ func (x *ListDeviceIDsRequest) Reset() {
 	}
 }
 
-func (x *ListDeviceIDsRequest) String() string {
+func (x *Group) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeviceIDsRequest) ProtoMessage() {}
+func (*Group) ProtoMessage() {}
 
-func (x *ListDeviceIDsRequest) ProtoReflect() protoreflect.Message {
+func (x *Group) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -573,28 +983,85 @@ func (x *ListDeviceIDsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeviceIDsRequest.ProtoReflect.Descriptor instead.
-func (*ListDeviceIDsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListDeviceIDsRequest) GetGroupId() int32 {
+func (x *Group) GetId() int32 {
 	if x != nil {
-		return x.GroupId
+		return x.Id
 	}
 	return 0
 }
 
-type ListDeviceIDsResponse struct {
+func (x *Group) GetPower() int32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *Group) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Group) GetDevices() []int32 {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetDimmer() int32 {
+	if x != nil {
+		return x.Dimmer
+	}
+	return 0
+}
+
+func (x *Group) GetSceneId() int32 {
+	if x != nil {
+		return x.SceneId
+	}
+	return 0
+}
+
+func (x *Group) GetGroupType() int32 {
+	if x != nil {
+		return x.GroupType
+	}
+	return 0
+}
+
+func (x *Group) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+type ListGroupsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// List the groups of all gateways instead of the one selected by the metadata.
+	AllGateways bool `protobuf:"varint,1,opt,name=all_gateways,json=allGateways,proto3" json:"all_gateways,omitempty"`
 }
 
-func (x *ListDeviceIDsResponse) Reset() {
-	*x = ListDeviceIDsResponse{}
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -602,13 +1069,13 @@ func (x *ListDeviceIDsResponse) Reset() {
 	}
 }
 
-func (x *ListDeviceIDsResponse) String() string {
+func (x *ListGroupsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeviceIDsResponse) ProtoMessage() {}
+func (*ListGroupsRequest) ProtoMessage() {}
 
-func (x *ListDeviceIDsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -620,28 +1087,28 @@ func (x *ListDeviceIDsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeviceIDsResponse.ProtoReflect.Descriptor instead.
-func (*ListDeviceIDsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ListDeviceIDsResponse) GetIds() []int32 {
+func (x *ListGroupsRequest) GetAllGateways() bool {
 	if x != nil {
-		return x.Ids
+		return x.AllGateways
 	}
-	return nil
+	return false
 }
 
-type GetDeviceRequest struct {
+type ListGroupsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Groups []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
 }
 
-func (x *GetDeviceRequest) Reset() {
-	*x = GetDeviceRequest{}
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -649,13 +1116,13 @@ func (x *GetDeviceRequest) Reset() {
 	}
 }
 
-func (x *GetDeviceRequest) String() string {
+func (x *ListGroupsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeviceRequest) ProtoMessage() {}
+func (*ListGroupsResponse) ProtoMessage() {}
 
-func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,28 +1134,28 @@ func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
-func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetDeviceRequest) GetId() int32 {
+func (x *ListGroupsResponse) GetGroups() []*Group {
 	if x != nil {
-		return x.Id
+		return x.Groups
 	}
-	return 0
+	return nil
 }
 
-type GetDeviceResponse struct {
+type GetGroupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *GetDeviceResponse) Reset() {
-	*x = GetDeviceResponse{}
+func (x *GetGroupRequest) Reset() {
+	*x = GetGroupRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -696,13 +1163,13 @@ func (x *GetDeviceResponse) Reset() {
 	}
 }
 
-func (x *GetDeviceResponse) String() string {
+func (x *GetGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeviceResponse) ProtoMessage() {}
+func (*GetGroupRequest) ProtoMessage() {}
 
-func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
+func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -714,31 +1181,28 @@ func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeviceResponse.ProtoReflect.Descriptor instead.
-func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetDeviceResponse) GetDevice() *Device {
+func (x *GetGroupRequest) GetId() int32 {
 	if x != nil {
-		return x.Device
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type ChangeDeviceColorRequest struct {
+type GetGroupResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id     int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Xcolor int32  `protobuf:"varint,2,opt,name=xcolor,proto3" json:"xcolor,omitempty"`
-	Ycolor int32  `protobuf:"varint,3,opt,name=ycolor,proto3" json:"ycolor,omitempty"`
-	Rgb    string `protobuf:"bytes,4,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	Group *Group `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
 }
 
-func (x *ChangeDeviceColorRequest) Reset() {
-	*x = ChangeDeviceColorRequest{}
+func (x *GetGroupResponse) Reset() {
+	*x = GetGroupResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -746,13 +1210,13 @@ func (x *ChangeDeviceColorRequest) Reset() {
 	}
 }
 
-func (x *ChangeDeviceColorRequest) String() string {
+func (x *GetGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDeviceColorRequest) ProtoMessage() {}
+func (*GetGroupResponse) ProtoMessage() {}
 
-func (x *ChangeDeviceColorRequest) ProtoReflect() protoreflect.Message {
+func (x *GetGroupResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -764,61 +1228,42 @@ func (x *ChangeDeviceColorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDeviceColorRequest.ProtoReflect.Descriptor instead.
-func (*ChangeDeviceColorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetGroupResponse) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ChangeDeviceColorRequest) GetId() int32 {
+func (x *GetGroupResponse) GetGroup() *Group {
 	if x != nil {
-		return x.Id
+		return x.Group
 	}
-	return 0
+	return nil
 }
 
-func (x *ChangeDeviceColorRequest) GetXcolor() int32 {
-	if x != nil {
-		return x.Xcolor
-	}
-	return 0
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId int32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 }
 
-func (x *ChangeDeviceColorRequest) GetYcolor() int32 {
-	if x != nil {
-		return x.Ycolor
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *ChangeDeviceColorRequest) GetRgb() string {
-	if x != nil {
-		return x.Rgb
-	}
-	return ""
-}
-
-type ChangeDeviceColorResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-}
-
-func (x *ChangeDeviceColorResponse) Reset() {
-	*x = ChangeDeviceColorResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_tradfri_proto_msgTypes[14]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *ChangeDeviceColorResponse) String() string {
+func (x *ListDevicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDeviceColorResponse) ProtoMessage() {}
+func (*ListDevicesRequest) ProtoMessage() {}
 
-func (x *ChangeDeviceColorResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -830,22 +1275,28 @@ func (x *ChangeDeviceColorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDeviceColorResponse.ProtoReflect.Descriptor instead.
-func (*ChangeDeviceColorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{14}
 }
 
-type ChangeDeviceDimmingRequest struct {
+func (x *ListDevicesRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+type ListDevicesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
 }
 
-func (x *ChangeDeviceDimmingRequest) Reset() {
-	*x = ChangeDeviceDimmingRequest{}
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -853,13 +1304,13 @@ func (x *ChangeDeviceDimmingRequest) Reset() {
 	}
 }
 
-func (x *ChangeDeviceDimmingRequest) String() string {
+func (x *ListDevicesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDeviceDimmingRequest) ProtoMessage() {}
+func (*ListDevicesResponse) ProtoMessage() {}
 
-func (x *ChangeDeviceDimmingRequest) ProtoReflect() protoreflect.Message {
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -871,33 +1322,28 @@ func (x *ChangeDeviceDimmingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDeviceDimmingRequest.ProtoReflect.Descriptor instead.
-func (*ChangeDeviceDimmingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ChangeDeviceDimmingRequest) GetId() int32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *ChangeDeviceDimmingRequest) GetValue() int32 {
+func (x *ListDevicesResponse) GetDevices() []*Device {
 	if x != nil {
-		return x.Value
+		return x.Devices
 	}
-	return 0
+	return nil
 }
 
-type ChangeDeviceDimmingResponse struct {
+type ListDeviceIDsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	GroupId int32 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 }
 
-func (x *ChangeDeviceDimmingResponse) Reset() {
-	*x = ChangeDeviceDimmingResponse{}
+func (x *ListDeviceIDsRequest) Reset() {
+	*x = ListDeviceIDsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -905,13 +1351,13 @@ func (x *ChangeDeviceDimmingResponse) Reset() {
 	}
 }
 
-func (x *ChangeDeviceDimmingResponse) String() string {
+func (x *ListDeviceIDsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDeviceDimmingResponse) ProtoMessage() {}
+func (*ListDeviceIDsRequest) ProtoMessage() {}
 
-func (x *ChangeDeviceDimmingResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDeviceIDsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -923,21 +1369,28 @@ func (x *ChangeDeviceDimmingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDeviceDimmingResponse.ProtoReflect.Descriptor instead.
-func (*ChangeDeviceDimmingResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDeviceIDsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeviceIDsRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{16}
 }
 
-type TurnDeviceOnRequest struct {
+func (x *ListDeviceIDsRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+type ListDeviceIDsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 }
 
-func (x *TurnDeviceOnRequest) Reset() {
-	*x = TurnDeviceOnRequest{}
+func (x *ListDeviceIDsResponse) Reset() {
+	*x = ListDeviceIDsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -945,13 +1398,13 @@ func (x *TurnDeviceOnRequest) Reset() {
 	}
 }
 
-func (x *TurnDeviceOnRequest) String() string {
+func (x *ListDeviceIDsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TurnDeviceOnRequest) ProtoMessage() {}
+func (*ListDeviceIDsResponse) ProtoMessage() {}
 
-func (x *TurnDeviceOnRequest) ProtoReflect() protoreflect.Message {
+func (x *ListDeviceIDsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -963,26 +1416,29 @@ func (x *TurnDeviceOnRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TurnDeviceOnRequest.ProtoReflect.Descriptor instead.
-func (*TurnDeviceOnRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDeviceIDsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeviceIDsResponse) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *TurnDeviceOnRequest) GetId() int32 {
+func (x *ListDeviceIDsResponse) GetIds() []int32 {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-type TurnDeviceOnResponse struct {
+type ListAllDevicesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// List the devices of all gateways instead of the one selected by the metadata.
+	AllGateways bool `protobuf:"varint,1,opt,name=all_gateways,json=allGateways,proto3" json:"all_gateways,omitempty"`
 }
 
-func (x *TurnDeviceOnResponse) Reset() {
-	*x = TurnDeviceOnResponse{}
+func (x *ListAllDevicesRequest) Reset() {
+	*x = ListAllDevicesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -990,13 +1446,13 @@ func (x *TurnDeviceOnResponse) Reset() {
 	}
 }
 
-func (x *TurnDeviceOnResponse) String() string {
+func (x *ListAllDevicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TurnDeviceOnResponse) ProtoMessage() {}
+func (*ListAllDevicesRequest) ProtoMessage() {}
 
-func (x *TurnDeviceOnResponse) ProtoReflect() protoreflect.Message {
+func (x *ListAllDevicesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1008,12 +1464,19 @@ func (x *TurnDeviceOnResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TurnDeviceOnResponse.ProtoReflect.Descriptor instead.
-func (*TurnDeviceOnResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListAllDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListAllDevicesRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{18}
 }
 
-type TurnDeviceOffRequest struct {
+func (x *ListAllDevicesRequest) GetAllGateways() bool {
+	if x != nil {
+		return x.AllGateways
+	}
+	return false
+}
+
+type GetDeviceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1021,8 +1484,8 @@ type TurnDeviceOffRequest struct {
 	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *TurnDeviceOffRequest) Reset() {
-	*x = TurnDeviceOffRequest{}
+func (x *GetDeviceRequest) Reset() {
+	*x = GetDeviceRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1030,13 +1493,13 @@ func (x *TurnDeviceOffRequest) Reset() {
 	}
 }
 
-func (x *TurnDeviceOffRequest) String() string {
+func (x *GetDeviceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TurnDeviceOffRequest) ProtoMessage() {}
+func (*GetDeviceRequest) ProtoMessage() {}
 
-func (x *TurnDeviceOffRequest) ProtoReflect() protoreflect.Message {
+func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1048,26 +1511,28 @@ func (x *TurnDeviceOffRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TurnDeviceOffRequest.ProtoReflect.Descriptor instead.
-func (*TurnDeviceOffRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *TurnDeviceOffRequest) GetId() int32 {
+func (x *GetDeviceRequest) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type TurnDeviceOffResponse struct {
+type GetDeviceResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
 }
 
-func (x *TurnDeviceOffResponse) Reset() {
-	*x = TurnDeviceOffResponse{}
+func (x *GetDeviceResponse) Reset() {
+	*x = GetDeviceResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1075,13 +1540,13 @@ func (x *TurnDeviceOffResponse) Reset() {
 	}
 }
 
-func (x *TurnDeviceOffResponse) String() string {
+func (x *GetDeviceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TurnDeviceOffResponse) ProtoMessage() {}
+func (*GetDeviceResponse) ProtoMessage() {}
 
-func (x *TurnDeviceOffResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1093,22 +1558,33 @@ func (x *TurnDeviceOffResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TurnDeviceOffResponse.ProtoReflect.Descriptor instead.
-func (*TurnDeviceOffResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDeviceResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{20}
 }
 
-type ChangeDevicePositioningRequest struct {
+func (x *GetDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+type ChangeDeviceColorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Id     int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Xcolor int32  `protobuf:"varint,2,opt,name=xcolor,proto3" json:"xcolor,omitempty"`
+	Ycolor int32  `protobuf:"varint,3,opt,name=ycolor,proto3" json:"ycolor,omitempty"`
+	Rgb    string `protobuf:"bytes,4,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	// Duration of the color change, rounded down to tenths of a second. 0 uses the default of 500 ms.
+	TransitionTimeMs int32 `protobuf:"varint,5,opt,name=transition_time_ms,json=transitionTimeMs,proto3" json:"transition_time_ms,omitempty"`
 }
 
-func (x *ChangeDevicePositioningRequest) Reset() {
-	*x = ChangeDevicePositioningRequest{}
+func (x *ChangeDeviceColorRequest) Reset() {
+	*x = ChangeDeviceColorRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1116,13 +1592,13 @@ func (x *ChangeDevicePositioningRequest) Reset() {
 	}
 }
 
-func (x *ChangeDevicePositioningRequest) String() string {
+func (x *ChangeDeviceColorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDevicePositioningRequest) ProtoMessage() {}
+func (*ChangeDeviceColorRequest) ProtoMessage() {}
 
-func (x *ChangeDevicePositioningRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangeDeviceColorRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1134,33 +1610,63 @@ func (x *ChangeDevicePositioningRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDevicePositioningRequest.ProtoReflect.Descriptor instead.
-func (*ChangeDevicePositioningRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeDeviceColorRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceColorRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ChangeDevicePositioningRequest) GetId() int32 {
+func (x *ChangeDeviceColorRequest) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *ChangeDevicePositioningRequest) GetValue() int32 {
+func (x *ChangeDeviceColorRequest) GetXcolor() int32 {
 	if x != nil {
-		return x.Value
+		return x.Xcolor
 	}
 	return 0
 }
 
-type ChangeDevicePositioningResponse struct {
+func (x *ChangeDeviceColorRequest) GetYcolor() int32 {
+	if x != nil {
+		return x.Ycolor
+	}
+	return 0
+}
+
+func (x *ChangeDeviceColorRequest) GetRgb() string {
+	if x != nil {
+		return x.Rgb
+	}
+	return ""
+}
+
+func (x *ChangeDeviceColorRequest) GetTransitionTimeMs() int32 {
+	if x != nil {
+		return x.TransitionTimeMs
+	}
+	return 0
+}
+
+type ChangeDeviceColorHSLRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Hue in degrees from 0 to 360.
+	Hue float64 `protobuf:"fixed64,2,opt,name=hue,proto3" json:"hue,omitempty"`
+	// Saturation and lightness in percent.
+	Saturation float64 `protobuf:"fixed64,3,opt,name=saturation,proto3" json:"saturation,omitempty"`
+	Lightness  float64 `protobuf:"fixed64,4,opt,name=lightness,proto3" json:"lightness,omitempty"`
+	// Duration of the color change. 0 uses the default of 500 ms.
+	TransitionTimeMs int32 `protobuf:"varint,5,opt,name=transition_time_ms,json=transitionTimeMs,proto3" json:"transition_time_ms,omitempty"`
 }
 
-func (x *ChangeDevicePositioningResponse) Reset() {
-	*x = ChangeDevicePositioningResponse{}
+func (x *ChangeDeviceColorHSLRequest) Reset() {
+	*x = ChangeDeviceColorHSLRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tradfri_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1168,13 +1674,13 @@ func (x *ChangeDevicePositioningResponse) Reset() {
 	}
 }
 
-func (x *ChangeDevicePositioningResponse) String() string {
+func (x *ChangeDeviceColorHSLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeDevicePositioningResponse) ProtoMessage() {}
+func (*ChangeDeviceColorHSLRequest) ProtoMessage() {}
 
-func (x *ChangeDevicePositioningResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangeDeviceColorHSLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tradfri_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1186,168 +1692,2443 @@ func (x *ChangeDevicePositioningResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeDevicePositioningResponse.ProtoReflect.Descriptor instead.
-func (*ChangeDevicePositioningResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeDeviceColorHSLRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceColorHSLRequest) Descriptor() ([]byte, []int) {
 	return file_tradfri_proto_rawDescGZIP(), []int{22}
 }
 
-var File_tradfri_proto protoreflect.FileDescriptor
+func (x *ChangeDeviceColorHSLRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
 
-var file_tradfri_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x74, 0x72, 0x61, 0x64, 0x66, 0x72, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x0b, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x72, 0x0a, 0x0e,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x62, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x62, 0x61, 0x74,
-	0x22, 0xcd, 0x01, 0x0a, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
-	0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
-	0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x78, 0x63,
-	0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03,
-	0x72, 0x67, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x67, 0x62, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70,
-	0x6f, 0x77, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x61, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12,
-	0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x40, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a,
-	0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65,
-	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3c, 0x0a,
-	0x10, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x28, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x2f, 0x0a, 0x12, 0x4c,
-	0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x13,
-	0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x22, 0x31, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x03, 0x69, 0x64, 0x73,
-	0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x40, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x06,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x22, 0x6c, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x79, 0x63,
-	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x79, 0x63, 0x6f, 0x6c,
-	0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x67, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x72, 0x67, 0x62, 0x22, 0x1b, 0x0a, 0x19, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x42, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x54,
-	0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x26, 0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x54,
-	0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x1e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x21, 0x0a, 0x1f,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0xa3, 0x07, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x64, 0x66, 0x72, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
-	0x12, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
-	0x1c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65,
-	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52,
-	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x2e,
-	0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x49, 0x44, 0x73, 0x12, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
-	0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x09,
-	0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x11, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
-	0x25, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x6a, 0x0a, 0x13, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x28, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c,
-	0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x12, 0x20, 0x2e, 0x67,
-	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72,
-	0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x4f, 0x66, 0x66, 0x12, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x76, 0x0a,
-	0x17, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x72, 0x69, 0x6b, 0x6c, 0x75, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x72,
-	0x2f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x6f, 0x6c,
-	0x61, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *ChangeDeviceColorHSLRequest) GetHue() float64 {
+	if x != nil {
+		return x.Hue
+	}
+	return 0
 }
 
-var (
+func (x *ChangeDeviceColorHSLRequest) GetSaturation() float64 {
+	if x != nil {
+		return x.Saturation
+	}
+	return 0
+}
+
+func (x *ChangeDeviceColorHSLRequest) GetLightness() float64 {
+	if x != nil {
+		return x.Lightness
+	}
+	return 0
+}
+
+func (x *ChangeDeviceColorHSLRequest) GetTransitionTimeMs() int32 {
+	if x != nil {
+		return x.TransitionTimeMs
+	}
+	return 0
+}
+
+type ChangeDeviceColorTemperatureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Color temperature in Kelvin, IKEA white spectrum bulbs support 2200 to 4000.
+	Kelvin int32 `protobuf:"varint,2,opt,name=kelvin,proto3" json:"kelvin,omitempty"`
+	// Duration of the color change. 0 uses the default of 500 ms.
+	TransitionTimeMs int32 `protobuf:"varint,3,opt,name=transition_time_ms,json=transitionTimeMs,proto3" json:"transition_time_ms,omitempty"`
+}
+
+func (x *ChangeDeviceColorTemperatureRequest) Reset() {
+	*x = ChangeDeviceColorTemperatureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceColorTemperatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceColorTemperatureRequest) ProtoMessage() {}
+
+func (x *ChangeDeviceColorTemperatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceColorTemperatureRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceColorTemperatureRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ChangeDeviceColorTemperatureRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeDeviceColorTemperatureRequest) GetKelvin() int32 {
+	if x != nil {
+		return x.Kelvin
+	}
+	return 0
+}
+
+func (x *ChangeDeviceColorTemperatureRequest) GetTransitionTimeMs() int32 {
+	if x != nil {
+		return x.TransitionTimeMs
+	}
+	return 0
+}
+
+type ChangeDeviceColorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeDeviceColorResponse) Reset() {
+	*x = ChangeDeviceColorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceColorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceColorResponse) ProtoMessage() {}
+
+func (x *ChangeDeviceColorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceColorResponse.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceColorResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{24}
+}
+
+type ChangeDeviceDimmingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ChangeDeviceDimmingRequest) Reset() {
+	*x = ChangeDeviceDimmingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceDimmingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceDimmingRequest) ProtoMessage() {}
+
+func (x *ChangeDeviceDimmingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceDimmingRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceDimmingRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChangeDeviceDimmingRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeDeviceDimmingRequest) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ChangeDeviceDimmingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeDeviceDimmingResponse) Reset() {
+	*x = ChangeDeviceDimmingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceDimmingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceDimmingResponse) ProtoMessage() {}
+
+func (x *ChangeDeviceDimmingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceDimmingResponse.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceDimmingResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{26}
+}
+
+type TurnDeviceOnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnDeviceOnRequest) Reset() {
+	*x = TurnDeviceOnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnDeviceOnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDeviceOnRequest) ProtoMessage() {}
+
+func (x *TurnDeviceOnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDeviceOnRequest.ProtoReflect.Descriptor instead.
+func (*TurnDeviceOnRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TurnDeviceOnRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnDeviceOnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnDeviceOnResponse) Reset() {
+	*x = TurnDeviceOnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnDeviceOnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDeviceOnResponse) ProtoMessage() {}
+
+func (x *TurnDeviceOnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDeviceOnResponse.ProtoReflect.Descriptor instead.
+func (*TurnDeviceOnResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{28}
+}
+
+type TurnDeviceOffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnDeviceOffRequest) Reset() {
+	*x = TurnDeviceOffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnDeviceOffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDeviceOffRequest) ProtoMessage() {}
+
+func (x *TurnDeviceOffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDeviceOffRequest.ProtoReflect.Descriptor instead.
+func (*TurnDeviceOffRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TurnDeviceOffRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnDeviceOffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnDeviceOffResponse) Reset() {
+	*x = TurnDeviceOffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnDeviceOffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnDeviceOffResponse) ProtoMessage() {}
+
+func (x *TurnDeviceOffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnDeviceOffResponse.ProtoReflect.Descriptor instead.
+func (*TurnDeviceOffResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{30}
+}
+
+type ChangeDeviceStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Power bool  `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	// Dimmer level from 0 to 254.
+	Dimmer int32 `protobuf:"varint,3,opt,name=dimmer,proto3" json:"dimmer,omitempty"`
+}
+
+func (x *ChangeDeviceStateRequest) Reset() {
+	*x = ChangeDeviceStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceStateRequest) ProtoMessage() {}
+
+func (x *ChangeDeviceStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceStateRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceStateRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ChangeDeviceStateRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeDeviceStateRequest) GetPower() bool {
+	if x != nil {
+		return x.Power
+	}
+	return false
+}
+
+func (x *ChangeDeviceStateRequest) GetDimmer() int32 {
+	if x != nil {
+		return x.Dimmer
+	}
+	return 0
+}
+
+type ChangeDeviceStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeDeviceStateResponse) Reset() {
+	*x = ChangeDeviceStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDeviceStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDeviceStateResponse) ProtoMessage() {}
+
+func (x *ChangeDeviceStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDeviceStateResponse.ProtoReflect.Descriptor instead.
+func (*ChangeDeviceStateResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{32}
+}
+
+type TurnOutletOnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnOutletOnRequest) Reset() {
+	*x = TurnOutletOnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnOutletOnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnOutletOnRequest) ProtoMessage() {}
+
+func (x *TurnOutletOnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnOutletOnRequest.ProtoReflect.Descriptor instead.
+func (*TurnOutletOnRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TurnOutletOnRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnOutletOnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnOutletOnResponse) Reset() {
+	*x = TurnOutletOnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnOutletOnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnOutletOnResponse) ProtoMessage() {}
+
+func (x *TurnOutletOnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnOutletOnResponse.ProtoReflect.Descriptor instead.
+func (*TurnOutletOnResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{34}
+}
+
+type TurnOutletOffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnOutletOffRequest) Reset() {
+	*x = TurnOutletOffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnOutletOffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnOutletOffRequest) ProtoMessage() {}
+
+func (x *TurnOutletOffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnOutletOffRequest.ProtoReflect.Descriptor instead.
+func (*TurnOutletOffRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TurnOutletOffRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnOutletOffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnOutletOffResponse) Reset() {
+	*x = TurnOutletOffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnOutletOffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnOutletOffResponse) ProtoMessage() {}
+
+func (x *TurnOutletOffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnOutletOffResponse.ProtoReflect.Descriptor instead.
+func (*TurnOutletOffResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{36}
+}
+
+type ChangeDevicePositioningRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Superseded by position, used when position is not set.
+	//
+	// Deprecated: Marked as deprecated in tradfri.proto.
+	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Position from 0 (open) to 100 (closed).
+	Position *float32 `protobuf:"fixed32,3,opt,name=position,proto3,oneof" json:"position,omitempty"`
+}
+
+func (x *ChangeDevicePositioningRequest) Reset() {
+	*x = ChangeDevicePositioningRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDevicePositioningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDevicePositioningRequest) ProtoMessage() {}
+
+func (x *ChangeDevicePositioningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDevicePositioningRequest.ProtoReflect.Descriptor instead.
+func (*ChangeDevicePositioningRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ChangeDevicePositioningRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in tradfri.proto.
+func (x *ChangeDevicePositioningRequest) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ChangeDevicePositioningRequest) GetPosition() float32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+type ChangeDevicePositioningResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeDevicePositioningResponse) Reset() {
+	*x = ChangeDevicePositioningResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeDevicePositioningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeDevicePositioningResponse) ProtoMessage() {}
+
+func (x *ChangeDevicePositioningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeDevicePositioningResponse.ProtoReflect.Descriptor instead.
+func (*ChangeDevicePositioningResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{38}
+}
+
+type TurnGroupOnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnGroupOnRequest) Reset() {
+	*x = TurnGroupOnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnGroupOnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnGroupOnRequest) ProtoMessage() {}
+
+func (x *TurnGroupOnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnGroupOnRequest.ProtoReflect.Descriptor instead.
+func (*TurnGroupOnRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TurnGroupOnRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnGroupOnResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnGroupOnResponse) Reset() {
+	*x = TurnGroupOnResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnGroupOnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnGroupOnResponse) ProtoMessage() {}
+
+func (x *TurnGroupOnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnGroupOnResponse.ProtoReflect.Descriptor instead.
+func (*TurnGroupOnResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{40}
+}
+
+type TurnGroupOffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TurnGroupOffRequest) Reset() {
+	*x = TurnGroupOffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnGroupOffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnGroupOffRequest) ProtoMessage() {}
+
+func (x *TurnGroupOffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnGroupOffRequest.ProtoReflect.Descriptor instead.
+func (*TurnGroupOffRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TurnGroupOffRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TurnGroupOffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TurnGroupOffResponse) Reset() {
+	*x = TurnGroupOffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TurnGroupOffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnGroupOffResponse) ProtoMessage() {}
+
+func (x *TurnGroupOffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnGroupOffResponse.ProtoReflect.Descriptor instead.
+func (*TurnGroupOffResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{42}
+}
+
+type ChangeGroupDimmingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Dimmer level from 0 to 254.
+	Value int32 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ChangeGroupDimmingRequest) Reset() {
+	*x = ChangeGroupDimmingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeGroupDimmingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeGroupDimmingRequest) ProtoMessage() {}
+
+func (x *ChangeGroupDimmingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeGroupDimmingRequest.ProtoReflect.Descriptor instead.
+func (*ChangeGroupDimmingRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ChangeGroupDimmingRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeGroupDimmingRequest) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ChangeGroupDimmingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChangeGroupDimmingResponse) Reset() {
+	*x = ChangeGroupDimmingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeGroupDimmingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeGroupDimmingResponse) ProtoMessage() {}
+
+func (x *ChangeGroupDimmingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeGroupDimmingResponse.ProtoReflect.Descriptor instead.
+func (*ChangeGroupDimmingResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{44}
+}
+
+type ActivateGroupSceneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SceneId int32 `protobuf:"varint,2,opt,name=scene_id,json=sceneId,proto3" json:"scene_id,omitempty"`
+}
+
+func (x *ActivateGroupSceneRequest) Reset() {
+	*x = ActivateGroupSceneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateGroupSceneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateGroupSceneRequest) ProtoMessage() {}
+
+func (x *ActivateGroupSceneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateGroupSceneRequest.ProtoReflect.Descriptor instead.
+func (*ActivateGroupSceneRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ActivateGroupSceneRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActivateGroupSceneRequest) GetSceneId() int32 {
+	if x != nil {
+		return x.SceneId
+	}
+	return 0
+}
+
+type ActivateGroupSceneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ActivateGroupSceneResponse) Reset() {
+	*x = ActivateGroupSceneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateGroupSceneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateGroupSceneResponse) ProtoMessage() {}
+
+func (x *ActivateGroupSceneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateGroupSceneResponse.ProtoReflect.Descriptor instead.
+func (*ActivateGroupSceneResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{46}
+}
+
+type WatchDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Devices to watch, empty watches all devices unless group_id is set.
+	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// Watch the devices of this group in addition to ids.
+	GroupId int32 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// Do not send the current state of the devices before the first change.
+	SkipSnapshot bool `protobuf:"varint,3,opt,name=skip_snapshot,json=skipSnapshot,proto3" json:"skip_snapshot,omitempty"`
+}
+
+func (x *WatchDevicesRequest) Reset() {
+	*x = WatchDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDevicesRequest) ProtoMessage() {}
+
+func (x *WatchDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDevicesRequest.ProtoReflect.Descriptor instead.
+func (*WatchDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WatchDevicesRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchDevicesRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *WatchDevicesRequest) GetSkipSnapshot() bool {
+	if x != nil {
+		return x.SkipSnapshot
+	}
+	return false
+}
+
+type WatchGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Groups to watch, empty watches all groups.
+	Ids []int32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// Do not send the current state of the groups before the first change.
+	SkipSnapshot bool `protobuf:"varint,2,opt,name=skip_snapshot,json=skipSnapshot,proto3" json:"skip_snapshot,omitempty"`
+}
+
+func (x *WatchGroupsRequest) Reset() {
+	*x = WatchGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchGroupsRequest) ProtoMessage() {}
+
+func (x *WatchGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchGroupsRequest.ProtoReflect.Descriptor instead.
+func (*WatchGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WatchGroupsRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchGroupsRequest) GetSkipSnapshot() bool {
+	if x != nil {
+		return x.SkipSnapshot
+	}
+	return false
+}
+
+type RawRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CoAP method, one of GET, PUT, POST or DELETE.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Gateway path, e.g. "/15011/15012".
+	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Accept readable JSON keys in the payload, e.g. "power" for "5850", and return the response payload
+	// with readable keys.
+	Readable bool `protobuf:"varint,4,opt,name=readable,proto3" json:"readable,omitempty"`
+}
+
+func (x *RawRequestRequest) Reset() {
+	*x = RawRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawRequestRequest) ProtoMessage() {}
+
+func (x *RawRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawRequestRequest.ProtoReflect.Descriptor instead.
+func (*RawRequestRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RawRequestRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RawRequestRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RawRequestRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *RawRequestRequest) GetReadable() bool {
+	if x != nil {
+		return x.Readable
+	}
+	return false
+}
+
+type RawOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Opaque values are hex encoded.
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *RawOption) Reset() {
+	*x = RawOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawOption) ProtoMessage() {}
+
+func (x *RawOption) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawOption.ProtoReflect.Descriptor instead.
+func (*RawOption) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RawOption) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *RawOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RawOption) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RawRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CoAP response code in class.detail notation, e.g. "2.05".
+	Code     string       `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CodeName string       `protobuf:"bytes,2,opt,name=code_name,json=codeName,proto3" json:"code_name,omitempty"`
+	Options  []*RawOption `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	Payload  []byte       `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *RawRequestResponse) Reset() {
+	*x = RawRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawRequestResponse) ProtoMessage() {}
+
+func (x *RawRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawRequestResponse.ProtoReflect.Descriptor instead.
+func (*RawRequestResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RawRequestResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RawRequestResponse) GetCodeName() string {
+	if x != nil {
+		return x.CodeName
+	}
+	return ""
+}
+
+func (x *RawRequestResponse) GetOptions() []*RawOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *RawRequestResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type BatchOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Exactly one of device_id and group_id must be set.
+	DeviceId int32 `protobuf:"varint,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	GroupId  int32 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// The attributes to change, applied in the order power, dimmer, color, position and scene. Color and
+	// position operations on a group are applied to each of its devices.
+	Power       *int32   `protobuf:"varint,3,opt,name=power,proto3,oneof" json:"power,omitempty"`
+	Dimmer      *int32   `protobuf:"varint,4,opt,name=dimmer,proto3,oneof" json:"dimmer,omitempty"`
+	RgbColor    string   `protobuf:"bytes,5,opt,name=rgb_color,json=rgbColor,proto3" json:"rgb_color,omitempty"`
+	X           *int32   `protobuf:"varint,6,opt,name=x,proto3,oneof" json:"x,omitempty"`
+	Y           *int32   `protobuf:"varint,7,opt,name=y,proto3,oneof" json:"y,omitempty"`
+	Positioning *float32 `protobuf:"fixed32,8,opt,name=positioning,proto3,oneof" json:"positioning,omitempty"`
+	SceneId     *int32   `protobuf:"varint,9,opt,name=scene_id,json=sceneId,proto3,oneof" json:"scene_id,omitempty"`
+}
+
+func (x *BatchOperation) Reset() {
+	*x = BatchOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperation) ProtoMessage() {}
+
+func (x *BatchOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperation.ProtoReflect.Descriptor instead.
+func (*BatchOperation) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BatchOperation) GetDeviceId() int32 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetPower() int32 {
+	if x != nil && x.Power != nil {
+		return *x.Power
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetDimmer() int32 {
+	if x != nil && x.Dimmer != nil {
+		return *x.Dimmer
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetRgbColor() string {
+	if x != nil {
+		return x.RgbColor
+	}
+	return ""
+}
+
+func (x *BatchOperation) GetX() int32 {
+	if x != nil && x.X != nil {
+		return *x.X
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetY() int32 {
+	if x != nil && x.Y != nil {
+		return *x.Y
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetPositioning() float32 {
+	if x != nil && x.Positioning != nil {
+		return *x.Positioning
+	}
+	return 0
+}
+
+func (x *BatchOperation) GetSceneId() int32 {
+	if x != nil && x.SceneId != nil {
+		return *x.SceneId
+	}
+	return 0
+}
+
+type BatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operations []*BatchOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// Skip the operations not yet started once one has failed.
+	StopOnError bool `protobuf:"varint,2,opt,name=stop_on_error,json=stopOnError,proto3" json:"stop_on_error,omitempty"`
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BatchRequest) GetOperations() []*BatchOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *BatchRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.StopOnError
+	}
+	return false
+}
+
+type BatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index  int32              `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status BatchResult_Status `protobuf:"varint,2,opt,name=status,proto3,enum=grpc_server.BatchResult_Status" json:"status,omitempty"`
+	// The gateway's response code of the last call made for the operation.
+	Result     string  `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Error      string  `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs float64 `protobuf:"fixed64,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *BatchResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchResult) GetStatus() BatchResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return BatchResult_STATUS_UNSPECIFIED
+}
+
+func (x *BatchResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchResult) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type BatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The results in the order of the operations of the request.
+	Results    []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	DurationMs float64        `protobuf:"fixed64,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *BatchResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchResponse) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type ListGatewaysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListGatewaysRequest) Reset() {
+	*x = ListGatewaysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGatewaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysRequest) ProtoMessage() {}
+
+func (x *ListGatewaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysRequest.ProtoReflect.Descriptor instead.
+func (*ListGatewaysRequest) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{56}
+}
+
+type Gateway struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The default gateway serves the calls that do not name a gateway.
+	IsDefault bool `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+}
+
+func (x *Gateway) Reset() {
+	*x = Gateway{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Gateway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gateway) ProtoMessage() {}
+
+func (x *Gateway) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
+func (*Gateway) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Gateway) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Gateway) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type ListGatewaysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gateways []*Gateway `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"`
+}
+
+func (x *ListGatewaysResponse) Reset() {
+	*x = ListGatewaysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tradfri_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGatewaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysResponse) ProtoMessage() {}
+
+func (x *ListGatewaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tradfri_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysResponse.ProtoReflect.Descriptor instead.
+func (*ListGatewaysResponse) Descriptor() ([]byte, []int) {
+	return file_tradfri_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListGatewaysResponse) GetGateways() []*Gateway {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+var File_tradfri_proto protoreflect.FileDescriptor
+
+var file_tradfri_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x74, 0x72, 0x61, 0x64, 0x66, 0x72, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0b, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xae, 0x03,
+	0x0a, 0x0e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72,
+	0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x3b, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x62, 0x61, 0x74, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x74, 0x61, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6f, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xda,
+	0x04, 0x0a, 0x06, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x67, 0x68, 0x74, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x27,
+	0x0a, 0x04, 0x70, 0x6c, 0x75, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x48,
+	0x00, 0x52, 0x04, 0x70, 0x6c, 0x75, 0x67, 0x12, 0x2a, 0x0a, 0x05, 0x62, 0x6c, 0x69, 0x6e, 0x64,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x05, 0x62, 0x6c,
+	0x69, 0x6e, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x12, 0x33, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x72, 0x18, 0x19, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65,
+	0x70, 0x65, 0x61, 0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x69, 0x72, 0x5f, 0x70, 0x75,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x69, 0x72, 0x50, 0x75,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x69, 0x72, 0x50, 0x75, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1a, 0x0a,
+	0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18,
+	0x01, 0x52, 0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x03, 0x72, 0x67, 0x62,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x03, 0x72, 0x67, 0x62, 0x12,
+	0x18, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xd6, 0x01, 0x0a, 0x05,
+	0x4c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x6d,
+	0x6d, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x79,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x79, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x67, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x72, 0x67, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x68, 0x75, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x61, 0x74, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x61, 0x74,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0x34, 0x0a, 0x04, 0x50, 0x6c, 0x75, 0x67, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x22, 0x23, 0x0a, 0x05, 0x42, 0x6c,
+	0x69, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x08, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x22, 0x08, 0x0a, 0x06, 0x53, 0x65, 0x6e,
+	0x73, 0x6f, 0x72, 0x22, 0x0a, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x72, 0x22,
+	0xd8, 0x02, 0x0a, 0x0b, 0x41, 0x69, 0x72, 0x50, 0x75, 0x72, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x6e, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x66, 0x61, 0x6e, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x69, 0x72, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x69, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x73, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65,
+	0x64, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c, 0x65,
+	0x64, 0x73, 0x4f, 0x66, 0x66, 0x12, 0x34, 0x0a, 0x16, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x49, 0x0a, 0x21, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x72,
+	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4c, 0x69,
+	0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x52, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x05, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x63,
+	0x65, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x63,
+	0x65, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x22, 0x36,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x5f, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x22, 0x40, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x28, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x2f, 0x0a, 0x12, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x22, 0x31, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x3a,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x5f, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61,
+	0x6c, 0x6c, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x40,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x22, 0x9a, 0x01, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x78, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x78,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x79, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x67, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x67, 0x62, 0x12,
+	0x2c, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0xab, 0x01,
+	0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x48, 0x53, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x68, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x68, 0x75, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x74, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a,
+	0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0x7b, 0x0a, 0x23, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x6c, 0x76, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6b, 0x65, 0x6c, 0x76, 0x69, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x42, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x54, 0x75, 0x72, 0x6e,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x16, 0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x17, 0x0a, 0x15, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x6d, 0x6d, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d,
+	0x65, 0x72, 0x22, 0x1b, 0x0a, 0x19, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x25, 0x0a, 0x13, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75,
+	0x74, 0x6c, 0x65, 0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26,
+	0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75,
+	0x74, 0x6c, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x78, 0x0a, 0x1e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x18, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x42, 0x02, 0x18, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x1f, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x0a, 0x12,
+	0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x54, 0x75, 0x72,
+	0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x16, 0x0a, 0x14, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x66, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x41, 0x0a, 0x19, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x19, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x63, 0x65, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x49,
+	0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x53, 0x63, 0x65, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x67, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4b, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x75, 0x0a, 0x11, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x4d, 0x0a, 0x09,
+	0x52, 0x61, 0x77, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x12,
+	0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x52, 0x61, 0x77, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
+	0xc8, 0x02, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x05, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x06, 0x64, 0x69, 0x6d, 0x6d, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x67, 0x62, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x67, 0x62, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x11, 0x0a, 0x01, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x01, 0x78, 0x88,
+	0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52,
+	0x01, 0x79, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x0b, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08,
+	0x73, 0x63, 0x65, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x48, 0x05,
+	0x52, 0x07, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06,
+	0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x69, 0x6d, 0x6d, 0x65,
+	0x72, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x78, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x79, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x6f, 0x0a, 0x0c, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0a, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x5f,
+	0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x73, 0x74, 0x6f, 0x70, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xee, 0x01, 0x0a, 0x0b,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0x41, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f,
+	0x4b, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x22, 0x64, 0x0a, 0x0d,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x07, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x48, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x08, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x52, 0x08, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x73, 0x2a, 0xfc, 0x01, 0x0a, 0x0b, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x13, 0x0a, 0x0f, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
+	0x44, 0x43, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x42, 0x41,
+	0x54, 0x54, 0x45, 0x52, 0x59, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x50, 0x4f, 0x57, 0x45, 0x52,
+	0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x45, 0x58, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c,
+	0x5f, 0x42, 0x41, 0x54, 0x54, 0x45, 0x52, 0x59, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x4f,
+	0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x42, 0x41, 0x54, 0x54, 0x45,
+	0x52, 0x59, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x45, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x4f,
+	0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x53, 0x42, 0x10, 0x06,
+	0x12, 0x16, 0x0a, 0x12, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x4d, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x07, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x4f, 0x57, 0x45,
+	0x52, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x53, 0x4f, 0x4c, 0x41, 0x52, 0x10, 0x08,
+	0x32, 0xf6, 0x11, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x64, 0x66, 0x72, 0x69, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x1c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1f,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x44, 0x73, 0x12, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x25, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6a, 0x0a, 0x13, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69,
+	0x6e, 0x67, 0x12, 0x27, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d,
+	0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x54, 0x75, 0x72, 0x6e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x6e, 0x12, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58,
+	0x0a, 0x0d, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x12,
+	0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75,
+	0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x54, 0x75, 0x72, 0x6e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6a,
+	0x0a, 0x14, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x48, 0x53, 0x4c, 0x12, 0x28, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x48, 0x53, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x7a, 0x0a, 0x1c, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x30, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75,
+	0x74, 0x6c, 0x65, 0x74, 0x4f, 0x6e, 0x12, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65,
+	0x74, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a,
+	0x0d, 0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x12, 0x21,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72,
+	0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x54, 0x75, 0x72, 0x6e, 0x4f, 0x75, 0x74, 0x6c, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x76, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x2b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x0b, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x6e, 0x12, 0x1f,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72,
+	0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x54, 0x75,
+	0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4f, 0x66, 0x66, 0x12, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x66, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x54, 0x75, 0x72, 0x6e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x66, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x12, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67,
+	0x12, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x44, 0x69, 0x6d, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x12, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x53, 0x63, 0x65, 0x6e, 0x65, 0x12, 0x26, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x63, 0x65, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x27, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x63, 0x65,
+	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x40, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4f, 0x0a, 0x0a, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x61,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x61,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x73, 0x12, 0x20, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x72, 0x69, 0x6b, 0x6c, 0x75, 0x70, 0x61,
+	0x6e, 0x64, 0x65, 0x72, 0x2f, 0x74, 0x72, 0x61, 0x64, 0x66, 0x72, 0x69, 0x2d, 0x67, 0x6f, 0x2f,
+	0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x6f, 0x6c, 0x61,
+	0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
 	file_tradfri_proto_rawDescOnce sync.Once
 	file_tradfri_proto_rawDescData = file_tradfri_proto_rawDesc
 )
@@ -1359,63 +4140,148 @@ func file_tradfri_proto_rawDescGZIP() []byte {
 	return file_tradfri_proto_rawDescData
 }
 
-var file_tradfri_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_tradfri_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tradfri_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
 var file_tradfri_proto_goTypes = []any{
-	(*DeviceMetadata)(nil),                  // 0: grpc_server.DeviceMetadata
-	(*Device)(nil),                          // 1: grpc_server.Device
-	(*Group)(nil),                           // 2: grpc_server.Group
-	(*ListGroupsRequest)(nil),               // 3: grpc_server.ListGroupsRequest
-	(*ListGroupsResponse)(nil),              // 4: grpc_server.ListGroupsResponse
-	(*GetGroupRequest)(nil),                 // 5: grpc_server.GetGroupRequest
-	(*GetGroupResponse)(nil),                // 6: grpc_server.GetGroupResponse
-	(*ListDevicesRequest)(nil),              // 7: grpc_server.ListDevicesRequest
-	(*ListDevicesResponse)(nil),             // 8: grpc_server.ListDevicesResponse
-	(*ListDeviceIDsRequest)(nil),            // 9: grpc_server.ListDeviceIDsRequest
-	(*ListDeviceIDsResponse)(nil),           // 10: grpc_server.ListDeviceIDsResponse
-	(*GetDeviceRequest)(nil),                // 11: grpc_server.GetDeviceRequest
-	(*GetDeviceResponse)(nil),               // 12: grpc_server.GetDeviceResponse
-	(*ChangeDeviceColorRequest)(nil),        // 13: grpc_server.ChangeDeviceColorRequest
-	(*ChangeDeviceColorResponse)(nil),       // 14: grpc_server.ChangeDeviceColorResponse
-	(*ChangeDeviceDimmingRequest)(nil),      // 15: grpc_server.ChangeDeviceDimmingRequest
-	(*ChangeDeviceDimmingResponse)(nil),     // 16: grpc_server.ChangeDeviceDimmingResponse
-	(*TurnDeviceOnRequest)(nil),             // 17: grpc_server.TurnDeviceOnRequest
-	(*TurnDeviceOnResponse)(nil),            // 18: grpc_server.TurnDeviceOnResponse
-	(*TurnDeviceOffRequest)(nil),            // 19: grpc_server.TurnDeviceOffRequest
-	(*TurnDeviceOffResponse)(nil),           // 20: grpc_server.TurnDeviceOffResponse
-	(*ChangeDevicePositioningRequest)(nil),  // 21: grpc_server.ChangeDevicePositioningRequest
-	(*ChangeDevicePositioningResponse)(nil), // 22: grpc_server.ChangeDevicePositioningResponse
+	(PowerSource)(0),                            // 0: grpc_server.PowerSource
+	(BatchResult_Status)(0),                     // 1: grpc_server.BatchResult.Status
+	(*DeviceMetadata)(nil),                      // 2: grpc_server.DeviceMetadata
+	(*Device)(nil),                              // 3: grpc_server.Device
+	(*Light)(nil),                               // 4: grpc_server.Light
+	(*Plug)(nil),                                // 5: grpc_server.Plug
+	(*Blind)(nil),                               // 6: grpc_server.Blind
+	(*Remote)(nil),                              // 7: grpc_server.Remote
+	(*Sensor)(nil),                              // 8: grpc_server.Sensor
+	(*Repeater)(nil),                            // 9: grpc_server.Repeater
+	(*AirPurifier)(nil),                         // 10: grpc_server.AirPurifier
+	(*Group)(nil),                               // 11: grpc_server.Group
+	(*ListGroupsRequest)(nil),                   // 12: grpc_server.ListGroupsRequest
+	(*ListGroupsResponse)(nil),                  // 13: grpc_server.ListGroupsResponse
+	(*GetGroupRequest)(nil),                     // 14: grpc_server.GetGroupRequest
+	(*GetGroupResponse)(nil),                    // 15: grpc_server.GetGroupResponse
+	(*ListDevicesRequest)(nil),                  // 16: grpc_server.ListDevicesRequest
+	(*ListDevicesResponse)(nil),                 // 17: grpc_server.ListDevicesResponse
+	(*ListDeviceIDsRequest)(nil),                // 18: grpc_server.ListDeviceIDsRequest
+	(*ListDeviceIDsResponse)(nil),               // 19: grpc_server.ListDeviceIDsResponse
+	(*ListAllDevicesRequest)(nil),               // 20: grpc_server.ListAllDevicesRequest
+	(*GetDeviceRequest)(nil),                    // 21: grpc_server.GetDeviceRequest
+	(*GetDeviceResponse)(nil),                   // 22: grpc_server.GetDeviceResponse
+	(*ChangeDeviceColorRequest)(nil),            // 23: grpc_server.ChangeDeviceColorRequest
+	(*ChangeDeviceColorHSLRequest)(nil),         // 24: grpc_server.ChangeDeviceColorHSLRequest
+	(*ChangeDeviceColorTemperatureRequest)(nil), // 25: grpc_server.ChangeDeviceColorTemperatureRequest
+	(*ChangeDeviceColorResponse)(nil),           // 26: grpc_server.ChangeDeviceColorResponse
+	(*ChangeDeviceDimmingRequest)(nil),          // 27: grpc_server.ChangeDeviceDimmingRequest
+	(*ChangeDeviceDimmingResponse)(nil),         // 28: grpc_server.ChangeDeviceDimmingResponse
+	(*TurnDeviceOnRequest)(nil),                 // 29: grpc_server.TurnDeviceOnRequest
+	(*TurnDeviceOnResponse)(nil),                // 30: grpc_server.TurnDeviceOnResponse
+	(*TurnDeviceOffRequest)(nil),                // 31: grpc_server.TurnDeviceOffRequest
+	(*TurnDeviceOffResponse)(nil),               // 32: grpc_server.TurnDeviceOffResponse
+	(*ChangeDeviceStateRequest)(nil),            // 33: grpc_server.ChangeDeviceStateRequest
+	(*ChangeDeviceStateResponse)(nil),           // 34: grpc_server.ChangeDeviceStateResponse
+	(*TurnOutletOnRequest)(nil),                 // 35: grpc_server.TurnOutletOnRequest
+	(*TurnOutletOnResponse)(nil),                // 36: grpc_server.TurnOutletOnResponse
+	(*TurnOutletOffRequest)(nil),                // 37: grpc_server.TurnOutletOffRequest
+	(*TurnOutletOffResponse)(nil),               // 38: grpc_server.TurnOutletOffResponse
+	(*ChangeDevicePositioningRequest)(nil),      // 39: grpc_server.ChangeDevicePositioningRequest
+	(*ChangeDevicePositioningResponse)(nil),     // 40: grpc_server.ChangeDevicePositioningResponse
+	(*TurnGroupOnRequest)(nil),                  // 41: grpc_server.TurnGroupOnRequest
+	(*TurnGroupOnResponse)(nil),                 // 42: grpc_server.TurnGroupOnResponse
+	(*TurnGroupOffRequest)(nil),                 // 43: grpc_server.TurnGroupOffRequest
+	(*TurnGroupOffResponse)(nil),                // 44: grpc_server.TurnGroupOffResponse
+	(*ChangeGroupDimmingRequest)(nil),           // 45: grpc_server.ChangeGroupDimmingRequest
+	(*ChangeGroupDimmingResponse)(nil),          // 46: grpc_server.ChangeGroupDimmingResponse
+	(*ActivateGroupSceneRequest)(nil),           // 47: grpc_server.ActivateGroupSceneRequest
+	(*ActivateGroupSceneResponse)(nil),          // 48: grpc_server.ActivateGroupSceneResponse
+	(*WatchDevicesRequest)(nil),                 // 49: grpc_server.WatchDevicesRequest
+	(*WatchGroupsRequest)(nil),                  // 50: grpc_server.WatchGroupsRequest
+	(*RawRequestRequest)(nil),                   // 51: grpc_server.RawRequestRequest
+	(*RawOption)(nil),                           // 52: grpc_server.RawOption
+	(*RawRequestResponse)(nil),                  // 53: grpc_server.RawRequestResponse
+	(*BatchOperation)(nil),                      // 54: grpc_server.BatchOperation
+	(*BatchRequest)(nil),                        // 55: grpc_server.BatchRequest
+	(*BatchResult)(nil),                         // 56: grpc_server.BatchResult
+	(*BatchResponse)(nil),                       // 57: grpc_server.BatchResponse
+	(*ListGatewaysRequest)(nil),                 // 58: grpc_server.ListGatewaysRequest
+	(*Gateway)(nil),                             // 59: grpc_server.Gateway
+	(*ListGatewaysResponse)(nil),                // 60: grpc_server.ListGatewaysResponse
+	(*timestamppb.Timestamp)(nil),               // 61: google.protobuf.Timestamp
 }
 var file_tradfri_proto_depIdxs = []int32{
-	0,  // 0: grpc_server.Device.metadata:type_name -> grpc_server.DeviceMetadata
-	2,  // 1: grpc_server.ListGroupsResponse.groups:type_name -> grpc_server.Group
-	2,  // 2: grpc_server.GetGroupResponse.group:type_name -> grpc_server.Group
-	1,  // 3: grpc_server.ListDevicesResponse.devices:type_name -> grpc_server.Device
-	1,  // 4: grpc_server.GetDeviceResponse.device:type_name -> grpc_server.Device
-	3,  // 5: grpc_server.TradfriService.ListGroups:input_type -> grpc_server.ListGroupsRequest
-	5,  // 6: grpc_server.TradfriService.GetGroup:input_type -> grpc_server.GetGroupRequest
-	7,  // 7: grpc_server.TradfriService.ListDevices:input_type -> grpc_server.ListDevicesRequest
-	9,  // 8: grpc_server.TradfriService.ListDeviceIDs:input_type -> grpc_server.ListDeviceIDsRequest
-	11, // 9: grpc_server.TradfriService.GetDevice:input_type -> grpc_server.GetDeviceRequest
-	13, // 10: grpc_server.TradfriService.ChangeDeviceColor:input_type -> grpc_server.ChangeDeviceColorRequest
-	15, // 11: grpc_server.TradfriService.ChangeDeviceDimming:input_type -> grpc_server.ChangeDeviceDimmingRequest
-	17, // 12: grpc_server.TradfriService.TurnDeviceOn:input_type -> grpc_server.TurnDeviceOnRequest
-	19, // 13: grpc_server.TradfriService.TurnDeviceOff:input_type -> grpc_server.TurnDeviceOffRequest
-	21, // 14: grpc_server.TradfriService.ChangeDevicePositioning:input_type -> grpc_server.ChangeDevicePositioningRequest
-	4,  // 15: grpc_server.TradfriService.ListGroups:output_type -> grpc_server.ListGroupsResponse
-	6,  // 16: grpc_server.TradfriService.GetGroup:output_type -> grpc_server.GetGroupResponse
-	8,  // 17: grpc_server.TradfriService.ListDevices:output_type -> grpc_server.ListDevicesResponse
-	10, // 18: grpc_server.TradfriService.ListDeviceIDs:output_type -> grpc_server.ListDeviceIDsResponse
-	12, // 19: grpc_server.TradfriService.GetDevice:output_type -> grpc_server.GetDeviceResponse
-	14, // 20: grpc_server.TradfriService.ChangeDeviceColor:output_type -> grpc_server.ChangeDeviceColorResponse
-	16, // 21: grpc_server.TradfriService.ChangeDeviceDimming:output_type -> grpc_server.ChangeDeviceDimmingResponse
-	18, // 22: grpc_server.TradfriService.TurnDeviceOn:output_type -> grpc_server.TurnDeviceOnResponse
-	20, // 23: grpc_server.TradfriService.TurnDeviceOff:output_type -> grpc_server.TurnDeviceOffResponse
-	22, // 24: grpc_server.TradfriService.ChangeDevicePositioning:output_type -> grpc_server.ChangeDevicePositioningResponse
-	15, // [15:25] is the sub-list for method output_type
-	5,  // [5:15] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: grpc_server.DeviceMetadata.power_source:type_name -> grpc_server.PowerSource
+	61, // 1: grpc_server.DeviceMetadata.last_seen:type_name -> google.protobuf.Timestamp
+	61, // 2: grpc_server.DeviceMetadata.created:type_name -> google.protobuf.Timestamp
+	2,  // 3: grpc_server.Device.metadata:type_name -> grpc_server.DeviceMetadata
+	4,  // 4: grpc_server.Device.light:type_name -> grpc_server.Light
+	5,  // 5: grpc_server.Device.plug:type_name -> grpc_server.Plug
+	6,  // 6: grpc_server.Device.blind:type_name -> grpc_server.Blind
+	7,  // 7: grpc_server.Device.remote:type_name -> grpc_server.Remote
+	8,  // 8: grpc_server.Device.sensor:type_name -> grpc_server.Sensor
+	9,  // 9: grpc_server.Device.repeater:type_name -> grpc_server.Repeater
+	10, // 10: grpc_server.Device.air_purifier:type_name -> grpc_server.AirPurifier
+	11, // 11: grpc_server.ListGroupsResponse.groups:type_name -> grpc_server.Group
+	11, // 12: grpc_server.GetGroupResponse.group:type_name -> grpc_server.Group
+	3,  // 13: grpc_server.ListDevicesResponse.devices:type_name -> grpc_server.Device
+	3,  // 14: grpc_server.GetDeviceResponse.device:type_name -> grpc_server.Device
+	52, // 15: grpc_server.RawRequestResponse.options:type_name -> grpc_server.RawOption
+	54, // 16: grpc_server.BatchRequest.operations:type_name -> grpc_server.BatchOperation
+	1,  // 17: grpc_server.BatchResult.status:type_name -> grpc_server.BatchResult.Status
+	56, // 18: grpc_server.BatchResponse.results:type_name -> grpc_server.BatchResult
+	59, // 19: grpc_server.ListGatewaysResponse.gateways:type_name -> grpc_server.Gateway
+	12, // 20: grpc_server.TradfriService.ListGroups:input_type -> grpc_server.ListGroupsRequest
+	14, // 21: grpc_server.TradfriService.GetGroup:input_type -> grpc_server.GetGroupRequest
+	16, // 22: grpc_server.TradfriService.ListDevices:input_type -> grpc_server.ListDevicesRequest
+	18, // 23: grpc_server.TradfriService.ListDeviceIDs:input_type -> grpc_server.ListDeviceIDsRequest
+	20, // 24: grpc_server.TradfriService.ListAllDevices:input_type -> grpc_server.ListAllDevicesRequest
+	21, // 25: grpc_server.TradfriService.GetDevice:input_type -> grpc_server.GetDeviceRequest
+	23, // 26: grpc_server.TradfriService.ChangeDeviceColor:input_type -> grpc_server.ChangeDeviceColorRequest
+	27, // 27: grpc_server.TradfriService.ChangeDeviceDimming:input_type -> grpc_server.ChangeDeviceDimmingRequest
+	29, // 28: grpc_server.TradfriService.TurnDeviceOn:input_type -> grpc_server.TurnDeviceOnRequest
+	31, // 29: grpc_server.TradfriService.TurnDeviceOff:input_type -> grpc_server.TurnDeviceOffRequest
+	33, // 30: grpc_server.TradfriService.ChangeDeviceState:input_type -> grpc_server.ChangeDeviceStateRequest
+	24, // 31: grpc_server.TradfriService.ChangeDeviceColorHSL:input_type -> grpc_server.ChangeDeviceColorHSLRequest
+	25, // 32: grpc_server.TradfriService.ChangeDeviceColorTemperature:input_type -> grpc_server.ChangeDeviceColorTemperatureRequest
+	35, // 33: grpc_server.TradfriService.TurnOutletOn:input_type -> grpc_server.TurnOutletOnRequest
+	37, // 34: grpc_server.TradfriService.TurnOutletOff:input_type -> grpc_server.TurnOutletOffRequest
+	39, // 35: grpc_server.TradfriService.ChangeDevicePositioning:input_type -> grpc_server.ChangeDevicePositioningRequest
+	41, // 36: grpc_server.TradfriService.TurnGroupOn:input_type -> grpc_server.TurnGroupOnRequest
+	43, // 37: grpc_server.TradfriService.TurnGroupOff:input_type -> grpc_server.TurnGroupOffRequest
+	45, // 38: grpc_server.TradfriService.ChangeGroupDimming:input_type -> grpc_server.ChangeGroupDimmingRequest
+	47, // 39: grpc_server.TradfriService.ActivateGroupScene:input_type -> grpc_server.ActivateGroupSceneRequest
+	49, // 40: grpc_server.TradfriService.WatchDevices:input_type -> grpc_server.WatchDevicesRequest
+	50, // 41: grpc_server.TradfriService.WatchGroups:input_type -> grpc_server.WatchGroupsRequest
+	55, // 42: grpc_server.TradfriService.Batch:input_type -> grpc_server.BatchRequest
+	51, // 43: grpc_server.TradfriService.RawRequest:input_type -> grpc_server.RawRequestRequest
+	58, // 44: grpc_server.TradfriService.ListGateways:input_type -> grpc_server.ListGatewaysRequest
+	13, // 45: grpc_server.TradfriService.ListGroups:output_type -> grpc_server.ListGroupsResponse
+	15, // 46: grpc_server.TradfriService.GetGroup:output_type -> grpc_server.GetGroupResponse
+	17, // 47: grpc_server.TradfriService.ListDevices:output_type -> grpc_server.ListDevicesResponse
+	19, // 48: grpc_server.TradfriService.ListDeviceIDs:output_type -> grpc_server.ListDeviceIDsResponse
+	17, // 49: grpc_server.TradfriService.ListAllDevices:output_type -> grpc_server.ListDevicesResponse
+	22, // 50: grpc_server.TradfriService.GetDevice:output_type -> grpc_server.GetDeviceResponse
+	26, // 51: grpc_server.TradfriService.ChangeDeviceColor:output_type -> grpc_server.ChangeDeviceColorResponse
+	28, // 52: grpc_server.TradfriService.ChangeDeviceDimming:output_type -> grpc_server.ChangeDeviceDimmingResponse
+	30, // 53: grpc_server.TradfriService.TurnDeviceOn:output_type -> grpc_server.TurnDeviceOnResponse
+	32, // 54: grpc_server.TradfriService.TurnDeviceOff:output_type -> grpc_server.TurnDeviceOffResponse
+	34, // 55: grpc_server.TradfriService.ChangeDeviceState:output_type -> grpc_server.ChangeDeviceStateResponse
+	26, // 56: grpc_server.TradfriService.ChangeDeviceColorHSL:output_type -> grpc_server.ChangeDeviceColorResponse
+	26, // 57: grpc_server.TradfriService.ChangeDeviceColorTemperature:output_type -> grpc_server.ChangeDeviceColorResponse
+	36, // 58: grpc_server.TradfriService.TurnOutletOn:output_type -> grpc_server.TurnOutletOnResponse
+	38, // 59: grpc_server.TradfriService.TurnOutletOff:output_type -> grpc_server.TurnOutletOffResponse
+	40, // 60: grpc_server.TradfriService.ChangeDevicePositioning:output_type -> grpc_server.ChangeDevicePositioningResponse
+	42, // 61: grpc_server.TradfriService.TurnGroupOn:output_type -> grpc_server.TurnGroupOnResponse
+	44, // 62: grpc_server.TradfriService.TurnGroupOff:output_type -> grpc_server.TurnGroupOffResponse
+	46, // 63: grpc_server.TradfriService.ChangeGroupDimming:output_type -> grpc_server.ChangeGroupDimmingResponse
+	48, // 64: grpc_server.TradfriService.ActivateGroupScene:output_type -> grpc_server.ActivateGroupSceneResponse
+	3,  // 65: grpc_server.TradfriService.WatchDevices:output_type -> grpc_server.Device
+	11, // 66: grpc_server.TradfriService.WatchGroups:output_type -> grpc_server.Group
+	57, // 67: grpc_server.TradfriService.Batch:output_type -> grpc_server.BatchResponse
+	53, // 68: grpc_server.TradfriService.RawRequest:output_type -> grpc_server.RawRequestResponse
+	60, // 69: grpc_server.TradfriService.ListGateways:output_type -> grpc_server.ListGatewaysResponse
+	45, // [45:70] is the sub-list for method output_type
+	20, // [20:45] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_tradfri_proto_init() }
@@ -1436,8 +4302,212 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Device); i {
+		file_tradfri_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Device); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Light); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Plug); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Blind); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Remote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Sensor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Repeater); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*AirPurifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDeviceIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDeviceIDsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAllDevicesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1448,8 +4518,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*Group); i {
+		file_tradfri_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDeviceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1460,8 +4530,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ListGroupsRequest); i {
+		file_tradfri_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDeviceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1472,8 +4542,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*ListGroupsResponse); i {
+		file_tradfri_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceColorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1484,8 +4554,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*GetGroupRequest); i {
+		file_tradfri_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceColorHSLRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1496,8 +4566,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*GetGroupResponse); i {
+		file_tradfri_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceColorTemperatureRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1508,8 +4578,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDevicesRequest); i {
+		file_tradfri_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceColorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1520,8 +4590,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDevicesResponse); i {
+		file_tradfri_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceDimmingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1532,8 +4602,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDeviceIDsRequest); i {
+		file_tradfri_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceDimmingResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1544,8 +4614,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDeviceIDsResponse); i {
+		file_tradfri_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnDeviceOnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1556,8 +4626,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*GetDeviceRequest); i {
+		file_tradfri_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnDeviceOnResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1568,8 +4638,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*GetDeviceResponse); i {
+		file_tradfri_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnDeviceOffRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1580,8 +4650,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDeviceColorRequest); i {
+		file_tradfri_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnDeviceOffResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1592,8 +4662,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDeviceColorResponse); i {
+		file_tradfri_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceStateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1604,8 +4674,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDeviceDimmingRequest); i {
+		file_tradfri_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDeviceStateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1616,8 +4686,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[16].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDeviceDimmingResponse); i {
+		file_tradfri_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnOutletOnRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1628,8 +4698,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*TurnDeviceOnRequest); i {
+		file_tradfri_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnOutletOnResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1640,8 +4710,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[18].Exporter = func(v any, i int) any {
-			switch v := v.(*TurnDeviceOnResponse); i {
+		file_tradfri_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnOutletOffRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1652,8 +4722,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[19].Exporter = func(v any, i int) any {
-			switch v := v.(*TurnDeviceOffRequest); i {
+		file_tradfri_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnOutletOffResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1664,8 +4734,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[20].Exporter = func(v any, i int) any {
-			switch v := v.(*TurnDeviceOffResponse); i {
+		file_tradfri_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDevicePositioningRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1676,8 +4746,8 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[21].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDevicePositioningRequest); i {
+		file_tradfri_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeDevicePositioningResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1688,8 +4758,236 @@ func file_tradfri_proto_init() {
 				return nil
 			}
 		}
-		file_tradfri_proto_msgTypes[22].Exporter = func(v any, i int) any {
-			switch v := v.(*ChangeDevicePositioningResponse); i {
+		file_tradfri_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnGroupOnRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnGroupOnResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnGroupOffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*TurnGroupOffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeGroupDimmingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeGroupDimmingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*ActivateGroupSceneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*ActivateGroupSceneResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[48].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[49].Exporter = func(v any, i int) any {
+			switch v := v.(*RawRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[50].Exporter = func(v any, i int) any {
+			switch v := v.(*RawOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[51].Exporter = func(v any, i int) any {
+			switch v := v.(*RawRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[52].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[53].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[54].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[55].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[56].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGatewaysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[57].Exporter = func(v any, i int) any {
+			switch v := v.(*Gateway); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tradfri_proto_msgTypes[58].Exporter = func(v any, i int) any {
+			switch v := v.(*ListGatewaysResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1701,18 +4999,30 @@ func file_tradfri_proto_init() {
 			}
 		}
 	}
+	file_tradfri_proto_msgTypes[1].OneofWrappers = []any{
+		(*Device_Light)(nil),
+		(*Device_Plug)(nil),
+		(*Device_Blind)(nil),
+		(*Device_Remote)(nil),
+		(*Device_Sensor)(nil),
+		(*Device_Repeater)(nil),
+		(*Device_AirPurifier)(nil),
+	}
+	file_tradfri_proto_msgTypes[37].OneofWrappers = []any{}
+	file_tradfri_proto_msgTypes[52].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tradfri_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      2,
+			NumMessages:   59,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_tradfri_proto_goTypes,
 		DependencyIndexes: file_tradfri_proto_depIdxs,
+		EnumInfos:         file_tradfri_proto_enumTypes,
 		MessageInfos:      file_tradfri_proto_msgTypes,
 	}.Build()
 	File_tradfri_proto = out.File
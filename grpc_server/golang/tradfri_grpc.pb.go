@@ -19,16 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TradfriService_ListGroups_FullMethodName              = "/grpc_server.TradfriService/ListGroups"
-	TradfriService_GetGroup_FullMethodName                = "/grpc_server.TradfriService/GetGroup"
-	TradfriService_ListDevices_FullMethodName             = "/grpc_server.TradfriService/ListDevices"
-	TradfriService_ListDeviceIDs_FullMethodName           = "/grpc_server.TradfriService/ListDeviceIDs"
-	TradfriService_GetDevice_FullMethodName               = "/grpc_server.TradfriService/GetDevice"
-	TradfriService_ChangeDeviceColor_FullMethodName       = "/grpc_server.TradfriService/ChangeDeviceColor"
-	TradfriService_ChangeDeviceDimming_FullMethodName     = "/grpc_server.TradfriService/ChangeDeviceDimming"
-	TradfriService_TurnDeviceOn_FullMethodName            = "/grpc_server.TradfriService/TurnDeviceOn"
-	TradfriService_TurnDeviceOff_FullMethodName           = "/grpc_server.TradfriService/TurnDeviceOff"
-	TradfriService_ChangeDevicePositioning_FullMethodName = "/grpc_server.TradfriService/ChangeDevicePositioning"
+	TradfriService_ListGroups_FullMethodName                   = "/grpc_server.TradfriService/ListGroups"
+	TradfriService_GetGroup_FullMethodName                     = "/grpc_server.TradfriService/GetGroup"
+	TradfriService_ListDevices_FullMethodName                  = "/grpc_server.TradfriService/ListDevices"
+	TradfriService_ListDeviceIDs_FullMethodName                = "/grpc_server.TradfriService/ListDeviceIDs"
+	TradfriService_ListAllDevices_FullMethodName               = "/grpc_server.TradfriService/ListAllDevices"
+	TradfriService_GetDevice_FullMethodName                    = "/grpc_server.TradfriService/GetDevice"
+	TradfriService_ChangeDeviceColor_FullMethodName            = "/grpc_server.TradfriService/ChangeDeviceColor"
+	TradfriService_ChangeDeviceDimming_FullMethodName          = "/grpc_server.TradfriService/ChangeDeviceDimming"
+	TradfriService_TurnDeviceOn_FullMethodName                 = "/grpc_server.TradfriService/TurnDeviceOn"
+	TradfriService_TurnDeviceOff_FullMethodName                = "/grpc_server.TradfriService/TurnDeviceOff"
+	TradfriService_ChangeDeviceState_FullMethodName            = "/grpc_server.TradfriService/ChangeDeviceState"
+	TradfriService_ChangeDeviceColorHSL_FullMethodName         = "/grpc_server.TradfriService/ChangeDeviceColorHSL"
+	TradfriService_ChangeDeviceColorTemperature_FullMethodName = "/grpc_server.TradfriService/ChangeDeviceColorTemperature"
+	TradfriService_TurnOutletOn_FullMethodName                 = "/grpc_server.TradfriService/TurnOutletOn"
+	TradfriService_TurnOutletOff_FullMethodName                = "/grpc_server.TradfriService/TurnOutletOff"
+	TradfriService_ChangeDevicePositioning_FullMethodName      = "/grpc_server.TradfriService/ChangeDevicePositioning"
+	TradfriService_TurnGroupOn_FullMethodName                  = "/grpc_server.TradfriService/TurnGroupOn"
+	TradfriService_TurnGroupOff_FullMethodName                 = "/grpc_server.TradfriService/TurnGroupOff"
+	TradfriService_ChangeGroupDimming_FullMethodName           = "/grpc_server.TradfriService/ChangeGroupDimming"
+	TradfriService_ActivateGroupScene_FullMethodName           = "/grpc_server.TradfriService/ActivateGroupScene"
+	TradfriService_WatchDevices_FullMethodName                 = "/grpc_server.TradfriService/WatchDevices"
+	TradfriService_WatchGroups_FullMethodName                  = "/grpc_server.TradfriService/WatchGroups"
+	TradfriService_Batch_FullMethodName                        = "/grpc_server.TradfriService/Batch"
+	TradfriService_RawRequest_FullMethodName                   = "/grpc_server.TradfriService/RawRequest"
+	TradfriService_ListGateways_FullMethodName                 = "/grpc_server.TradfriService/ListGateways"
 )
 
 // TradfriServiceClient is the client API for TradfriService service.
@@ -41,12 +56,37 @@ type TradfriServiceClient interface {
 	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
 	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 	ListDeviceIDs(ctx context.Context, in *ListDeviceIDsRequest, opts ...grpc.CallOption) (*ListDeviceIDsResponse, error)
+	// ListAllDevices lists the devices of all groups, including those not part of any group.
+	ListAllDevices(ctx context.Context, in *ListAllDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
 	ChangeDeviceColor(ctx context.Context, in *ChangeDeviceColorRequest, opts ...grpc.CallOption) (*ChangeDeviceColorResponse, error)
 	ChangeDeviceDimming(ctx context.Context, in *ChangeDeviceDimmingRequest, opts ...grpc.CallOption) (*ChangeDeviceDimmingResponse, error)
 	TurnDeviceOn(ctx context.Context, in *TurnDeviceOnRequest, opts ...grpc.CallOption) (*TurnDeviceOnResponse, error)
 	TurnDeviceOff(ctx context.Context, in *TurnDeviceOffRequest, opts ...grpc.CallOption) (*TurnDeviceOffResponse, error)
+	// ChangeDeviceState switches a bulb on or off and sets its dimmer level in one call.
+	ChangeDeviceState(ctx context.Context, in *ChangeDeviceStateRequest, opts ...grpc.CallOption) (*ChangeDeviceStateResponse, error)
+	ChangeDeviceColorHSL(ctx context.Context, in *ChangeDeviceColorHSLRequest, opts ...grpc.CallOption) (*ChangeDeviceColorResponse, error)
+	ChangeDeviceColorTemperature(ctx context.Context, in *ChangeDeviceColorTemperatureRequest, opts ...grpc.CallOption) (*ChangeDeviceColorResponse, error)
+	TurnOutletOn(ctx context.Context, in *TurnOutletOnRequest, opts ...grpc.CallOption) (*TurnOutletOnResponse, error)
+	TurnOutletOff(ctx context.Context, in *TurnOutletOffRequest, opts ...grpc.CallOption) (*TurnOutletOffResponse, error)
 	ChangeDevicePositioning(ctx context.Context, in *ChangeDevicePositioningRequest, opts ...grpc.CallOption) (*ChangeDevicePositioningResponse, error)
+	TurnGroupOn(ctx context.Context, in *TurnGroupOnRequest, opts ...grpc.CallOption) (*TurnGroupOnResponse, error)
+	TurnGroupOff(ctx context.Context, in *TurnGroupOffRequest, opts ...grpc.CallOption) (*TurnGroupOffResponse, error)
+	ChangeGroupDimming(ctx context.Context, in *ChangeGroupDimmingRequest, opts ...grpc.CallOption) (*ChangeGroupDimmingResponse, error)
+	// ActivateGroupScene activates a scene (mood) of a group, switching it on.
+	ActivateGroupScene(ctx context.Context, in *ActivateGroupSceneRequest, opts ...grpc.CallOption) (*ActivateGroupSceneResponse, error)
+	// WatchDevices streams the current state of the matching devices followed by every change to them.
+	WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Device], error)
+	// WatchGroups streams the current state of the matching groups followed by every change to them.
+	WatchGroups(ctx context.Context, in *WatchGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Group], error)
+	// Batch executes a list of device and group operations concurrently and returns the result of each.
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// RawRequest forwards a CoAP request to an arbitrary gateway path and returns the response as is.
+	// Requires the admin scope.
+	RawRequest(ctx context.Context, in *RawRequestRequest, opts ...grpc.CallOption) (*RawRequestResponse, error)
+	// ListGateways lists the gateways served by this server. The other calls are sent to the gateway named
+	// by the "tradfri-gateway" metadata, or to the default gateway without it.
+	ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error)
 }
 
 type tradfriServiceClient struct {
@@ -97,6 +137,16 @@ func (c *tradfriServiceClient) ListDeviceIDs(ctx context.Context, in *ListDevice
 	return out, nil
 }
 
+func (c *tradfriServiceClient) ListAllDevices(ctx context.Context, in *ListAllDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ListAllDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tradfriServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetDeviceResponse)
@@ -147,6 +197,56 @@ func (c *tradfriServiceClient) TurnDeviceOff(ctx context.Context, in *TurnDevice
 	return out, nil
 }
 
+func (c *tradfriServiceClient) ChangeDeviceState(ctx context.Context, in *ChangeDeviceStateRequest, opts ...grpc.CallOption) (*ChangeDeviceStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeDeviceStateResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ChangeDeviceState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) ChangeDeviceColorHSL(ctx context.Context, in *ChangeDeviceColorHSLRequest, opts ...grpc.CallOption) (*ChangeDeviceColorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeDeviceColorResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ChangeDeviceColorHSL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) ChangeDeviceColorTemperature(ctx context.Context, in *ChangeDeviceColorTemperatureRequest, opts ...grpc.CallOption) (*ChangeDeviceColorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeDeviceColorResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ChangeDeviceColorTemperature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) TurnOutletOn(ctx context.Context, in *TurnOutletOnRequest, opts ...grpc.CallOption) (*TurnOutletOnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TurnOutletOnResponse)
+	err := c.cc.Invoke(ctx, TradfriService_TurnOutletOn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) TurnOutletOff(ctx context.Context, in *TurnOutletOffRequest, opts ...grpc.CallOption) (*TurnOutletOffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TurnOutletOffResponse)
+	err := c.cc.Invoke(ctx, TradfriService_TurnOutletOff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tradfriServiceClient) ChangeDevicePositioning(ctx context.Context, in *ChangeDevicePositioningRequest, opts ...grpc.CallOption) (*ChangeDevicePositioningResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChangeDevicePositioningResponse)
@@ -157,6 +257,114 @@ func (c *tradfriServiceClient) ChangeDevicePositioning(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *tradfriServiceClient) TurnGroupOn(ctx context.Context, in *TurnGroupOnRequest, opts ...grpc.CallOption) (*TurnGroupOnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TurnGroupOnResponse)
+	err := c.cc.Invoke(ctx, TradfriService_TurnGroupOn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) TurnGroupOff(ctx context.Context, in *TurnGroupOffRequest, opts ...grpc.CallOption) (*TurnGroupOffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TurnGroupOffResponse)
+	err := c.cc.Invoke(ctx, TradfriService_TurnGroupOff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) ChangeGroupDimming(ctx context.Context, in *ChangeGroupDimmingRequest, opts ...grpc.CallOption) (*ChangeGroupDimmingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeGroupDimmingResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ChangeGroupDimming_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) ActivateGroupScene(ctx context.Context, in *ActivateGroupSceneRequest, opts ...grpc.CallOption) (*ActivateGroupSceneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivateGroupSceneResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ActivateGroupScene_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Device], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TradfriService_ServiceDesc.Streams[0], TradfriService_WatchDevices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDevicesRequest, Device]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TradfriService_WatchDevicesClient = grpc.ServerStreamingClient[Device]
+
+func (c *tradfriServiceClient) WatchGroups(ctx context.Context, in *WatchGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Group], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TradfriService_ServiceDesc.Streams[1], TradfriService_WatchGroups_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchGroupsRequest, Group]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TradfriService_WatchGroupsClient = grpc.ServerStreamingClient[Group]
+
+func (c *tradfriServiceClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, TradfriService_Batch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) RawRequest(ctx context.Context, in *RawRequestRequest, opts ...grpc.CallOption) (*RawRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RawRequestResponse)
+	err := c.cc.Invoke(ctx, TradfriService_RawRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradfriServiceClient) ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGatewaysResponse)
+	err := c.cc.Invoke(ctx, TradfriService_ListGateways_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TradfriServiceServer is the server API for TradfriService service.
 // All implementations should embed UnimplementedTradfriServiceServer
 // for forward compatibility.
@@ -167,12 +375,37 @@ type TradfriServiceServer interface {
 	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
 	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
 	ListDeviceIDs(context.Context, *ListDeviceIDsRequest) (*ListDeviceIDsResponse, error)
+	// ListAllDevices lists the devices of all groups, including those not part of any group.
+	ListAllDevices(context.Context, *ListAllDevicesRequest) (*ListDevicesResponse, error)
 	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
 	ChangeDeviceColor(context.Context, *ChangeDeviceColorRequest) (*ChangeDeviceColorResponse, error)
 	ChangeDeviceDimming(context.Context, *ChangeDeviceDimmingRequest) (*ChangeDeviceDimmingResponse, error)
 	TurnDeviceOn(context.Context, *TurnDeviceOnRequest) (*TurnDeviceOnResponse, error)
 	TurnDeviceOff(context.Context, *TurnDeviceOffRequest) (*TurnDeviceOffResponse, error)
+	// ChangeDeviceState switches a bulb on or off and sets its dimmer level in one call.
+	ChangeDeviceState(context.Context, *ChangeDeviceStateRequest) (*ChangeDeviceStateResponse, error)
+	ChangeDeviceColorHSL(context.Context, *ChangeDeviceColorHSLRequest) (*ChangeDeviceColorResponse, error)
+	ChangeDeviceColorTemperature(context.Context, *ChangeDeviceColorTemperatureRequest) (*ChangeDeviceColorResponse, error)
+	TurnOutletOn(context.Context, *TurnOutletOnRequest) (*TurnOutletOnResponse, error)
+	TurnOutletOff(context.Context, *TurnOutletOffRequest) (*TurnOutletOffResponse, error)
 	ChangeDevicePositioning(context.Context, *ChangeDevicePositioningRequest) (*ChangeDevicePositioningResponse, error)
+	TurnGroupOn(context.Context, *TurnGroupOnRequest) (*TurnGroupOnResponse, error)
+	TurnGroupOff(context.Context, *TurnGroupOffRequest) (*TurnGroupOffResponse, error)
+	ChangeGroupDimming(context.Context, *ChangeGroupDimmingRequest) (*ChangeGroupDimmingResponse, error)
+	// ActivateGroupScene activates a scene (mood) of a group, switching it on.
+	ActivateGroupScene(context.Context, *ActivateGroupSceneRequest) (*ActivateGroupSceneResponse, error)
+	// WatchDevices streams the current state of the matching devices followed by every change to them.
+	WatchDevices(*WatchDevicesRequest, grpc.ServerStreamingServer[Device]) error
+	// WatchGroups streams the current state of the matching groups followed by every change to them.
+	WatchGroups(*WatchGroupsRequest, grpc.ServerStreamingServer[Group]) error
+	// Batch executes a list of device and group operations concurrently and returns the result of each.
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+	// RawRequest forwards a CoAP request to an arbitrary gateway path and returns the response as is.
+	// Requires the admin scope.
+	RawRequest(context.Context, *RawRequestRequest) (*RawRequestResponse, error)
+	// ListGateways lists the gateways served by this server. The other calls are sent to the gateway named
+	// by the "tradfri-gateway" metadata, or to the default gateway without it.
+	ListGateways(context.Context, *ListGatewaysRequest) (*ListGatewaysResponse, error)
 }
 
 // UnimplementedTradfriServiceServer should be embedded to have
@@ -194,6 +427,9 @@ func (UnimplementedTradfriServiceServer) ListDevices(context.Context, *ListDevic
 func (UnimplementedTradfriServiceServer) ListDeviceIDs(context.Context, *ListDeviceIDsRequest) (*ListDeviceIDsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceIDs not implemented")
 }
+func (UnimplementedTradfriServiceServer) ListAllDevices(context.Context, *ListAllDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllDevices not implemented")
+}
 func (UnimplementedTradfriServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
 }
@@ -209,9 +445,51 @@ func (UnimplementedTradfriServiceServer) TurnDeviceOn(context.Context, *TurnDevi
 func (UnimplementedTradfriServiceServer) TurnDeviceOff(context.Context, *TurnDeviceOffRequest) (*TurnDeviceOffResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TurnDeviceOff not implemented")
 }
+func (UnimplementedTradfriServiceServer) ChangeDeviceState(context.Context, *ChangeDeviceStateRequest) (*ChangeDeviceStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeviceState not implemented")
+}
+func (UnimplementedTradfriServiceServer) ChangeDeviceColorHSL(context.Context, *ChangeDeviceColorHSLRequest) (*ChangeDeviceColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeviceColorHSL not implemented")
+}
+func (UnimplementedTradfriServiceServer) ChangeDeviceColorTemperature(context.Context, *ChangeDeviceColorTemperatureRequest) (*ChangeDeviceColorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeviceColorTemperature not implemented")
+}
+func (UnimplementedTradfriServiceServer) TurnOutletOn(context.Context, *TurnOutletOnRequest) (*TurnOutletOnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TurnOutletOn not implemented")
+}
+func (UnimplementedTradfriServiceServer) TurnOutletOff(context.Context, *TurnOutletOffRequest) (*TurnOutletOffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TurnOutletOff not implemented")
+}
 func (UnimplementedTradfriServiceServer) ChangeDevicePositioning(context.Context, *ChangeDevicePositioningRequest) (*ChangeDevicePositioningResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeDevicePositioning not implemented")
 }
+func (UnimplementedTradfriServiceServer) TurnGroupOn(context.Context, *TurnGroupOnRequest) (*TurnGroupOnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TurnGroupOn not implemented")
+}
+func (UnimplementedTradfriServiceServer) TurnGroupOff(context.Context, *TurnGroupOffRequest) (*TurnGroupOffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TurnGroupOff not implemented")
+}
+func (UnimplementedTradfriServiceServer) ChangeGroupDimming(context.Context, *ChangeGroupDimmingRequest) (*ChangeGroupDimmingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeGroupDimming not implemented")
+}
+func (UnimplementedTradfriServiceServer) ActivateGroupScene(context.Context, *ActivateGroupSceneRequest) (*ActivateGroupSceneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateGroupScene not implemented")
+}
+func (UnimplementedTradfriServiceServer) WatchDevices(*WatchDevicesRequest, grpc.ServerStreamingServer[Device]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDevices not implemented")
+}
+func (UnimplementedTradfriServiceServer) WatchGroups(*WatchGroupsRequest, grpc.ServerStreamingServer[Group]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGroups not implemented")
+}
+func (UnimplementedTradfriServiceServer) Batch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
+func (UnimplementedTradfriServiceServer) RawRequest(context.Context, *RawRequestRequest) (*RawRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RawRequest not implemented")
+}
+func (UnimplementedTradfriServiceServer) ListGateways(context.Context, *ListGatewaysRequest) (*ListGatewaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGateways not implemented")
+}
 func (UnimplementedTradfriServiceServer) testEmbeddedByValue() {}
 
 // UnsafeTradfriServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -304,6 +582,24 @@ func _TradfriService_ListDeviceIDs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TradfriService_ListAllDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ListAllDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ListAllDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ListAllDevices(ctx, req.(*ListAllDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TradfriService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDeviceRequest)
 	if err := dec(in); err != nil {
@@ -394,6 +690,96 @@ func _TradfriService_TurnDeviceOff_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TradfriService_ChangeDeviceState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeviceStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ChangeDeviceState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ChangeDeviceState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ChangeDeviceState(ctx, req.(*ChangeDeviceStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_ChangeDeviceColorHSL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeviceColorHSLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ChangeDeviceColorHSL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ChangeDeviceColorHSL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ChangeDeviceColorHSL(ctx, req.(*ChangeDeviceColorHSLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_ChangeDeviceColorTemperature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeviceColorTemperatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ChangeDeviceColorTemperature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ChangeDeviceColorTemperature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ChangeDeviceColorTemperature(ctx, req.(*ChangeDeviceColorTemperatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_TurnOutletOn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TurnOutletOnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).TurnOutletOn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_TurnOutletOn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).TurnOutletOn(ctx, req.(*TurnOutletOnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_TurnOutletOff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TurnOutletOffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).TurnOutletOff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_TurnOutletOff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).TurnOutletOff(ctx, req.(*TurnOutletOffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TradfriService_ChangeDevicePositioning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangeDevicePositioningRequest)
 	if err := dec(in); err != nil {
@@ -412,6 +798,154 @@ func _TradfriService_ChangeDevicePositioning_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TradfriService_TurnGroupOn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TurnGroupOnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).TurnGroupOn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_TurnGroupOn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).TurnGroupOn(ctx, req.(*TurnGroupOnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_TurnGroupOff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TurnGroupOffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).TurnGroupOff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_TurnGroupOff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).TurnGroupOff(ctx, req.(*TurnGroupOffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_ChangeGroupDimming_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeGroupDimmingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ChangeGroupDimming(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ChangeGroupDimming_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ChangeGroupDimming(ctx, req.(*ChangeGroupDimmingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_ActivateGroupScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateGroupSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ActivateGroupScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ActivateGroupScene_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ActivateGroupScene(ctx, req.(*ActivateGroupSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_WatchDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDevicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TradfriServiceServer).WatchDevices(m, &grpc.GenericServerStream[WatchDevicesRequest, Device]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TradfriService_WatchDevicesServer = grpc.ServerStreamingServer[Device]
+
+func _TradfriService_WatchGroups_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGroupsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TradfriServiceServer).WatchGroups(m, &grpc.GenericServerStream[WatchGroupsRequest, Group]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TradfriService_WatchGroupsServer = grpc.ServerStreamingServer[Group]
+
+func _TradfriService_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_Batch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_RawRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).RawRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_RawRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).RawRequest(ctx, req.(*RawRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradfriService_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradfriServiceServer).ListGateways(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradfriService_ListGateways_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradfriServiceServer).ListGateways(ctx, req.(*ListGatewaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TradfriService_ServiceDesc is the grpc.ServiceDesc for TradfriService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -435,6 +969,10 @@ var TradfriService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDeviceIDs",
 			Handler:    _TradfriService_ListDeviceIDs_Handler,
 		},
+		{
+			MethodName: "ListAllDevices",
+			Handler:    _TradfriService_ListAllDevices_Handler,
+		},
 		{
 			MethodName: "GetDevice",
 			Handler:    _TradfriService_GetDevice_Handler,
@@ -455,11 +993,70 @@ var TradfriService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TurnDeviceOff",
 			Handler:    _TradfriService_TurnDeviceOff_Handler,
 		},
+		{
+			MethodName: "ChangeDeviceState",
+			Handler:    _TradfriService_ChangeDeviceState_Handler,
+		},
+		{
+			MethodName: "ChangeDeviceColorHSL",
+			Handler:    _TradfriService_ChangeDeviceColorHSL_Handler,
+		},
+		{
+			MethodName: "ChangeDeviceColorTemperature",
+			Handler:    _TradfriService_ChangeDeviceColorTemperature_Handler,
+		},
+		{
+			MethodName: "TurnOutletOn",
+			Handler:    _TradfriService_TurnOutletOn_Handler,
+		},
+		{
+			MethodName: "TurnOutletOff",
+			Handler:    _TradfriService_TurnOutletOff_Handler,
+		},
 		{
 			MethodName: "ChangeDevicePositioning",
 			Handler:    _TradfriService_ChangeDevicePositioning_Handler,
 		},
+		{
+			MethodName: "TurnGroupOn",
+			Handler:    _TradfriService_TurnGroupOn_Handler,
+		},
+		{
+			MethodName: "TurnGroupOff",
+			Handler:    _TradfriService_TurnGroupOff_Handler,
+		},
+		{
+			MethodName: "ChangeGroupDimming",
+			Handler:    _TradfriService_ChangeGroupDimming_Handler,
+		},
+		{
+			MethodName: "ActivateGroupScene",
+			Handler:    _TradfriService_ActivateGroupScene_Handler,
+		},
+		{
+			MethodName: "Batch",
+			Handler:    _TradfriService_Batch_Handler,
+		},
+		{
+			MethodName: "RawRequest",
+			Handler:    _TradfriService_RawRequest_Handler,
+		},
+		{
+			MethodName: "ListGateways",
+			Handler:    _TradfriService_ListGateways_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDevices",
+			Handler:       _TradfriService_WatchDevices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchGroups",
+			Handler:       _TradfriService_WatchGroups_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tradfri.proto",
 }
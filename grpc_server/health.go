@@ -0,0 +1,54 @@
+package grpc_server
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/health"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Service names of the grpc.health.v1 service for probes checking liveness and readiness. The empty service
+// name and the TradfriService report readiness as well. The health of a single gateway is reported by the
+// services suffixed with "/" and its name, e.g. "readiness/garage".
+const (
+	LivenessService  = "liveness"
+	ReadinessService = "readiness"
+)
+
+// UpdateHealth sets the statuses of the health server from the trackers of the gateways by name every
+// interval until ctx is cancelled, when all services are set to NOT_SERVING. Readiness requires all gateways to
+// be ready, liveness any gateway to be live, see health.CheckAll.
+func UpdateHealth(ctx context.Context, hs *grpchealth.Server, trackers map[string]*health.Tracker, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		setHealth(hs, health.CheckAll(trackers))
+		select {
+		case <-ctx.Done():
+			hs.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func setHealth(hs *grpchealth.Server, status health.Status) {
+	hs.SetServingStatus(LivenessService, servingStatus(status.Live))
+	for _, service := range []string{"", ReadinessService, pb.TradfriService_ServiceDesc.ServiceName} {
+		hs.SetServingStatus(service, servingStatus(status.Ready))
+	}
+	for name, report := range status.Gateways {
+		hs.SetServingStatus(LivenessService+"/"+name, servingStatus(report.Live))
+		hs.SetServingStatus(ReadinessService+"/"+name, servingStatus(report.Ready))
+	}
+}
+
+func servingStatus(ok bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
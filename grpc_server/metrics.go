@@ -0,0 +1,30 @@
+package grpc_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnaryInterceptor records Prometheus metrics for unary calls.
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor records Prometheus metrics for streaming calls, their duration is the lifetime of the stream.
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
@@ -2,11 +2,19 @@ package grpc_server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/gateway"
 	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
 	"github.com/eriklupander/tradfri-go/model"
 	"github.com/eriklupander/tradfri-go/tradfri"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -15,31 +23,104 @@ type TradfriClient interface {
 	GetDevice(deviceId int) (model.Device, error)
 	GetGroup(groupId int) (model.Group, error)
 	ListGroups() ([]model.Group, error)
+	ListDevices() ([]model.Device, error)
 	PutDeviceColor(deviceId int, x, y int) (model.Result, error)
+	PutDeviceColorTimed(deviceId int, x, y int, transitionTimeMS int) (model.Result, error)
 	PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error)
+	PutDeviceColorRGBTimed(deviceId int, rgb string, transitionTimeMS int) (model.Result, error)
+	PutDeviceColorHSLTimed(deviceId int, hue, saturation, lightness float64, transitionTimeMS int) (model.Result, error)
+	PutDeviceColorTemperatureTimed(deviceId int, kelvin int, transitionTimeMS int) (model.Result, error)
 	PutDeviceDimming(deviceId int, dimming int) (model.Result, error)
 	PutDevicePower(deviceId int, power int) (model.Result, error)
+	PutOutletPower(deviceId int, power int) (model.Result, error)
 	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+	PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error)
+	PutGroupPower(groupId int, power int) (model.Result, error)
+	PutGroupDimming(groupId int, dimming int) (model.Result, error)
+	PutGroupScene(groupId int, sceneId int) (model.Result, error)
+	RawRequest(method, path string, payload []byte) (model.RawResponse, error)
 }
 
-// New initializes a new tradfri gRPC server.
-func New(tradfriClient *tradfri.Client) pb.TradfriServiceServer {
-	return &server{tradfriClient: tradfriClient}
+// GatewayMetadata is the metadata key, and the header of Connect requests, naming the gateway a call is
+// for. Calls without it are sent to the default gateway.
+const GatewayMetadata = "tradfri-gateway"
+
+// Gateway is a named gateway served by the gRPC server.
+type Gateway struct {
+	Name   string
+	Client TradfriClient
+	// Watcher enables the Watch RPCs for the gateway.
+	Watcher *tradfri.Watcher
+}
+
+// New initializes a new tradfri gRPC server. The watcher is optional and enables the Watch RPCs.
+func New(tradfriClient *tradfri.Client, watcher *tradfri.Watcher) pb.TradfriServiceServer {
+	return NewGateways([]Gateway{{Name: gateway.DefaultName, Client: tradfriClient, Watcher: watcher}})
+}
+
+// NewGateways initializes a tradfri gRPC server for several gateways, the first one is the default gateway.
+func NewGateways(gateways []Gateway) pb.TradfriServiceServer {
+	return &server{gateways: gateways}
 }
 
 type server struct {
-	tradfriClient TradfriClient
+	gateways []Gateway
+}
+
+// gateway returns the gateway named by the metadata of ctx, or the default gateway.
+func (s *server) gateway(ctx context.Context) (Gateway, error) {
+	name := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(GatewayMetadata); len(values) > 0 {
+			name = values[0]
+		}
+	}
+	for _, g := range s.gateways {
+		if name == "" || g.Name == name {
+			return g, nil
+		}
+	}
+	return Gateway{}, status.Errorf(codes.NotFound, "gateway %q is not configured", name)
+}
+
+// client returns the client of the gateway selected by ctx, bound to ctx so that CoAP exchanges become part
+// of the call's trace.
+func (s *server) client(ctx context.Context) (TradfriClient, error) {
+	g, err := s.gateway(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bindClient(ctx, g.Client), nil
+}
+
+func bindClient(ctx context.Context, c TradfriClient) TradfriClient {
+	if tc, ok := c.(*tradfri.Client); ok {
+		return tc.WithContext(ctx)
+	}
+	return c
 }
 
 func (s *server) ListGroups(ctx context.Context, r *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	gateways, err := s.selectGateways(ctx, r.GetAllGateways())
+	if err != nil {
+		return nil, err
+	}
+	perGateway, err := acrossGateways(ctx, gateways, func(c TradfriClient) ([]model.Group, error) { return c.ListGroups() })
+	if err != nil {
+		return nil, gatewayError(err)
+	}
+	principal := auth.FromContext(ctx)
 	res := make([]*pb.Group, 0)
-	{
-		groups, err := s.tradfriClient.ListGroups()
-		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
-		}
+	for i, groups := range perGateway {
 		for _, g := range groups {
-			res = append(res, model.ToGroupResponseProto(g))
+			if !principal.AllowsGroup(g.DeviceId) {
+				continue
+			}
+			group := model.ToGroupResponseProto(g)
+			if r.GetAllGateways() {
+				group.Gateway = gateways[i].Name
+			}
+			res = append(res, group)
 		}
 	}
 	return &pb.ListGroupsResponse{
@@ -51,9 +132,13 @@ func (s *server) GetGroup(ctx context.Context, r *pb.GetGroupRequest) (*pb.GetGr
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
 	}
-	g, err := s.tradfriClient.GetGroup(int(r.GetId()))
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	g, err := client.GetGroup(int(r.GetId()))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, gatewayError(err)
 	}
 	return &pb.GetGroupResponse{
 		Group: model.ToGroupResponseProto(g),
@@ -64,13 +149,24 @@ func (s *server) ListDevices(ctx context.Context, r *pb.ListDevicesRequest) (*pb
 	if r.GetGroupId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
 	}
-	g, err := s.tradfriClient.GetGroup(int(r.GetGroupId()))
+	client, err := s.client(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
+	g, err := client.GetGroup(int(r.GetGroupId()))
+	if err != nil {
+		return nil, gatewayError(err)
+	}
+	principal := auth.FromContext(ctx)
 	res := make([]*pb.Device, 0)
 	for _, id := range g.Content.DeviceList.DeviceIds {
-		d, _ := s.tradfriClient.GetDevice(id)
+		if !principal.AllowsDevice(id) {
+			continue
+		}
+		d, err := client.GetDevice(id)
+		if err != nil {
+			return nil, gatewayError(err)
+		}
 		res = append(res, model.ToDeviceResponseProto(d))
 	}
 	return &pb.ListDevicesResponse{
@@ -78,17 +174,52 @@ func (s *server) ListDevices(ctx context.Context, r *pb.ListDevicesRequest) (*pb
 	}, nil
 }
 
+func (s *server) ListAllDevices(ctx context.Context, r *pb.ListAllDevicesRequest) (*pb.ListDevicesResponse, error) {
+	gateways, err := s.selectGateways(ctx, r.GetAllGateways())
+	if err != nil {
+		return nil, err
+	}
+	perGateway, err := acrossGateways(ctx, gateways, func(c TradfriClient) ([]model.Device, error) { return c.ListDevices() })
+	if err != nil {
+		return nil, gatewayError(err)
+	}
+	principal := auth.FromContext(ctx)
+	res := make([]*pb.Device, 0)
+	for i, devices := range perGateway {
+		for _, d := range devices {
+			if !principal.AllowsDevice(d.DeviceId) {
+				continue
+			}
+			device := model.ToDeviceResponseProto(d)
+			if r.GetAllGateways() {
+				device.Gateway = gateways[i].Name
+			}
+			res = append(res, device)
+		}
+	}
+	return &pb.ListDevicesResponse{
+		Devices: res,
+	}, nil
+}
+
 func (s *server) ListDeviceIDs(ctx context.Context, r *pb.ListDeviceIDsRequest) (*pb.ListDeviceIDsResponse, error) {
 	if r.GetGroupId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
 	}
-	g, err := s.tradfriClient.GetGroup(int(r.GetGroupId()))
+	client, err := s.client(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
+	g, err := client.GetGroup(int(r.GetGroupId()))
+	if err != nil {
+		return nil, gatewayError(err)
+	}
+	principal := auth.FromContext(ctx)
 	res := make([]int32, 0)
 	for _, id := range g.Content.DeviceList.DeviceIds {
-		res = append(res, int32(id))
+		if principal.AllowsDevice(id) {
+			res = append(res, int32(id))
+		}
 	}
 	return &pb.ListDeviceIDsResponse{
 		Ids: res,
@@ -99,9 +230,13 @@ func (s *server) GetDevice(ctx context.Context, r *pb.GetDeviceRequest) (*pb.Get
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
-	d, err := s.tradfriClient.GetDevice(int(r.GetId()))
+	client, err := s.client(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+	d, err := client.GetDevice(int(r.GetId()))
+	if err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.GetDeviceResponse{
 		Device: model.ToDeviceResponseProto(d),
@@ -112,16 +247,71 @@ func (s *server) ChangeDeviceColor(ctx context.Context, r *pb.ChangeDeviceColorR
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
+	transition, err := transitionTime(r.GetTransitionTimeMs())
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// rgb
 	if r.GetRgb() != "" {
-		if _, err := s.tradfriClient.PutDeviceColorRGB(int(r.GetId()), r.GetRgb()); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+		if _, err := client.PutDeviceColorRGBTimed(int(r.GetId()), r.GetRgb(), transition); err != nil {
+			return nil, gatewayError(err)
 		}
 		return &pb.ChangeDeviceColorResponse{}, nil
 	}
 	// we assume it is x and y request
-	if _, err := s.tradfriClient.PutDeviceColor(int(r.GetId()), int(r.GetXcolor()), int(r.GetYcolor())); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if _, err := client.PutDeviceColorTimed(int(r.GetId()), int(r.GetXcolor()), int(r.GetYcolor()), transition); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.ChangeDeviceColorResponse{}, nil
+}
+
+func (s *server) ChangeDeviceColorHSL(ctx context.Context, r *pb.ChangeDeviceColorHSLRequest) (*pb.ChangeDeviceColorResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
+	}
+	switch {
+	case r.GetHue() < 0 || r.GetHue() > 360:
+		return nil, status.Error(codes.InvalidArgument, "hue must be between 0 and 360")
+	case r.GetSaturation() < 0 || r.GetSaturation() > 100:
+		return nil, status.Error(codes.InvalidArgument, "saturation must be between 0 and 100")
+	case r.GetLightness() < 0 || r.GetLightness() > 100:
+		return nil, status.Error(codes.InvalidArgument, "lightness must be between 0 and 100")
+	}
+	transition, err := transitionTime(r.GetTransitionTimeMs())
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDeviceColorHSLTimed(int(r.GetId()), r.GetHue(), r.GetSaturation(), r.GetLightness(), transition); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.ChangeDeviceColorResponse{}, nil
+}
+
+func (s *server) ChangeDeviceColorTemperature(ctx context.Context, r *pb.ChangeDeviceColorTemperatureRequest) (*pb.ChangeDeviceColorResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
+	}
+	if r.GetKelvin() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "kelvin is mandatory")
+	}
+	transition, err := transitionTime(r.GetTransitionTimeMs())
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDeviceColorTemperatureTimed(int(r.GetId()), int(r.GetKelvin()), transition); err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.ChangeDeviceColorResponse{}, nil
 }
@@ -130,8 +320,15 @@ func (s *server) ChangeDeviceDimming(ctx context.Context, r *pb.ChangeDeviceDimm
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
-	if _, err := s.tradfriClient.PutDeviceDimming(int(r.GetId()), int(r.GetValue())); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err := checkDimmer(r.GetValue()); err != nil {
+		return nil, err
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDeviceDimming(int(r.GetId()), int(r.GetValue())); err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.ChangeDeviceDimmingResponse{}, nil
 }
@@ -140,8 +337,12 @@ func (s *server) TurnDeviceOn(ctx context.Context, r *pb.TurnDeviceOnRequest) (*
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
-	if _, err := s.tradfriClient.PutDevicePower(int(r.GetId()), 1); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDevicePower(int(r.GetId()), 1); err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.TurnDeviceOnResponse{}, nil
 }
@@ -150,18 +351,271 @@ func (s *server) TurnDeviceOff(ctx context.Context, r *pb.TurnDeviceOffRequest)
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
-	if _, err := s.tradfriClient.PutDevicePower(int(r.GetId()), 0); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDevicePower(int(r.GetId()), 0); err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.TurnDeviceOffResponse{}, nil
 }
 
+func (s *server) ChangeDeviceState(ctx context.Context, r *pb.ChangeDeviceStateRequest) (*pb.ChangeDeviceStateResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
+	}
+	if err := checkDimmer(r.GetDimmer()); err != nil {
+		return nil, err
+	}
+	power := 0
+	if r.GetPower() {
+		power = 1
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDeviceState(int(r.GetId()), power, int(r.GetDimmer())); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.ChangeDeviceStateResponse{}, nil
+}
+
+func (s *server) TurnOutletOn(ctx context.Context, r *pb.TurnOutletOnRequest) (*pb.TurnOutletOnResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutOutletPower(int(r.GetId()), 1); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.TurnOutletOnResponse{}, nil
+}
+
+func (s *server) TurnOutletOff(ctx context.Context, r *pb.TurnOutletOffRequest) (*pb.TurnOutletOffResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutOutletPower(int(r.GetId()), 0); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.TurnOutletOffResponse{}, nil
+}
+
 func (s *server) ChangeDevicePositioning(ctx context.Context, r *pb.ChangeDevicePositioningRequest) (*pb.ChangeDevicePositioningResponse, error) {
 	if r.GetId() < 1 {
 		return nil, status.Error(codes.InvalidArgument, "id is mandatory")
 	}
-	if _, err := s.tradfriClient.PutDevicePositioning(int(r.GetId()), float32(r.GetValue())); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	// older clients send the deprecated integer value
+	position := float32(r.GetValue())
+	if r.Position != nil {
+		position = r.GetPosition()
+	}
+	if position < 0 || position > 100 {
+		return nil, status.Error(codes.InvalidArgument, "position must be between 0 and 100")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutDevicePositioning(int(r.GetId()), position); err != nil {
+		return nil, gatewayError(err)
 	}
 	return &pb.ChangeDevicePositioningResponse{}, nil
 }
+
+func (s *server) TurnGroupOn(ctx context.Context, r *pb.TurnGroupOnRequest) (*pb.TurnGroupOnResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutGroupPower(int(r.GetId()), 1); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.TurnGroupOnResponse{}, nil
+}
+
+func (s *server) TurnGroupOff(ctx context.Context, r *pb.TurnGroupOffRequest) (*pb.TurnGroupOffResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutGroupPower(int(r.GetId()), 0); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.TurnGroupOffResponse{}, nil
+}
+
+func (s *server) ChangeGroupDimming(ctx context.Context, r *pb.ChangeGroupDimmingRequest) (*pb.ChangeGroupDimmingResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
+	}
+	if err := checkDimmer(r.GetValue()); err != nil {
+		return nil, err
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutGroupDimming(int(r.GetId()), int(r.GetValue())); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.ChangeGroupDimmingResponse{}, nil
+}
+
+func (s *server) ActivateGroupScene(ctx context.Context, r *pb.ActivateGroupSceneRequest) (*pb.ActivateGroupSceneResponse, error) {
+	if r.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "group id is mandatory")
+	}
+	if r.GetSceneId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "scene id is mandatory")
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.PutGroupScene(int(r.GetId()), int(r.GetSceneId())); err != nil {
+		return nil, gatewayError(err)
+	}
+	return &pb.ActivateGroupSceneResponse{}, nil
+}
+
+func (s *server) RawRequest(ctx context.Context, r *pb.RawRequestRequest) (*pb.RawRequestResponse, error) {
+	if r.GetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is mandatory")
+	}
+	payload := r.GetPayload()
+	if r.GetReadable() && len(payload) > 0 {
+		var err error
+		if payload, err = model.NumericKeys(payload); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "payload is not valid JSON: %v", err)
+		}
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.RawRequest(r.GetMethod(), r.GetPath(), payload)
+	if errors.Is(err, tradfri.ErrUnsupportedMethod) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, gatewayError(err)
+	}
+	options := make([]*pb.RawOption, 0, len(res.Options))
+	for _, o := range res.Options {
+		options = append(options, &pb.RawOption{Number: int32(o.Number), Name: o.Name, Value: o.Value})
+	}
+	if r.GetReadable() {
+		res.Payload = model.ReadableOrRaw([]byte(res.Payload))
+	}
+	return &pb.RawRequestResponse{
+		Code:     res.Code,
+		CodeName: res.CodeName,
+		Options:  options,
+		Payload:  []byte(res.Payload),
+	}, nil
+}
+
+func (s *server) ListGateways(ctx context.Context, r *pb.ListGatewaysRequest) (*pb.ListGatewaysResponse, error) {
+	res := make([]*pb.Gateway, 0, len(s.gateways))
+	for i, g := range s.gateways {
+		res = append(res, &pb.Gateway{Name: g.Name, IsDefault: i == 0})
+	}
+	return &pb.ListGatewaysResponse{
+		Gateways: res,
+	}, nil
+}
+
+// selectGateways returns all gateways, or the one selected by ctx.
+func (s *server) selectGateways(ctx context.Context, all bool) ([]Gateway, error) {
+	if all {
+		return s.gateways, nil
+	}
+	g, err := s.gateway(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []Gateway{g}, nil
+}
+
+// acrossGateways queries the gateways concurrently and returns their results in the order of gateways. The
+// listing fails if any gateway fails, naming the gateway.
+func acrossGateways[T any](ctx context.Context, gateways []Gateway, list func(c TradfriClient) ([]T, error)) ([][]T, error) {
+	res := make([][]T, len(gateways))
+	g := errgroup.Group{}
+	for i, gw := range gateways {
+		g.Go(func() error {
+			items, err := list(bindClient(ctx, gw.Client))
+			if err != nil {
+				return fmt.Errorf("gateway %q: %w", gw.Name, err)
+			}
+			res[i] = items
+			return nil
+		})
+	}
+	return res, g.Wait()
+}
+
+// transitionTime returns the transition time of a color change in milliseconds, 0 selects the default.
+func transitionTime(ms int32) (int, error) {
+	switch {
+	case ms < 0:
+		return 0, status.Error(codes.InvalidArgument, "transition time must not be negative")
+	case ms == 0:
+		return 500, nil
+	}
+	return int(ms), nil
+}
+
+// checkDimmer rejects dimmer values the gateway does not accept.
+func checkDimmer(v int32) error {
+	if v < 0 || v > 254 {
+		return status.Error(codes.InvalidArgument, "dimmer must be between 0 and 254")
+	}
+	return nil
+}
+
+// gatewayError maps an error returned by a gateway to a gRPC status, in the same way problem.FromError
+// maps it to a REST problem.
+func gatewayError(err error) error {
+	var gwErr tradfri.GatewayError
+	if errors.As(err, &gwErr) {
+		switch gwErr.Code {
+		case coap.NotFound:
+			return status.Error(codes.NotFound, err.Error())
+		case coap.BadRequest:
+			return status.Error(codes.InvalidArgument, err.Error())
+		case coap.MethodNotAllowed, coap.PreconditionFailed:
+			return status.Error(codes.FailedPrecondition, err.Error())
+		case coap.ServiceUnavailable:
+			return status.Error(codes.Unavailable, err.Error())
+		case coap.GatewayTimeout:
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		return status.Error(codes.Unknown, err.Error())
+	}
+	switch {
+	case errors.Is(err, dtlscoap.ErrUnavailable), errors.Is(err, dtlscoap.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, dtlscoap.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -2,34 +2,95 @@ package grpc_server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
 	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/grpc_server/golang/golangconnect"
+	"github.com/eriklupander/tradfri-go/health"
 	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 // mockClient implements TradfriClient without any DTLS or gateway dependency.
 type mockClient struct {
-	device model.Device
-	group  model.Group
-	groups []model.Group
-	result model.Result
-	err    error
-}
-
-func (m *mockClient) GetDevice(_ int) (model.Device, error)                       { return m.device, m.err }
-func (m *mockClient) GetGroup(_ int) (model.Group, error)                         { return m.group, m.err }
-func (m *mockClient) ListGroups() ([]model.Group, error)                          { return m.groups, m.err }
-func (m *mockClient) PutDeviceColor(_ int, _, _ int) (model.Result, error)        { return m.result, m.err }
-func (m *mockClient) PutDeviceColorRGB(_ int, _ string) (model.Result, error)     { return m.result, m.err }
-func (m *mockClient) PutDeviceDimming(_ int, _ int) (model.Result, error)         { return m.result, m.err }
-func (m *mockClient) PutDevicePower(_ int, _ int) (model.Result, error)           { return m.result, m.err }
-func (m *mockClient) PutDevicePositioning(_ int, _ float32) (model.Result, error) { return m.result, m.err }
+	device  model.Device
+	devices []model.Device
+	group   model.Group
+	groups  []model.Group
+	result  model.Result
+	raw     model.RawResponse
+	err     error
+	// deviceErr fails GetDevice only
+	deviceErr error
+
+	// arguments of the last call
+	position float32
+	kelvin   int
+}
+
+func (m *mockClient) ListDevices() ([]model.Device, error) { return m.devices, m.err }
+
+func (m *mockClient) GetDevice(_ int) (model.Device, error) {
+	if m.deviceErr != nil {
+		return model.Device{}, m.deviceErr
+	}
+	return m.device, m.err
+}
+func (m *mockClient) GetGroup(_ int) (model.Group, error)                     { return m.group, m.err }
+func (m *mockClient) ListGroups() ([]model.Group, error)                      { return m.groups, m.err }
+func (m *mockClient) PutDeviceColor(_ int, _, _ int) (model.Result, error)    { return m.result, m.err }
+func (m *mockClient) PutDeviceColorRGB(_ int, _ string) (model.Result, error) { return m.result, m.err }
+func (m *mockClient) PutDeviceDimming(_ int, _ int) (model.Result, error)     { return m.result, m.err }
+func (m *mockClient) PutDevicePower(_ int, _ int) (model.Result, error)       { return m.result, m.err }
+func (m *mockClient) PutDeviceColorTimed(_ int, _, _ int, _ int) (model.Result, error) {
+	return m.result, m.err
+}
+func (m *mockClient) PutDeviceColorRGBTimed(_ int, _ string, _ int) (model.Result, error) {
+	return m.result, m.err
+}
+func (m *mockClient) PutDeviceColorHSLTimed(_ int, _, _, _ float64, _ int) (model.Result, error) {
+	return m.result, m.err
+}
+func (m *mockClient) PutDeviceColorTemperatureTimed(_ int, kelvin int, _ int) (model.Result, error) {
+	m.kelvin = kelvin
+	return m.result, m.err
+}
+func (m *mockClient) PutOutletPower(_ int, _ int) (model.Result, error) { return m.result, m.err }
+func (m *mockClient) PutDevicePositioning(_ int, position float32) (model.Result, error) {
+	m.position = position
+	return m.result, m.err
+}
+func (m *mockClient) PutDeviceState(_ int, _, _ int) (model.Result, error) { return m.result, m.err }
+func (m *mockClient) PutGroupPower(_ int, _ int) (model.Result, error)     { return m.result, m.err }
+func (m *mockClient) PutGroupDimming(_ int, _ int) (model.Result, error)   { return m.result, m.err }
+func (m *mockClient) PutGroupScene(_ int, _ int) (model.Result, error)     { return m.result, m.err }
+func (m *mockClient) RawRequest(_, _ string, _ []byte) (model.RawResponse, error) {
+	return m.raw, m.err
+}
 
 func newTestServer(mc *mockClient) *server {
-	return &server{tradfriClient: mc}
+	return &server{gateways: []Gateway{{Name: "default", Client: mc}}}
 }
 
 // ── ListGroups ────────────────────────────────────────────────────────────────
@@ -112,6 +173,14 @@ func TestListDevices(t *testing.T) {
 	}
 }
 
+func TestListDevices_DeviceError(t *testing.T) {
+	mc := &mockClient{deviceErr: tradfri.GatewayError{Code: coap.NotFound, Path: "/15001/101"}}
+	mc.group.Content.DeviceList.DeviceIds = []int{101}
+	s := newTestServer(mc)
+	_, err := s.ListDevices(context.Background(), &pb.ListDevicesRequest{GroupId: 5})
+	assertCode(t, err, codes.NotFound)
+}
+
 func TestListDevices_MissingGroupId(t *testing.T) {
 	s := newTestServer(&mockClient{})
 	_, err := s.ListDevices(context.Background(), &pb.ListDevicesRequest{GroupId: 0})
@@ -142,6 +211,20 @@ func TestListDeviceIDs(t *testing.T) {
 	}
 }
 
+func TestListDeviceIDs_Filtered(t *testing.T) {
+	mc := &mockClient{}
+	mc.group.Content.DeviceList.DeviceIds = []int{10, 20, 30}
+	s := newTestServer(mc)
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Scope: auth.ScopeRead, Devices: []int{20}})
+	resp, err := s.ListDeviceIDs(ctx, &pb.ListDeviceIDsRequest{GroupId: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Ids) != 1 || resp.Ids[0] != 20 {
+		t.Fatalf("expected only device 20, got %v", resp.Ids)
+	}
+}
+
 func TestListDeviceIDs_MissingGroupId(t *testing.T) {
 	s := newTestServer(&mockClient{})
 	_, err := s.ListDeviceIDs(context.Background(), &pb.ListDeviceIDsRequest{GroupId: 0})
@@ -213,6 +296,19 @@ func TestChangeDeviceDimming_MissingId(t *testing.T) {
 	assertCode(t, err, codes.InvalidArgument)
 }
 
+func TestDimmerOutOfRange(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	ctx := context.Background()
+	_, err := s.ChangeDeviceDimming(ctx, &pb.ChangeDeviceDimmingRequest{Id: 7, Value: 255})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ChangeDeviceDimming(ctx, &pb.ChangeDeviceDimmingRequest{Id: 7, Value: -1})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ChangeGroupDimming(ctx, &pb.ChangeGroupDimmingRequest{Id: 131073, Value: 300})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ChangeDeviceState(ctx, &pb.ChangeDeviceStateRequest{Id: 7, Power: true, Dimmer: 255})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
 // ── TurnDeviceOn / TurnDeviceOff ──────────────────────────────────────────────
 
 func TestTurnDeviceOn(t *testing.T) {
@@ -259,6 +355,200 @@ func TestChangeDevicePositioning_MissingId(t *testing.T) {
 	assertCode(t, err, codes.InvalidArgument)
 }
 
+func TestChangeDevicePositioning_Position(t *testing.T) {
+	mc := &mockClient{}
+	s := newTestServer(mc)
+	position := float32(42.5)
+	if _, err := s.ChangeDevicePositioning(context.Background(), &pb.ChangeDevicePositioningRequest{Id: 7, Position: &position}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mc.position != 42.5 {
+		t.Fatalf("expected position 42.5, got %f", mc.position)
+	}
+
+	position = 101
+	_, err := s.ChangeDevicePositioning(context.Background(), &pb.ChangeDevicePositioningRequest{Id: 7, Position: &position})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+// ── ChangeDeviceState / ChangeDeviceColorHSL / ChangeDeviceColorTemperature ──
+
+func TestChangeDeviceState(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.ChangeDeviceState(context.Background(), &pb.ChangeDeviceStateRequest{Id: 7, Power: true, Dimmer: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestChangeDeviceState_MissingId(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.ChangeDeviceState(context.Background(), &pb.ChangeDeviceStateRequest{Id: 0})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestChangeDeviceColorHSL(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.ChangeDeviceColorHSL(context.Background(), &pb.ChangeDeviceColorHSLRequest{Id: 7, Hue: 300, Saturation: 50, Lightness: 80, TransitionTimeMs: 2000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestChangeDeviceColorHSL_Invalid(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.ChangeDeviceColorHSL(context.Background(), &pb.ChangeDeviceColorHSLRequest{Id: 7, Hue: 400})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ChangeDeviceColorHSL(context.Background(), &pb.ChangeDeviceColorHSLRequest{Id: 7, TransitionTimeMs: -1})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestChangeDeviceColorTemperature(t *testing.T) {
+	mc := &mockClient{}
+	s := newTestServer(mc)
+	_, err := s.ChangeDeviceColorTemperature(context.Background(), &pb.ChangeDeviceColorTemperatureRequest{Id: 7, Kelvin: 2700})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mc.kelvin != 2700 {
+		t.Fatalf("expected 2700 K, got %d", mc.kelvin)
+	}
+}
+
+func TestChangeDeviceColorTemperature_MissingKelvin(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.ChangeDeviceColorTemperature(context.Background(), &pb.ChangeDeviceColorTemperatureRequest{Id: 7})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+// ── TurnOutletOn / TurnOutletOff ──────────────────────────────────────────────
+
+func TestTurnOutletOn(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.TurnOutletOn(context.Background(), &pb.TurnOutletOnRequest{Id: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTurnOutletOff_MissingId(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	_, err := s.TurnOutletOff(context.Background(), &pb.TurnOutletOffRequest{Id: 0})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+// ── ListAllDevices ────────────────────────────────────────────────────────────
+
+func TestListAllDevices(t *testing.T) {
+	s := newTestServer(&mockClient{devices: []model.Device{{DeviceId: 7}, {DeviceId: 8}}})
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Scope: auth.ScopeRead, Devices: []int{8}})
+	resp, err := s.ListAllDevices(ctx, &pb.ListAllDevicesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Devices) != 1 || resp.Devices[0].GetMetadata().GetId() != 8 {
+		t.Fatalf("expected only device 8, got %v", resp.Devices)
+	}
+}
+
+// ── Group writes ──────────────────────────────────────────────────────────────
+
+func TestGroupWrites(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	ctx := context.Background()
+	if _, err := s.TurnGroupOn(ctx, &pb.TurnGroupOnRequest{Id: 131073}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.TurnGroupOff(ctx, &pb.TurnGroupOffRequest{Id: 131073}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.ChangeGroupDimming(ctx, &pb.ChangeGroupDimmingRequest{Id: 131073, Value: 127}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.ActivateGroupScene(ctx, &pb.ActivateGroupSceneRequest{Id: 131073, SceneId: 196608}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGroupWrites_MissingId(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	ctx := context.Background()
+	_, err := s.TurnGroupOn(ctx, &pb.TurnGroupOnRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ChangeGroupDimming(ctx, &pb.ChangeGroupDimmingRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = s.ActivateGroupScene(ctx, &pb.ActivateGroupSceneRequest{Id: 131073})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+// ── WatchDevices ──────────────────────────────────────────────────────────────
+
+func TestWatchDevices(t *testing.T) {
+	var blinds []model.Device
+	_ = json.Unmarshal([]byte(`[{"9003":7,"15015":[{"5536":20}]},{"9003":8,"15015":[{"5536":80}]}]`), &blinds)
+	s := newWatchingTestServer(t, &mockClient{device: blinds[0], devices: blinds})
+	stream := newMockStream[pb.Device]()
+	go func() { _ = s.WatchDevices(&pb.WatchDevicesRequest{Ids: []int32{7}}, stream) }()
+
+	// the snapshot, followed by the first poll reporting both devices as changed
+	for i := 0; i < 2; i++ {
+		if d := stream.next(t); d.GetMetadata().GetId() != 7 {
+			t.Fatalf("expected device 7, got %v", d)
+		}
+	}
+	stream.expectNone(t)
+}
+
+func TestWatchDevices_SkipSnapshot(t *testing.T) {
+	s := newWatchingTestServer(t, &mockClient{devices: []model.Device{{DeviceId: 7}}})
+	stream := newMockStream[pb.Device]()
+	go func() { _ = s.WatchDevices(&pb.WatchDevicesRequest{SkipSnapshot: true}, stream) }()
+	stream.next(t)
+	stream.expectNone(t)
+}
+
+func TestWatchDevices_NoWatcher(t *testing.T) {
+	s := newTestServer(&mockClient{})
+	err := s.WatchDevices(&pb.WatchDevicesRequest{}, newMockStream[pb.Device]())
+	assertCode(t, err, codes.Unavailable)
+}
+
+func TestWatchDevices_EndsWhenWatcherStops(t *testing.T) {
+	watcher := tradfri.NewWatcher(&mockClient{}, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	go watcher.Run(ctx)
+	s := &server{gateways: []Gateway{{Name: "default", Client: &mockClient{}, Watcher: watcher}}}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchDevices(&pb.WatchDevicesRequest{SkipSnapshot: true}, newMockStream[pb.Device]())
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the stream to end when the watcher stops")
+	}
+}
+
+// ── WatchGroups ───────────────────────────────────────────────────────────────
+
+func TestWatchGroups(t *testing.T) {
+	mc := &mockClient{groups: []model.Group{{Name: "Hall", DeviceId: 10}, {Name: "Kitchen", DeviceId: 11}}}
+	s := newWatchingTestServer(t, mc)
+	stream := newMockStream[pb.Group]()
+	go func() { _ = s.WatchGroups(&pb.WatchGroupsRequest{}, stream) }()
+
+	// snapshot of both groups, followed by both groups reported by the first poll
+	for i := 0; i < 4; i++ {
+		stream.next(t)
+	}
+	stream.expectNone(t)
+}
+
 // ── helpers ───────────────────────────────────────────────────────────────────
 
 func assertCode(t *testing.T, err error, expected codes.Code) {
@@ -270,3 +560,470 @@ func assertCode(t *testing.T, err error, expected codes.Code) {
 		t.Fatalf("expected gRPC code %v, got %v", expected, err)
 	}
 }
+
+func newWatchingTestServer(t *testing.T, mc *mockClient) *server {
+	watcher := tradfri.NewWatcher(mc, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go watcher.Run(ctx)
+	return &server{gateways: []Gateway{{Name: "default", Client: mc, Watcher: watcher}}}
+}
+
+// mockStream implements grpc.ServerStreamingServer and collects the sent messages.
+type mockStream[T any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *T
+}
+
+func newMockStream[T any]() *mockStream[T] {
+	return &mockStream[T]{ctx: context.Background(), sent: make(chan *T, 16)}
+}
+
+func (m *mockStream[T]) Context() context.Context { return m.ctx }
+func (m *mockStream[T]) Send(msg *T) error {
+	m.sent <- msg
+	return nil
+}
+
+func (m *mockStream[T]) next(t *testing.T) *T {
+	t.Helper()
+	select {
+	case msg := <-m.sent:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func (m *mockStream[T]) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case msg := <-m.sent:
+		t.Fatalf("expected no more messages, got %v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// ── Batch ─────────────────────────────────────────────────────────────────────
+
+func TestBatch(t *testing.T) {
+	s := newTestServer(&mockClient{result: model.Result{Msg: "Changed"}})
+	power, scene := int32(1), int32(196608)
+	resp, err := s.Batch(context.Background(), &pb.BatchRequest{Operations: []*pb.BatchOperation{
+		{DeviceId: 65538, Power: &power},
+		{GroupId: 131073, SceneId: &scene},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, r := range resp.GetResults() {
+		if r.GetIndex() != int32(i) || r.GetStatus() != pb.BatchResult_OK || r.GetResult() != "Changed" {
+			t.Fatalf("unexpected result %v", r)
+		}
+	}
+}
+
+func TestBatch_Invalid(t *testing.T) {
+	dimmer := int32(300)
+	_, err := newTestServer(&mockClient{}).Batch(context.Background(), &pb.BatchRequest{Operations: []*pb.BatchOperation{
+		{DeviceId: 65538, Dimmer: &dimmer},
+	}})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestBatch_PermissionDenied(t *testing.T) {
+	power := int32(1)
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Scope: auth.ScopeControl, Devices: []int{7}})
+	_, err := newTestServer(&mockClient{}).Batch(ctx, &pb.BatchRequest{Operations: []*pb.BatchOperation{
+		{DeviceId: 8, Power: &power},
+	}})
+	assertCode(t, err, codes.PermissionDenied)
+}
+
+// ── RawRequest ────────────────────────────────────────────────────────────────
+
+func TestRawRequest(t *testing.T) {
+	s := newTestServer(&mockClient{raw: model.RawResponse{
+		Code:     "2.05",
+		CodeName: "Content",
+		Options:  []model.RawOption{{Number: 12, Name: "Content-Format", Value: "50"}},
+		Payload:  `[65536]`,
+	}})
+	resp, err := s.RawRequest(context.Background(), &pb.RawRequestRequest{Method: "GET", Path: "/15001"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetCode() != "2.05" || string(resp.GetPayload()) != "[65536]" || resp.GetOptions()[0].GetName() != "Content-Format" {
+		t.Fatalf("unexpected response %v", resp)
+	}
+}
+
+func TestRawRequest_Readable(t *testing.T) {
+	s := newTestServer(&mockClient{raw: model.RawResponse{Code: "2.05", Payload: `{"9001":"Hall"}`}})
+	resp, err := s.RawRequest(context.Background(), &pb.RawRequestRequest{Method: "GET", Path: "/15004/131073", Readable: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(resp.GetPayload()) != `{"name":"Hall"}` {
+		t.Fatalf("expected readable keys, got %s", resp.GetPayload())
+	}
+
+	_, err = s.RawRequest(context.Background(), &pb.RawRequestRequest{Method: "PUT", Path: "/15004/131073", Payload: []byte("{"), Readable: true})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestRawRequest_InvalidArgument(t *testing.T) {
+	_, err := newTestServer(&mockClient{}).RawRequest(context.Background(), &pb.RawRequestRequest{Method: "GET"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	s := newTestServer(&mockClient{err: fmt.Errorf("%w: %q", tradfri.ErrUnsupportedMethod, "PATCH")})
+	_, err = s.RawRequest(context.Background(), &pb.RawRequestRequest{Method: "PATCH", Path: "/15001"})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+// ── Auth ──────────────────────────────────────────────────────────────────────
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	a, err := auth.New([]auth.Key{
+		{Name: "reader", Key: "read-key", Scope: "read"},
+		{Name: "controller", Key: "control-key", Scope: "control", Devices: []int{7}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := AuthUnaryInterceptor(a)
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	call := func(method, key string, req any) error {
+		ctx := context.Background()
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+key))
+		}
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	assertCode(t, call(pb.TradfriService_GetDevice_FullMethodName, "", &pb.GetDeviceRequest{Id: 7}), codes.Unauthenticated)
+	assertCode(t, call(pb.TradfriService_TurnDeviceOn_FullMethodName, "read-key", &pb.TurnDeviceOnRequest{Id: 7}), codes.PermissionDenied)
+	assertCode(t, call(pb.TradfriService_TurnDeviceOn_FullMethodName, "control-key", &pb.TurnDeviceOnRequest{Id: 8}), codes.PermissionDenied)
+	assertCode(t, call(pb.TradfriService_RawRequest_FullMethodName, "control-key", &pb.RawRequestRequest{Path: "/15001"}), codes.PermissionDenied)
+	if err := call(pb.TradfriService_TurnDeviceOn_FullMethodName, "control-key", &pb.TurnDeviceOnRequest{Id: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := call(pb.TradfriService_GetDevice_FullMethodName, "read-key", &pb.GetDeviceRequest{Id: 8}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthUnaryInterceptor_Disabled(t *testing.T) {
+	_, err := AuthUnaryInterceptor(nil)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.TradfriService_TurnDeviceOn_FullMethodName},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// ── Client ────────────────────────────────────────────────────────────────────
+
+func TestClient(t *testing.T) {
+	a, err := auth.New([]auth.Key{{Name: "controller", Key: "control-key", Scope: "control"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc := &mockClient{devices: []model.Device{{DeviceId: 7, Name: "Blind"}}, device: model.Device{DeviceId: 7, Name: "Blind"}}
+	listener := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(AuthUnaryInterceptor(a)))
+	pb.RegisterTradfriServiceServer(srv, newTestServer(mc))
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	dial := func(key string) *Client {
+		c, err := Dial("passthrough:///bufnet", key,
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = c.Close() })
+		return c
+	}
+	c := dial("control-key")
+
+	if d, err := c.GetDevice(7); err != nil || d.GetMetadata().GetName() != "Blind" {
+		t.Fatalf("unexpected device %v, error %v", d, err)
+	}
+	if ids, err := c.ListDeviceIds(); err != nil || len(ids) != 1 || ids[0] != 7 {
+		t.Fatalf("unexpected ids %v, error %v", ids, err)
+	}
+	if err := c.PutDevicePositioning(7, 33.3); err != nil || mc.position != 33.3 {
+		t.Fatalf("expected position 33.3, got %f, error %v", mc.position, err)
+	}
+	if err := c.PutDeviceColorTemperature(7, 4000); err != nil || mc.kelvin != 4000 {
+		t.Fatalf("expected 4000 K, got %d, error %v", mc.kelvin, err)
+	}
+	if err := c.PutDeviceColorRGBInt(7, 255, 0, 128); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.PutGroupScene(131073, 196608); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.PutDevicePower(7, 2); err == nil {
+		t.Fatal("expected an error for an invalid power state")
+	}
+	_, err = c.RawRequest("GET", "/15001", nil)
+	assertCode(t, err, codes.PermissionDenied)
+	_, err = dial("unknown-key").GetDevice(7)
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+// ── Gateways ──────────────────────────────────────────────────────────────────
+
+func newGatewaysTestServer() *server {
+	return &server{gateways: []Gateway{
+		{Name: "house", Client: &mockClient{devices: []model.Device{{DeviceId: 7, Name: "Hall"}}}},
+		{Name: "garage", Client: &mockClient{devices: []model.Device{{DeviceId: 7, Name: "Door"}, {DeviceId: 8}}, device: model.Device{DeviceId: 7, Name: "Door"}}},
+	}}
+}
+
+func onGateway(name string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(GatewayMetadata, name))
+}
+
+func TestGateways(t *testing.T) {
+	s := newGatewaysTestServer()
+
+	gateways, err := s.ListGateways(context.Background(), &pb.ListGatewaysRequest{})
+	if err != nil || len(gateways.GetGateways()) != 2 || !gateways.GetGateways()[0].GetIsDefault() || gateways.GetGateways()[1].GetIsDefault() {
+		t.Fatalf("unexpected gateways %v, error %v", gateways, err)
+	}
+
+	names := func(ctx context.Context, all bool) []string {
+		t.Helper()
+		resp, err := s.ListAllDevices(ctx, &pb.ListAllDevicesRequest{AllGateways: all})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res := []string{}
+		for _, d := range resp.GetDevices() {
+			res = append(res, d.GetGateway()+"/"+d.GetMetadata().GetName())
+		}
+		return res
+	}
+	if n := names(context.Background(), false); !slices.Equal(n, []string{"/Hall"}) {
+		t.Errorf("expected the default gateway, got %v", n)
+	}
+	if n := names(onGateway("garage"), false); !slices.Equal(n, []string{"/Door", "/"}) {
+		t.Errorf("expected the garage, got %v", n)
+	}
+	if n := names(context.Background(), true); !slices.Equal(n, []string{"house/Hall", "garage/Door", "garage/"}) {
+		t.Errorf("expected all devices named by their gateway, got %v", n)
+	}
+
+	_, err = s.GetDevice(onGateway("shed"), &pb.GetDeviceRequest{Id: 7})
+	assertCode(t, err, codes.NotFound)
+	err = s.WatchGroups(&pb.WatchGroupsRequest{}, &mockStream[pb.Group]{ctx: onGateway("shed")})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestGateways_ClientAndConnect(t *testing.T) {
+	s := newGatewaysTestServer()
+	listener := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	pb.RegisterTradfriServiceServer(srv, s)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+	c, err := Dial("passthrough:///bufnet", "",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	if devices, err := c.WithGateway("garage").ListDevices(); err != nil || len(devices) != 2 {
+		t.Fatalf("expected the devices of the garage, got %v, error %v", devices, err)
+	}
+	if gateways, err := c.ListGateways(); err != nil || len(gateways) != 2 {
+		t.Fatalf("unexpected gateways %v, error %v", gateways, err)
+	}
+
+	connectClient := golangconnect.NewTradfriServiceClient(http.DefaultClient, newConnectTestServer(t, s, nil).URL)
+	ctx, info := connect.NewClientContext(context.Background())
+	info.RequestHeader().Set(GatewayMetadata, "garage")
+	if res, err := connectClient.GetDevice(ctx, &pb.GetDeviceRequest{Id: 7}); err != nil || res.GetDevice().GetMetadata().GetName() != "Door" {
+		t.Fatalf("unexpected response %v, error %v", res, err)
+	}
+	info.RequestHeader().Set(GatewayMetadata, "shed")
+	if _, err := connectClient.GetDevice(ctx, &pb.GetDeviceRequest{Id: 7}); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+// ── Health ────────────────────────────────────────────────────────────────────
+
+func TestUpdateHealth(t *testing.T) {
+	tracker, garage := health.NewTracker(), health.NewTracker()
+	hs := grpchealth.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		UpdateHealth(ctx, hs, map[string]*health.Tracker{"house": tracker, "garage": garage}, 10*time.Millisecond)
+		close(done)
+	}()
+	expect := func(service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err == nil && resp.GetStatus() == expected {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %q to be %v, got %v (%v)", service, expected, resp.GetStatus(), err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	expect(LivenessService, healthpb.HealthCheckResponse_SERVING)
+	expect(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+	tracker.SetSession(health.SessionUp)
+	expect(ReadinessService+"/house", healthpb.HealthCheckResponse_SERVING)
+	// the garage is still down, which keeps the server from being ready
+	expect(ReadinessService+"/garage", healthpb.HealthCheckResponse_NOT_SERVING)
+	expect(LivenessService+"/garage", healthpb.HealthCheckResponse_SERVING)
+	expect("", healthpb.HealthCheckResponse_NOT_SERVING)
+	garage.SetSession(health.SessionUp)
+	expect("", healthpb.HealthCheckResponse_SERVING)
+	expect(ReadinessService, healthpb.HealthCheckResponse_SERVING)
+	expect(pb.TradfriService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	cancel()
+	<-done
+	expect(LivenessService, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestAuthUnaryInterceptor_HealthIsPublic(t *testing.T) {
+	a, err := auth.New([]auth.Key{{Name: "reader", Key: "read-key", Scope: "read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = AuthUnaryInterceptor(a)(context.Background(), &healthpb.HealthCheckRequest{}, &grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// ── Connect ───────────────────────────────────────────────────────────────────
+
+func newConnectTestServer(t *testing.T, s *server, a *auth.Authenticator) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(ConnectPath, newConnectHandler(s, a))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestConnect(t *testing.T) {
+	a, err := auth.New([]auth.Key{{Name: "reader", Key: "read-key", Scope: "read", Devices: []int{7}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newConnectTestServer(t, newTestServer(&mockClient{device: model.Device{DeviceId: 7, Name: "Bulb"}}), a)
+	withKey := func(key string) context.Context {
+		ctx, info := connect.NewClientContext(context.Background())
+		info.RequestHeader().Set("Authorization", "Bearer "+key)
+		return ctx
+	}
+
+	for name, opts := range map[string][]connect.ClientOption{
+		"connect":  nil,
+		"grpc-web": {connect.WithGRPCWeb()},
+	} {
+		client := golangconnect.NewTradfriServiceClient(srv.Client(), srv.URL, opts...)
+		call := func(id int32, key string) (*pb.GetDeviceResponse, error) {
+			return client.GetDevice(withKey(key), &pb.GetDeviceRequest{Id: id})
+		}
+
+		res, err := call(7, "read-key")
+		if err != nil || res.GetDevice().GetMetadata().GetName() != "Bulb" {
+			t.Fatalf("%s: unexpected response %v, error %v", name, res, err)
+		}
+		for _, tc := range []struct {
+			id       int32
+			key      string
+			expected connect.Code
+		}{
+			{7, "unknown-key", connect.CodeUnauthenticated},
+			{8, "read-key", connect.CodePermissionDenied},
+		} {
+			if _, err := call(tc.id, tc.key); connect.CodeOf(err) != tc.expected {
+				t.Fatalf("%s: expected %v, got %v", name, tc.expected, err)
+			}
+		}
+		if _, err := client.TurnDeviceOn(withKey("read-key"), &pb.TurnDeviceOnRequest{Id: 7}); connect.CodeOf(err) != connect.CodePermissionDenied {
+			t.Fatalf("%s: expected permission_denied, got %v", name, err)
+		}
+	}
+}
+
+func TestConnect_JSON(t *testing.T) {
+	srv := newConnectTestServer(t, newTestServer(&mockClient{device: model.Device{DeviceId: 7, Name: "Bulb"}}), nil)
+	post := func(body string) (int, string) {
+		resp, err := http.Post(srv.URL+ConnectPath+"GetDevice", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	if code, body := post(`{"id": 7}`); code != http.StatusOK || !strings.Contains(body, `"name":"Bulb"`) {
+		t.Fatalf("unexpected response %d %s", code, body)
+	}
+	if code, body := post(`{"id": 0}`); code != http.StatusBadRequest || !strings.Contains(body, `"code":"invalid_argument"`) {
+		t.Fatalf("expected invalid_argument, got %d %s", code, body)
+	}
+}
+
+func TestConnect_WatchDevices(t *testing.T) {
+	s := newWatchingTestServer(t, &mockClient{devices: []model.Device{{DeviceId: 7}}})
+	srv := newConnectTestServer(t, s, nil)
+	client := golangconnect.NewTradfriServiceClient(srv.Client(), srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.WatchDevices(ctx, &pb.WatchDevicesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stream.Receive() || stream.Msg().GetMetadata().GetId() != 7 {
+		t.Fatalf("expected device 7, got %v (%v)", stream.Msg(), stream.Err())
+	}
+}
+
+// ── Gateway errors ────────────────────────────────────────────────────────────
+
+func TestGatewayErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{tradfri.GatewayError{Code: coap.NotFound, Path: "/15001/7"}, codes.NotFound},
+		{tradfri.GatewayError{Code: coap.BadRequest, Path: "/15001/7"}, codes.InvalidArgument},
+		{tradfri.GatewayError{Code: coap.MethodNotAllowed, Path: "/15001/7"}, codes.FailedPrecondition},
+		{fmt.Errorf("gateway %q: %w", "default", dtlscoap.ErrUnavailable), codes.Unavailable},
+		{dtlscoap.ErrClosed, codes.Unavailable},
+		{dtlscoap.ErrTimeout, codes.DeadlineExceeded},
+		{fmt.Errorf("boom"), codes.Internal},
+	}
+	for _, c := range cases {
+		s := newTestServer(&mockClient{err: c.err})
+		_, err := s.GetDevice(context.Background(), &pb.GetDeviceRequest{Id: 7})
+		assertCode(t, err, c.code)
+		_, err = s.ListAllDevices(context.Background(), &pb.ListAllDevicesRequest{})
+		assertCode(t, err, c.code)
+	}
+}
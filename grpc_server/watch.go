@@ -0,0 +1,152 @@
+package grpc_server
+
+import (
+	"slices"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The watch streams subscribe before sending the snapshot so no change can be missed in between,
+// a device or group may therefore be sent twice at the start of a stream.
+
+func (s *server) WatchDevices(r *pb.WatchDevicesRequest, stream grpc.ServerStreamingServer[pb.Device]) error {
+	g, err := s.gateway(stream.Context())
+	if err != nil {
+		return err
+	}
+	if g.Watcher == nil {
+		return status.Error(codes.Unavailable, "state updates are not enabled on this server")
+	}
+	client := bindClient(stream.Context(), g.Client)
+	principal := auth.FromContext(stream.Context())
+	ids := toInts(r.GetIds())
+	all := len(ids) == 0 && r.GetGroupId() < 1
+	if r.GetGroupId() > 0 {
+		g, err := client.GetGroup(int(r.GetGroupId()))
+		if err != nil {
+			return gatewayError(err)
+		}
+		ids = append(ids, g.Content.DeviceList.DeviceIds...)
+	}
+	events, unsubscribe := g.Watcher.Subscribe()
+	defer unsubscribe()
+
+	if !r.GetSkipSnapshot() {
+		devices, err := currentDevices(client, all, ids)
+		if err != nil {
+			return gatewayError(err)
+		}
+		for _, d := range devices {
+			if !principal.AllowsDevice(d.DeviceId) {
+				continue
+			}
+			if err := stream.Send(model.ToDeviceResponseProto(d)); err != nil {
+				return err
+			}
+		}
+	}
+	return forward(stream, events, func(event tradfri.StateEvent) *pb.Device {
+		if event.Device == nil || !principal.AllowsDevice(event.Device.DeviceId) || (!all && !slices.Contains(ids, event.Device.DeviceId)) {
+			return nil
+		}
+		return model.ToDeviceResponseProto(*event.Device)
+	})
+}
+
+func (s *server) WatchGroups(r *pb.WatchGroupsRequest, stream grpc.ServerStreamingServer[pb.Group]) error {
+	g, err := s.gateway(stream.Context())
+	if err != nil {
+		return err
+	}
+	if g.Watcher == nil {
+		return status.Error(codes.Unavailable, "state updates are not enabled on this server")
+	}
+	principal := auth.FromContext(stream.Context())
+	ids := toInts(r.GetIds())
+	events, unsubscribe := g.Watcher.Subscribe()
+	defer unsubscribe()
+
+	if !r.GetSkipSnapshot() {
+		groups, err := currentGroups(bindClient(stream.Context(), g.Client), ids)
+		if err != nil {
+			return gatewayError(err)
+		}
+		for _, g := range groups {
+			if !principal.AllowsGroup(g.DeviceId) {
+				continue
+			}
+			if err := stream.Send(model.ToGroupResponseProto(g)); err != nil {
+				return err
+			}
+		}
+	}
+	return forward(stream, events, func(event tradfri.StateEvent) *pb.Group {
+		if event.Group == nil || !principal.AllowsGroup(event.Group.DeviceId) || (len(ids) > 0 && !slices.Contains(ids, event.Group.DeviceId)) {
+			return nil
+		}
+		return model.ToGroupResponseProto(*event.Group)
+	})
+}
+
+// forward sends every event accepted by convert to the stream until the client goes away.
+func forward[T any](stream grpc.ServerStreamingServer[T], events <-chan tradfri.StateEvent, convert func(tradfri.StateEvent) *T) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if msg := convert(event); msg != nil {
+				if err := stream.Send(msg); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func currentDevices(client TradfriClient, all bool, ids []int) ([]model.Device, error) {
+	if all {
+		return client.ListDevices()
+	}
+	devices := make([]model.Device, 0, len(ids))
+	for _, id := range ids {
+		d, err := client.GetDevice(id)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+func currentGroups(client TradfriClient, ids []int) ([]model.Group, error) {
+	if len(ids) == 0 {
+		return client.ListGroups()
+	}
+	groups := make([]model.Group, 0, len(ids))
+	for _, id := range ids {
+		g, err := client.GetGroup(id)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+func toInts(ids []int32) []int {
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int(id))
+	}
+	return res
+}
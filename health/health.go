@@ -0,0 +1,202 @@
+// Package health tracks the connectivity of the gateways, which decides the liveness and readiness reported by
+// the REST and gRPC health endpoints.
+package health
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Session states of the DTLS session with the gateway.
+const (
+	SessionDown   = "down"
+	SessionUp     = "up"
+	SessionClosed = "closed"
+)
+
+// Defaults of the thresholds of a Tracker.
+const (
+	DefaultWindow       = 5 * time.Minute
+	DefaultMaxErrorRate = 0.5
+	DefaultMaxSilence   = 5 * time.Minute
+)
+
+// minCalls is the number of calls in the window below which the error rate does not affect readiness, so
+// that a single failed call does not take the service out of rotation.
+const minCalls = 4
+
+// maxCalls bounds the number of calls kept for the error rate.
+const maxCalls = 256
+
+// Tracker records the state of the DTLS session and the outcome of the calls made to the gateway. It is safe
+// for concurrent use.
+type Tracker struct {
+	// Window is the period over which the error rate is computed.
+	Window time.Duration
+	// MaxErrorRate is the share of failed calls in the window above which the service is not ready.
+	MaxErrorRate float64
+	// MaxSilence is how long calls may fail without any successful round-trip before the service is no longer
+	// live, so that an orchestrator restarts it.
+	MaxSilence time.Duration
+
+	mu          sync.Mutex
+	now         func() time.Time
+	started     time.Time
+	session     string
+	since       time.Time
+	lastUp      time.Time
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	calls       []call
+}
+
+type call struct {
+	at     time.Time
+	failed bool
+}
+
+// NewTracker returns a tracker with the default thresholds and the session down.
+func NewTracker() *Tracker {
+	now := time.Now()
+	return &Tracker{
+		Window:       DefaultWindow,
+		MaxErrorRate: DefaultMaxErrorRate,
+		MaxSilence:   DefaultMaxSilence,
+		now:          time.Now,
+		session:      SessionDown,
+		started:      now,
+		since:        now,
+	}
+}
+
+// SetSession records a change of the state of the DTLS session.
+func (t *Tracker) SetSession(state string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.session == state {
+		return
+	}
+	t.session, t.since = state, t.now()
+	if state == SessionUp {
+		t.lastUp = t.since
+	}
+}
+
+// ObserveCall records a call to the gateway. Only errors of the transport count as failures, the gateway
+// answering with an error code is a successful round-trip.
+func (t *Tracker) ObserveCall(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	if err != nil {
+		t.lastFailure, t.lastError = now, err.Error()
+	} else {
+		t.lastSuccess = now
+	}
+	t.calls = append(t.calls, call{at: now, failed: err != nil})
+	if len(t.calls) > maxCalls {
+		t.calls = t.calls[len(t.calls)-maxCalls:]
+	}
+}
+
+// Report is the health of the gateway connection at a point in time.
+type Report struct {
+	Live  bool `json:"live"`
+	Ready bool `json:"ready"`
+	// Session is the state of the DTLS session, one of "up", "down" and "closed".
+	Session      string     `json:"session"`
+	SessionSince time.Time  `json:"sessionSince"`
+	LastSuccess  *time.Time `json:"lastSuccess,omitempty"`
+	LastFailure  *time.Time `json:"lastFailure,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+	// Calls and Failures count the calls of the window the error rate is computed over.
+	Calls     int     `json:"calls"`
+	Failures  int     `json:"failures"`
+	ErrorRate float64 `json:"errorRate"`
+	// Reasons explains why the service is not live or not ready.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Check evaluates the recorded state. The service is ready while the session is up and the error rate of
+// the window is acceptable, and live unless the session is closed or calls have failed without any
+// successful round-trip for longer than MaxSilence.
+func (t *Tracker) Check() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	r := Report{Live: true, Ready: true, Session: t.session, SessionSince: t.since, LastError: t.lastError}
+	if !t.lastSuccess.IsZero() {
+		r.LastSuccess = &t.lastSuccess
+	}
+	if !t.lastFailure.IsZero() {
+		r.LastFailure = &t.lastFailure
+	}
+	for _, c := range t.calls {
+		if now.Sub(c.at) > t.Window {
+			continue
+		}
+		r.Calls++
+		if c.failed {
+			r.Failures++
+		}
+	}
+	if r.Calls > 0 {
+		r.ErrorRate = float64(r.Failures) / float64(r.Calls)
+	}
+
+	switch t.session {
+	case SessionClosed:
+		r.Live, r.Ready = false, false
+		r.Reasons = append(r.Reasons, "the DTLS session is closed")
+	case SessionDown:
+		r.Ready = false
+		r.Reasons = append(r.Reasons, "the DTLS session is down")
+	}
+	if r.Calls >= minCalls && r.ErrorRate > t.MaxErrorRate {
+		r.Ready = false
+		r.Reasons = append(r.Reasons, fmt.Sprintf("%d of the last %d gateway calls failed", r.Failures, r.Calls))
+	}
+	// a new session counts as a successful round-trip
+	lastGood := t.lastSuccess
+	if t.lastUp.After(lastGood) {
+		lastGood = t.lastUp
+	}
+	if lastGood.IsZero() {
+		lastGood = t.started
+	}
+	if t.lastFailure.After(lastGood) && now.Sub(lastGood) > t.MaxSilence {
+		r.Live, r.Ready = false, false
+		r.Reasons = append(r.Reasons, fmt.Sprintf("no successful gateway round-trip for %s", now.Sub(lastGood).Round(time.Second)))
+	}
+	return r
+}
+
+// Status is the health of a server connected to several gateways.
+type Status struct {
+	Live  bool `json:"live"`
+	Ready bool `json:"ready"`
+	// Gateways holds the report of each gateway by name.
+	Gateways map[string]Report `json:"gateways,omitempty"`
+}
+
+// CheckAll checks the trackers of the gateways by name. The server is ready only while all of its gateways are,
+// so that a gateway that is down is noticed. It is live while any gateway is, since restarting the server does
+// not help a single unreachable gateway. The health of each gateway is in its report, for probes that check
+// them one by one.
+func CheckAll(trackers map[string]*Tracker) Status {
+	s := Status{Live: len(trackers) == 0, Ready: true}
+	if len(trackers) > 0 {
+		s.Gateways = make(map[string]Report, len(trackers))
+	}
+	for _, name := range slices.Sorted(maps.Keys(trackers)) {
+		r := trackers[name].Check()
+		s.Gateways[name] = r
+		s.Live = s.Live || r.Live
+		s.Ready = s.Ready && r.Ready
+	}
+	return s
+}
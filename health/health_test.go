@@ -0,0 +1,103 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestTracker() (*Tracker, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	t := NewTracker()
+	t.now = func() time.Time { return now }
+	t.started = now
+	return t, &now
+}
+
+func TestCheck_SessionUp(t *testing.T) {
+	tracker, _ := newTestTracker()
+	if r := tracker.Check(); !r.Live || r.Ready || r.Session != SessionDown {
+		t.Fatalf("expected live but not ready before the session is up, got %+v", r)
+	}
+	tracker.SetSession(SessionUp)
+	tracker.ObserveCall(nil)
+	if r := tracker.Check(); !r.Live || !r.Ready || r.Calls != 1 || r.LastSuccess == nil {
+		t.Fatalf("expected live and ready, got %+v", r)
+	}
+}
+
+func TestCheck_ErrorRate(t *testing.T) {
+	tracker, now := newTestTracker()
+	tracker.SetSession(SessionUp)
+	tracker.ObserveCall(nil)
+	for i := 0; i < 3; i++ {
+		tracker.ObserveCall(errors.New("gateway did not respond in time"))
+	}
+	r := tracker.Check()
+	if r.Ready || !r.Live || r.Failures != 3 || r.ErrorRate != 0.75 || r.LastError != "gateway did not respond in time" {
+		t.Fatalf("expected not ready with an error rate of 0.75, got %+v", r)
+	}
+
+	// the failures leave the window
+	*now = now.Add(DefaultWindow + time.Second)
+	if r := tracker.Check(); !r.Ready || r.Calls != 0 {
+		t.Fatalf("expected ready once the failures left the window, got %+v", r)
+	}
+}
+
+func TestCheck_FewFailures(t *testing.T) {
+	tracker, _ := newTestTracker()
+	tracker.SetSession(SessionUp)
+	tracker.ObserveCall(errors.New("timeout"))
+	if r := tracker.Check(); !r.Ready {
+		t.Fatalf("expected a single failure to keep the server ready, got %+v", r)
+	}
+}
+
+func TestCheck_Silence(t *testing.T) {
+	tracker, now := newTestTracker()
+	tracker.SetSession(SessionUp)
+	tracker.ObserveCall(nil)
+	*now = now.Add(DefaultMaxSilence + time.Minute)
+	tracker.ObserveCall(errors.New("gateway unavailable"))
+	tracker.SetSession(SessionDown)
+	if r := tracker.Check(); r.Live || r.Ready {
+		t.Fatalf("expected neither live nor ready, got %+v", r)
+	}
+
+	// a new session restores liveness
+	tracker.SetSession(SessionUp)
+	if r := tracker.Check(); !r.Live || !r.Ready {
+		t.Fatalf("expected live and ready after reconnecting, got %+v", r)
+	}
+}
+
+func TestCheck_Closed(t *testing.T) {
+	tracker, _ := newTestTracker()
+	tracker.SetSession(SessionUp)
+	tracker.SetSession(SessionClosed)
+	if r := tracker.Check(); r.Live || r.Ready || r.Session != SessionClosed {
+		t.Fatalf("expected neither live nor ready, got %+v", r)
+	}
+}
+
+func TestCheckAll(t *testing.T) {
+	if s := CheckAll(nil); !s.Live || !s.Ready {
+		t.Fatalf("expected a server without gateways to be healthy, got %+v", s)
+	}
+	house, _ := newTestTracker()
+	house.SetSession(SessionUp)
+	garage, _ := newTestTracker()
+	s := CheckAll(map[string]*Tracker{"house": house, "garage": garage})
+	if !s.Live || s.Ready || !s.Gateways["house"].Ready || s.Gateways["garage"].Ready {
+		t.Fatalf("expected the server not to be ready while the garage is down, got %+v", s)
+	}
+	garage.SetSession(SessionUp)
+	if s := CheckAll(map[string]*Tracker{"house": house, "garage": garage}); !s.Live || !s.Ready {
+		t.Fatalf("expected the server to be ready with both gateways up, got %+v", s)
+	}
+	house.SetSession(SessionClosed)
+	if s := CheckAll(map[string]*Tracker{"house": house, "garage": garage}); !s.Live || s.Ready {
+		t.Fatalf("expected the server to be live but not ready, got %+v", s)
+	}
+}
@@ -0,0 +1,147 @@
+package homeassistant
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/eriklupander/tradfri-go/model"
+)
+
+// entity is a Home Assistant entity, announced by publishing its config to the discovery topic.
+type entity struct {
+	topic  string
+	config map[string]any
+}
+
+// entities returns the entities of a device: a light, switch or cover for bulbs, outlets and blinds, and a
+// battery sensor for battery powered devices. All entities of a device share its state topic.
+func (b *Bridge) entities(d model.Device) []entity {
+	v2 := model.ToDeviceV2(d)
+	id := strconv.Itoa(d.DeviceId)
+	device := map[string]any{
+		"identifiers":  []string{b.opts.NodeID + "_" + id},
+		"name":         d.Name,
+		"manufacturer": v2.Metadata.Vendor,
+		"model":        v2.Metadata.Model,
+		"sw_version":   v2.Metadata.FirmwareVersion,
+	}
+	state := b.stateTopic(d.DeviceId)
+	// controllable entities are unavailable while the bridge is offline or the gateway can't reach them
+	controllable := map[string]any{
+		"availability": []map[string]any{
+			{"topic": b.availabilityTopic()},
+			{"topic": state, "value_template": "{{ value_json.availability }}"},
+		},
+		"availability_mode": "all",
+	}
+
+	var res []entity
+	add := func(component, objectID string, config map[string]any) {
+		config["unique_id"] = b.opts.NodeID + "_" + objectID
+		config["device"] = device
+		if _, ok := config["availability"]; !ok {
+			config["availability_topic"] = b.availabilityTopic()
+		}
+		topic := fmt.Sprintf("%s/%s/%s/%s/config", b.opts.DiscoveryPrefix, component, b.opts.NodeID, objectID)
+		res = append(res, entity{topic: topic, config: config})
+	}
+	command := fmt.Sprintf("%s/%d/set", b.opts.BaseTopic, d.DeviceId)
+	switch v2.Kind {
+	case model.KindLight:
+		// a null name makes the entity take the name of the device
+		add("light", id, with(controllable, map[string]any{
+			"name":                  nil,
+			"schema":                "json",
+			"state_topic":           state,
+			"command_topic":         command,
+			"brightness":            true,
+			"brightness_scale":      254,
+			"supported_color_modes": []string{colorMode(d)},
+		}))
+	case model.KindOutlet:
+		add("switch", id, with(controllable, map[string]any{
+			"name":           nil,
+			"state_topic":    state,
+			"value_template": "{{ value_json.state }}",
+			"command_topic":  command,
+		}))
+	case model.KindBlind:
+		add("cover", id, with(controllable, map[string]any{
+			"name":               nil,
+			"device_class":       "blind",
+			"command_topic":      command,
+			"payload_stop":       nil,
+			"position_topic":     state,
+			"position_template":  "{{ value_json.position }}",
+			"set_position_topic": fmt.Sprintf("%s/%d/set_position", b.opts.BaseTopic, d.DeviceId),
+		}))
+	}
+	if v2.Metadata.BatteryLevel != nil {
+		// remotes sleep most of the time, so the battery level stays available with the bridge
+		add("sensor", id+"_battery", map[string]any{
+			"name":                "Battery",
+			"device_class":        "battery",
+			"state_class":         "measurement",
+			"entity_category":     "diagnostic",
+			"unit_of_measurement": "%",
+			"state_topic":         state,
+			"value_template":      "{{ value_json.battery }}",
+		})
+	}
+	return res
+}
+
+// deviceState returns the state published for a device, holding the attributes of all its entities.
+func deviceState(d model.Device) map[string]any {
+	v2 := model.ToDeviceV2(d)
+	s := map[string]any{"availability": offline}
+	if v2.Metadata.Alive {
+		s["availability"] = online
+	}
+	switch {
+	case len(d.LightControl) > 0:
+		lc := d.LightControl[0]
+		s["state"] = onOff(lc.Power)
+		s["brightness"] = lc.Dimmer
+		s["color_mode"] = colorMode(d)
+		if rgb, err := hex.DecodeString(lc.RGBHex); err == nil && len(rgb) == 3 && colorMode(d) == "rgb" {
+			s["color"] = map[string]int{"r": int(rgb[0]), "g": int(rgb[1]), "b": int(rgb[2])}
+		}
+	case len(d.OutletControl) > 0:
+		s["state"] = onOff(d.OutletControl[0].Power)
+	case len(d.BlindControl) > 0:
+		// Home Assistant counts from closed (0) to open (100), the gateway the other way round
+		s["position"] = 100 - int(math.Round(float64(d.BlindControl[0].Position)))
+	}
+	if v2.Metadata.BatteryLevel != nil {
+		s["battery"] = *v2.Metadata.BatteryLevel
+	}
+	return s
+}
+
+// colorMode returns the Home Assistant color mode of a bulb. Only the color bulbs (CWS) take any RGB color,
+// the others are offered with brightness only.
+func colorMode(d model.Device) string {
+	if strings.Contains(d.Metadata.TypeName, " CWS") {
+		return "rgb"
+	}
+	return "brightness"
+}
+
+func onOff(power int) string {
+	if power == 1 {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// with returns the union of base and config.
+func with(base, config map[string]any) map[string]any {
+	res := maps.Clone(base)
+	maps.Copy(res, config)
+	return res
+}
@@ -0,0 +1,327 @@
+// Package homeassistant bridges the devices of a gateway to Home Assistant over MQTT. It publishes Home
+// Assistant discovery configs for bulbs (light), power outlets (switch), blinds (cover) and the batteries of
+// remotes, sensors and blinds (sensor), publishes their state as it changes and applies the commands Home
+// Assistant sends.
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+const (
+	// DefaultDiscoveryPrefix is the discovery prefix Home Assistant subscribes to unless configured otherwise.
+	DefaultDiscoveryPrefix = "homeassistant"
+	// DefaultBaseTopic starts the state and command topics of the devices.
+	DefaultBaseTopic = "tradfri"
+	// DefaultClientID is the MQTT client ID of the bridge.
+	DefaultClientID = "tradfri-go"
+	// DefaultReconnectInterval is the longest wait between attempts to reconnect to the broker.
+	DefaultReconnectInterval = 30 * time.Second
+)
+
+// Availability payloads of the bridge and the devices.
+const (
+	online  = "online"
+	offline = "offline"
+)
+
+// qos is the MQTT quality of service of all messages, they are all delivered at least once.
+const qos = 1
+
+// publishTimeout bounds how long a publish is waited for, the broker may have gone away meanwhile.
+const publishTimeout = 5 * time.Second
+
+// Client is the part of tradfri.Client used by the bridge.
+type Client interface {
+	ListDevices() ([]model.Device, error)
+	GetDevice(deviceId int) (model.Device, error)
+	PutDevicePower(deviceId int, power int) (model.Result, error)
+	PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error)
+	PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error)
+	PutOutletPower(deviceId int, power int) (model.Result, error)
+	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+}
+
+// Options configures a Bridge.
+type Options struct {
+	// Broker is the URL of the MQTT broker, e.g. "tcp://localhost:1883" or "ssl://broker:8883".
+	Broker   string
+	Username string
+	Password string
+	// ClientID is the MQTT client ID, defaults to DefaultClientID. It must be unique per broker.
+	ClientID string
+	// DiscoveryPrefix is the topic prefix of the discovery configs, defaults to DefaultDiscoveryPrefix.
+	DiscoveryPrefix string
+	// BaseTopic starts the availability, state and command topics, defaults to DefaultBaseTopic.
+	BaseTopic string
+	// NodeID identifies the gateway in the discovery topics and unique IDs of the entities, defaults to
+	// "tradfri". Bridges of several gateways need distinct IDs.
+	NodeID string
+	// ReconnectInterval is the longest wait between attempts to reconnect, defaults to
+	// DefaultReconnectInterval.
+	ReconnectInterval time.Duration
+}
+
+func (o *Options) setDefaults() {
+	if o.ClientID == "" {
+		o.ClientID = DefaultClientID
+	}
+	if o.DiscoveryPrefix == "" {
+		o.DiscoveryPrefix = DefaultDiscoveryPrefix
+	}
+	if o.BaseTopic == "" {
+		o.BaseTopic = DefaultBaseTopic
+	}
+	if o.NodeID == "" {
+		o.NodeID = "tradfri"
+	}
+	if o.ReconnectInterval <= 0 {
+		o.ReconnectInterval = DefaultReconnectInterval
+	}
+}
+
+// Bridge publishes the devices of a gateway to Home Assistant and applies its commands.
+type Bridge struct {
+	client  Client
+	watcher *tradfri.Watcher
+	opts    Options
+	conn    mqtt.Client
+
+	// mu guards announced, the devices whose discovery configs were published on the current connection.
+	mu        sync.Mutex
+	announced map[int]bool
+}
+
+// New creates a bridge for the devices of client, whose state changes are reported by watcher.
+func New(client Client, watcher *tradfri.Watcher, opts Options) *Bridge {
+	opts.setDefaults()
+	return &Bridge{client: client, watcher: watcher, opts: opts, announced: map[int]bool{}}
+}
+
+// Run connects to the broker and bridges until ctx is cancelled. Connection losses are retried until then.
+// The availability of the bridge is published retained, as "online" once connected and as "offline" by the
+// broker on behalf of the bridge if the connection is lost, and by the bridge itself when ctx is cancelled.
+func (b *Bridge) Run(ctx context.Context) error {
+	if b.opts.Broker == "" {
+		return errors.New("homeassistant: the MQTT broker is required")
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(b.opts.Broker).
+		SetClientID(b.opts.ClientID).
+		SetUsername(b.opts.Username).
+		SetPassword(b.opts.Password).
+		SetWill(b.availabilityTopic(), offline, qos, true).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(b.opts.ReconnectInterval).
+		SetMaxReconnectInterval(b.opts.ReconnectInterval).
+		// commands call the gateway, which must not hold up the other messages
+		SetOrderMatters(false).
+		SetOnConnectHandler(b.onConnect).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			slog.Warn("Lost the connection to the MQTT broker, reconnecting", slog.Any("error", err))
+		})
+	b.conn = mqtt.NewClient(opts)
+	// with ConnectRetry the token only completes once connected, onConnect takes over from there
+	b.conn.Connect()
+
+	events, unsubscribe := b.watcher.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			if b.conn.IsConnectionOpen() {
+				b.publish(b.availabilityTopic(), offline)
+			}
+			b.conn.Disconnect(250)
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				b.conn.Disconnect(250)
+				return errors.New("homeassistant: the watcher has stopped")
+			}
+			if event.Device != nil && b.conn.IsConnectionOpen() {
+				b.publishDevice(*event.Device)
+			}
+		}
+	}
+}
+
+// onConnect subscribes the command topics and publishes the availability, the discovery configs and the
+// state of all devices, on the first connection and after every reconnect.
+func (b *Bridge) onConnect(c mqtt.Client) {
+	slog.Info("Connected to the MQTT broker", slog.String("broker", b.opts.Broker))
+	b.mu.Lock()
+	b.announced = map[int]bool{}
+	b.mu.Unlock()
+	filters := map[string]byte{
+		b.opts.BaseTopic + "/+/set":          qos,
+		b.opts.BaseTopic + "/+/set_position": qos,
+		// Home Assistant announces its restarts, after which it needs the configs again
+		b.opts.DiscoveryPrefix + "/status": qos,
+	}
+	if err := wait(c.SubscribeMultiple(filters, b.handleMessage)); err != nil {
+		slog.Error("Unable to subscribe to the command topics", slog.Any("error", err))
+	}
+	b.publish(b.availabilityTopic(), online)
+	b.publishAll()
+}
+
+func (b *Bridge) publishAll() {
+	devices, err := b.client.ListDevices()
+	if err != nil {
+		slog.Error("Unable to list the devices for Home Assistant", slog.Any("error", err))
+	}
+	for _, d := range devices {
+		b.publishDevice(d)
+	}
+}
+
+// publishDevice publishes the state of the device, preceded by its discovery configs unless they were
+// already published.
+func (b *Bridge) publishDevice(d model.Device) {
+	b.mu.Lock()
+	announce := !b.announced[d.DeviceId]
+	b.announced[d.DeviceId] = true
+	b.mu.Unlock()
+	if announce {
+		for _, e := range b.entities(d) {
+			b.publishJSON(e.topic, e.config)
+		}
+	}
+	b.publishJSON(b.stateTopic(d.DeviceId), deviceState(d))
+}
+
+func (b *Bridge) handleMessage(_ mqtt.Client, msg mqtt.Message) {
+	if msg.Topic() == b.opts.DiscoveryPrefix+"/status" {
+		if string(msg.Payload()) == online {
+			slog.Info("Home Assistant has started, publishing the discovery configs")
+			b.mu.Lock()
+			b.announced = map[int]bool{}
+			b.mu.Unlock()
+			b.publishAll()
+		}
+		return
+	}
+	// <base>/<deviceId>/<command>
+	parts := strings.Split(strings.TrimPrefix(msg.Topic(), b.opts.BaseTopic+"/"), "/")
+	if len(parts) != 2 {
+		return
+	}
+	deviceId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return
+	}
+	if err := b.apply(deviceId, parts[1], string(msg.Payload())); err != nil {
+		slog.Warn("Unable to apply the command of Home Assistant", slog.String("topic", msg.Topic()), slog.String("payload", string(msg.Payload())), slog.Any("error", err))
+	}
+	// the new state is published right away, or the old one again if the command failed
+	if d, err := b.client.GetDevice(deviceId); err == nil {
+		b.publishJSON(b.stateTopic(deviceId), deviceState(d))
+	}
+}
+
+// lightCommand is the payload of the JSON schema of MQTT lights.
+type lightCommand struct {
+	State      string `json:"state"`
+	Brightness *int   `json:"brightness"`
+	Color      *struct {
+		R int `json:"r"`
+		G int `json:"g"`
+		B int `json:"b"`
+	} `json:"color"`
+}
+
+// apply applies a command to a device.
+func (b *Bridge) apply(deviceId int, command, payload string) error {
+	d, err := b.client.GetDevice(deviceId)
+	if err != nil {
+		return err
+	}
+	switch kind := model.ToDeviceV2(d).Kind; {
+	case kind == model.KindLight && command == "set":
+		cmd := lightCommand{}
+		if err := json.Unmarshal([]byte(payload), &cmd); err != nil {
+			return err
+		}
+		switch {
+		case cmd.State == "OFF":
+			_, err = b.client.PutDevicePower(deviceId, 0)
+			return err
+		case cmd.Brightness != nil:
+			_, err = b.client.PutDeviceState(deviceId, 1, min(max(*cmd.Brightness, 0), 254))
+		case cmd.State == "ON":
+			_, err = b.client.PutDevicePower(deviceId, 1)
+		}
+		if err == nil && cmd.Color != nil {
+			_, err = b.client.PutDeviceColorRGB(deviceId, fmt.Sprintf("%02x%02x%02x", cmd.Color.R, cmd.Color.G, cmd.Color.B))
+		}
+		return err
+	case kind == model.KindOutlet && command == "set":
+		_, err = b.client.PutOutletPower(deviceId, map[string]int{"ON": 1, "OFF": 0}[payload])
+		return err
+	case kind == model.KindBlind && command == "set":
+		switch payload {
+		case "OPEN":
+			_, err = b.client.PutDevicePositioning(deviceId, 0)
+		case "CLOSE":
+			_, err = b.client.PutDevicePositioning(deviceId, 100)
+		default:
+			return fmt.Errorf("unknown cover command %q", payload)
+		}
+		return err
+	case kind == model.KindBlind && command == "set_position":
+		position, err := strconv.Atoi(payload)
+		if err != nil {
+			return err
+		}
+		// Home Assistant counts from closed (0) to open (100), the gateway the other way round
+		_, err = b.client.PutDevicePositioning(deviceId, float32(100-min(max(position, 0), 100)))
+		return err
+	default:
+		return fmt.Errorf("the %s does not support %q", kind, command)
+	}
+}
+
+func (b *Bridge) availabilityTopic() string {
+	return b.opts.BaseTopic + "/status"
+}
+
+func (b *Bridge) stateTopic(deviceId int) string {
+	return fmt.Sprintf("%s/%d/state", b.opts.BaseTopic, deviceId)
+}
+
+func (b *Bridge) publishJSON(topic string, v any) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("Unable to encode the MQTT message", slog.String("topic", topic), slog.Any("error", err))
+		return
+	}
+	b.publish(topic, string(payload))
+}
+
+// publish publishes a retained message, so that Home Assistant gets the latest one whenever it subscribes.
+func (b *Bridge) publish(topic, payload string) {
+	if err := wait(b.conn.Publish(topic, qos, true, payload)); err != nil {
+		slog.Warn("Unable to publish to the MQTT broker", slog.String("topic", topic), slog.Any("error", err))
+	}
+}
+
+func wait(t mqtt.Token) error {
+	if !t.WaitTimeout(publishTimeout) {
+		return errors.New("timed out")
+	}
+	return t.Error()
+}
@@ -0,0 +1,333 @@
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// fakeClient holds the devices of a gateway and records the commands applied to them.
+type fakeClient struct {
+	mu       sync.Mutex
+	devices  map[int]model.Device
+	commands []string
+}
+
+func newFakeClient(t *testing.T, devices ...string) *fakeClient {
+	c := &fakeClient{devices: map[int]model.Device{}}
+	for _, raw := range devices {
+		d := model.Device{}
+		if err := json.Unmarshal([]byte(raw), &d); err != nil {
+			t.Fatal(err)
+		}
+		c.devices[d.DeviceId] = d
+	}
+	return c
+}
+
+func (c *fakeClient) ListDevices() ([]model.Device, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res := make([]model.Device, 0, len(c.devices))
+	for _, d := range c.devices {
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+func (c *fakeClient) ListGroups() ([]model.Group, error) {
+	return nil, nil
+}
+
+func (c *fakeClient) GetDevice(deviceId int) (model.Device, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.devices[deviceId]
+	if !ok {
+		return d, fmt.Errorf("no device %d", deviceId)
+	}
+	return d, nil
+}
+
+// update changes a device, as if through the IKEA app.
+func (c *fakeClient) update(deviceId int, change func(d *model.Device)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d := c.devices[deviceId]
+	// copy the control slices, the watcher keeps the previous device for comparison
+	d.LightControl = append(d.LightControl[:0:0], d.LightControl...)
+	d.OutletControl = append(d.OutletControl[:0:0], d.OutletControl...)
+	d.BlindControl = append(d.BlindControl[:0:0], d.BlindControl...)
+	change(&d)
+	c.devices[deviceId] = d
+}
+
+func (c *fakeClient) record(command string, deviceId int, change func(d *model.Device)) (model.Result, error) {
+	c.mu.Lock()
+	c.commands = append(c.commands, command)
+	c.mu.Unlock()
+	c.update(deviceId, change)
+	return model.Result{}, nil
+}
+
+func (c *fakeClient) PutDevicePower(deviceId int, power int) (model.Result, error) {
+	return c.record(fmt.Sprintf("power %d %d", deviceId, power), deviceId, func(d *model.Device) {
+		d.LightControl[0].Power = power
+	})
+}
+
+func (c *fakeClient) PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error) {
+	return c.record(fmt.Sprintf("state %d %d %d", deviceId, power, dimmer), deviceId, func(d *model.Device) {
+		d.LightControl[0].Power = power
+		d.LightControl[0].Dimmer = dimmer
+	})
+}
+
+func (c *fakeClient) PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error) {
+	return c.record(fmt.Sprintf("rgb %d %s", deviceId, rgb), deviceId, func(d *model.Device) {
+		d.LightControl[0].RGBHex = rgb
+	})
+}
+
+func (c *fakeClient) PutOutletPower(deviceId int, power int) (model.Result, error) {
+	return c.record(fmt.Sprintf("outlet %d %d", deviceId, power), deviceId, func(d *model.Device) {
+		d.OutletControl[0].Power = power
+	})
+}
+
+func (c *fakeClient) PutDevicePositioning(deviceId int, positioning float32) (model.Result, error) {
+	return c.record(fmt.Sprintf("position %d %g", deviceId, positioning), deviceId, func(d *model.Device) {
+		d.BlindControl[0].Position = positioning
+	})
+}
+
+func (c *fakeClient) recorded() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.commands...)
+}
+
+const (
+	bulb   = `{"3":{"0":"IKEA of Sweden","1":"TRADFRI bulb E27 CWS opal 600lm","3":"2.3.086","6":6},"3311":[{"5706":"f1e0b5","5850":1,"5851":127}],"5750":2,"9001":"Ceiling","9003":65537,"9019":1}`
+	outlet = `{"3":{"0":"IKEA of Sweden","1":"TRADFRI control outlet","6":6},"3312":[{"5850":1}],"5750":3,"9001":"Lamp","9003":65538,"9019":1}`
+	blind  = `{"3":{"0":"IKEA of Sweden","1":"FYRTUR block-out roller blind","6":3,"9":80},"15015":[{"5536":30}],"5750":7,"9001":"Blind","9003":65539,"9019":1}`
+	remote = `{"3":{"0":"IKEA of Sweden","1":"TRADFRI remote control","6":3,"9":60},"5750":0,"9001":"Remote","9003":65540,"9019":0}`
+)
+
+// startBroker starts an MQTT broker on address, "127.0.0.1:0" for any free port, and returns its address.
+func startBroker(t *testing.T, address string) (*server.Server, string) {
+	t.Helper()
+	srv := server.New(&server.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := srv.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	tcp := listeners.NewTCP(listeners.Config{ID: "tcp", Address: address})
+	if err := srv.AddListener(tcp); err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve() }()
+	return srv, tcp.Address()
+}
+
+// message is a message received by a recorder.
+type message struct {
+	payload  string
+	retained bool
+}
+
+// recorder subscribes to all topics of a broker and keeps the messages received per topic.
+type recorder struct {
+	conn     mqtt.Client
+	mu       sync.Mutex
+	messages map[string][]message
+}
+
+func record(t *testing.T, address string) *recorder {
+	t.Helper()
+	r := &recorder{messages: map[string][]message{}}
+	r.conn = mqtt.NewClient(mqtt.NewClientOptions().AddBroker("tcp://" + address).SetClientID(fmt.Sprintf("recorder-%d", time.Now().UnixNano())))
+	if err := wait(r.conn.Connect()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.conn.Disconnect(0) })
+	err := wait(r.conn.Subscribe("#", qos, func(_ mqtt.Client, msg mqtt.Message) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.messages[msg.Topic()] = append(r.messages[msg.Topic()], message{string(msg.Payload()), msg.Retained()})
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// last returns the last message of topic.
+func (r *recorder) last(topic string) (message, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	msgs := r.messages[topic]
+	if len(msgs) == 0 {
+		return message{}, false
+	}
+	return msgs[len(msgs)-1], true
+}
+
+func (r *recorder) count(topic string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.messages[topic])
+}
+
+// await waits for the last message of topic to satisfy match.
+func (r *recorder) await(t *testing.T, topic string, match func(payload string) bool) message {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		msg, ok := r.last(topic)
+		if ok && match(msg.payload) {
+			return msg
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no matching message on %s, last %q", topic, msg.payload)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func equals(want string) func(string) bool {
+	return func(payload string) bool { return payload == want }
+}
+
+// hasJSON returns a match for JSON payloads holding all the attributes of want.
+func hasJSON(want map[string]any) func(string) bool {
+	return func(payload string) bool {
+		got := map[string]any{}
+		if err := json.Unmarshal([]byte(payload), &got); err != nil {
+			return false
+		}
+		for k, v := range want {
+			if fmt.Sprint(got[k]) != fmt.Sprint(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func publish(t *testing.T, r *recorder, topic, payload string) {
+	t.Helper()
+	if err := wait(r.conn.Publish(topic, qos, false, payload)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	srv, address := startBroker(t, "127.0.0.1:0")
+	client := newFakeClient(t, bulb, outlet, blind, remote)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := tradfri.NewWatcher(client, 20*time.Millisecond)
+	go watcher.Run(ctx)
+	bridge := New(client, watcher, Options{Broker: "tcp://" + address, ReconnectInterval: 100 * time.Millisecond})
+	done := make(chan error, 1)
+	go func() { done <- bridge.Run(ctx) }()
+
+	r := record(t, address)
+	r.await(t, "tradfri/status", equals("online"))
+	light := r.await(t, "homeassistant/light/tradfri/65537/config", hasJSON(map[string]any{
+		"schema":        "json",
+		"command_topic": "tradfri/65537/set",
+		"state_topic":   "tradfri/65537/state",
+		"unique_id":     "tradfri_65537",
+	}))
+	if !strings.Contains(light.payload, `"supported_color_modes":["rgb"]`) || !strings.Contains(light.payload, `"model":"TRADFRI bulb E27 CWS opal 600lm"`) {
+		t.Errorf("unexpected light config %s", light.payload)
+	}
+	r.await(t, "homeassistant/switch/tradfri/65538/config", hasJSON(map[string]any{"command_topic": "tradfri/65538/set"}))
+	r.await(t, "homeassistant/cover/tradfri/65539/config", hasJSON(map[string]any{"set_position_topic": "tradfri/65539/set_position"}))
+	r.await(t, "homeassistant/sensor/tradfri/65539_battery/config", hasJSON(map[string]any{"device_class": "battery"}))
+	r.await(t, "homeassistant/sensor/tradfri/65540_battery/config", hasJSON(map[string]any{"entity_category": "diagnostic"}))
+	if r.count("homeassistant/sensor/tradfri/65537_battery/config") != 0 {
+		t.Error("expected no battery sensor for a mains powered bulb")
+	}
+	r.await(t, "tradfri/65537/state", hasJSON(map[string]any{"state": "ON", "brightness": 127, "availability": "online", "color_mode": "rgb"}))
+	r.await(t, "tradfri/65539/state", hasJSON(map[string]any{"position": 70, "battery": 80}))
+	r.await(t, "tradfri/65540/state", hasJSON(map[string]any{"availability": "offline", "battery": 60}))
+
+	// Home Assistant subscribing later gets the retained messages
+	late := record(t, address)
+	if msg := late.await(t, "homeassistant/light/tradfri/65537/config", hasJSON(nil)); !msg.retained {
+		t.Error("expected the discovery config to be retained")
+	}
+	if msg := late.await(t, "tradfri/status", equals("online")); !msg.retained {
+		t.Error("expected the availability to be retained")
+	}
+
+	// commands
+	publish(t, r, "tradfri/65537/set", `{"state":"ON","brightness":100,"color":{"r":255,"g":0,"b":0}}`)
+	r.await(t, "tradfri/65537/state", hasJSON(map[string]any{"brightness": 100, "color": map[string]any{"r": 255, "g": 0, "b": 0}}))
+	publish(t, r, "tradfri/65537/set", `{"state":"OFF"}`)
+	r.await(t, "tradfri/65537/state", hasJSON(map[string]any{"state": "OFF"}))
+	publish(t, r, "tradfri/65538/set", "OFF")
+	r.await(t, "tradfri/65538/state", hasJSON(map[string]any{"state": "OFF"}))
+	publish(t, r, "tradfri/65539/set_position", "25")
+	r.await(t, "tradfri/65539/state", hasJSON(map[string]any{"position": 25}))
+	publish(t, r, "tradfri/65539/set", "CLOSE")
+	r.await(t, "tradfri/65539/state", hasJSON(map[string]any{"position": 0}))
+	// commands a device doesn't support are ignored
+	publish(t, r, "tradfri/65540/set", "ON")
+	publish(t, r, "tradfri/65538/set_position", "10")
+	want := []string{"state 65537 1 100", "rgb 65537 ff0000", "power 65537 0", "outlet 65538 0", "position 65539 75", "position 65539 100"}
+	if got := client.recorded(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected the commands %v, got %v", want, got)
+	}
+
+	// changes made elsewhere are published by way of the watcher
+	client.update(65538, func(d *model.Device) { d.OutletControl[0].Power = 1 })
+	r.await(t, "tradfri/65538/state", hasJSON(map[string]any{"state": "ON"}))
+
+	// a restart of Home Assistant makes the bridge publish the discovery configs again
+	configs := r.count("homeassistant/switch/tradfri/65538/config")
+	publish(t, r, "homeassistant/status", "online")
+	deadline := time.Now().Add(5 * time.Second)
+	for r.count("homeassistant/switch/tradfri/65538/config") == configs {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the discovery configs to be published again")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the bridge reconnects to a restarted broker and publishes everything again
+	_ = srv.Close()
+	_, address = startBroker(t, address)
+	r = record(t, address)
+	r.await(t, "tradfri/status", equals("online"))
+	r.await(t, "homeassistant/light/tradfri/65537/config", hasJSON(map[string]any{"schema": "json"}))
+	r.await(t, "tradfri/65538/state", hasJSON(map[string]any{"state": "ON"}))
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	r.await(t, "tradfri/status", equals("offline"))
+}
+
+func TestBridge_RequiresBroker(t *testing.T) {
+	client := newFakeClient(t)
+	if err := New(client, tradfri.NewWatcher(client, time.Second), Options{}).Run(context.Background()); err == nil {
+		t.Fatal("expected an error without a broker")
+	}
+}
@@ -1,25 +1,55 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/eriklupander/dtls"
 
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/cli"
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/gateway"
 	"github.com/eriklupander/tradfri-go/grpc_server"
 	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/eriklupander/tradfri-go/homeassistant"
+	"github.com/eriklupander/tradfri-go/metrics"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/onboarding"
 	"github.com/eriklupander/tradfri-go/router"
+	"github.com/eriklupander/tradfri-go/secrets"
+	"github.com/eriklupander/tradfri-go/tlsutil"
+	"github.com/eriklupander/tradfri-go/tracing"
 	"github.com/eriklupander/tradfri-go/tradfri"
+	"github.com/eriklupander/tradfri-go/tui"
+	"github.com/eriklupander/tradfri-go/webui"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -32,16 +62,47 @@ func init() {
 	configFlags.String("gateway_address", "", "Address to your gateway. Including port here!")
 	configFlags.String("psk", "", "Pre-shared key on bottom of Gateway")
 	configFlags.String("client_id", "", "Your client id, make something up or use the NNN-NNN-NNN on the bottom of your Gateway")
+	configFlags.Bool("tls", false, "Serve the REST and gRPC APIs over TLS")
+	configFlags.String("tls_cert", "tls.crt", "PEM certificate file used with --tls. Generated self-signed together with --tls_key if both are missing.")
+	configFlags.String("tls_key", "tls.key", "PEM private key file used with --tls")
+	configFlags.String("tls_client_ca", "", "PEM file with the CAs of accepted client certificates. Enables mutual TLS.")
+	configFlags.String("secrets_file", "", "Encrypted file with the PSKs and API keys, managed with the \"secrets\" commands. Its passphrase is read from "+secrets.PassphraseEnv+" unless --secrets_key_file is set.")
+	configFlags.String("secrets_key_file", "", "Key file encrypting --secrets_file instead of a passphrase, created by \"secrets keygen\"")
+	configFlags.Bool("secrets_env_only", false, "Read the PSKs and API keys only from TRADFRI_* environment variables and refuse them in the config file, e.g. in containers")
+	configFlags.String("mqtt_broker", "", "URL of the MQTT broker the devices are bridged to Home Assistant over, e.g. tcp://localhost:1883. Bridging is disabled without it.")
+	configFlags.String("mqtt_username", "", "Username of the MQTT broker")
+	configFlags.String("mqtt_password", "", "Password of the MQTT broker")
+	configFlags.String("mqtt_client_id", homeassistant.DefaultClientID, "MQTT client ID of the bridge, suffixed with the gateway name if there are several gateways")
+	configFlags.String("mqtt_discovery_prefix", homeassistant.DefaultDiscoveryPrefix, "Home Assistant MQTT discovery prefix")
+	configFlags.String("mqtt_base_topic", homeassistant.DefaultBaseTopic, "Topic prefix of the availability, state and command topics of the bridge, suffixed with the gateway name if there are several gateways")
 	configFlags.String("loglevel", "info", "Log level. Allowed values: fatal, error, warn, info, debug, trace")
 
 	commandFlags.Bool("server", false, "Start in server mode?")
 	commandFlags.Bool("authenticate", false, "Perform PSK exchange?")
+	commandFlags.String("config_file", "", "Config file to read instead of config.json or config.yaml in the working directory. --authenticate writes to it, config.json by default.")
 	commandFlags.String("get", "", "URL to GET")
 	commandFlags.String("put", "", "URL to PUT")
 	commandFlags.String("payload", "", "Payload for PUT")
+	commandFlags.String("gateway", "", "Name of the gateway of the \"gateways\" config used by the commands, --get, --put and tui. Defaults to the first one.")
+	commandFlags.Bool("readable", false, "Print --get and --put responses as indented JSON with readable keys and accept readable keys in --payload, e.g. \"power\" instead of \"5850\"")
+	commandFlags.String("output", cli.OutputTable, "Output format of the commands. Allowed values: table, json, yaml, template")
+	commandFlags.String("template", "", "Go template used by --output template, executed with the JSON result, e.g. '{{range .}}{{.name}}{{\"\\n\"}}{{end}}'")
+	commandFlags.Duration("transition", 500*time.Millisecond, "Transition time of the \"light color\" command")
+	commandFlags.Duration("discovery_timeout", discovery.DefaultTimeout, "How long the \"discover\" command and --authenticate without a gateway address wait for gateways to answer")
+	commandFlags.Bool("webui", true, "Serve the web UI at /ui/ of the REST server.")
+	commandFlags.Bool("setup", false, "Without gateway credentials, make --server serve only the gateway setup of the web UI until a client is registered, instead of exiting. Anyone who can reach the port can run it.")
 	commandFlags.String("listen_host", "", "Host to listen on. Default empty allows connections from anywhere. Use \"127.0.0.1\" to only allow local connections.")
 	commandFlags.Int("port", 8080, "Port of the REST server. Set to 0 to disable REST server.")
 	commandFlags.Int("grpc_port", 8081, "Port of the gRPC server. Set to 0 to disable gRPC server.")
+	commandFlags.String("trace_exporter", tracing.ExporterNone, "OpenTelemetry trace exporter. Allowed values: none, otlp, file")
+	commandFlags.String("trace_endpoint", "", "host:port of the OTLP/gRPC collector receiving traces. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.")
+	commandFlags.String("trace_file", "traces.json", "File the file trace exporter appends spans to.")
+	commandFlags.Duration("shutdown_timeout", 10*time.Second, "How long in-flight requests are drained when shutting down server mode.")
+	commandFlags.Duration("watch_interval", 5*time.Second, "How often the gateway is polled for state changes while there are subscribers.")
+	commandFlags.Duration("metrics_device_ttl", 30*time.Second, "How long the device gauges read from a gateway are reused by later scrapes, best close to the scrape interval.")
+	commandFlags.Duration("health_window", health.DefaultWindow, "Period over which the error rate of gateway calls is computed for readiness.")
+	commandFlags.Float64("health_max_error_rate", health.DefaultMaxErrorRate, "Share of failed calls to a gateway in --health_window above which the gateway is not ready.")
+	commandFlags.Duration("health_max_silence", health.DefaultMaxSilence, "How long calls to a gateway may fail without a successful round-trip before the gateway is no longer live.")
 
 	commandFlags.AddFlagSet(configFlags)
 	_ = commandFlags.Parse(os.Args[1:])
@@ -49,6 +110,9 @@ func init() {
 	_ = viper.BindPFlags(configFlags)
 	viper.AutomaticEnv()
 	viper.AddConfigPath(".") // e.g. reads ./config.json or config.yaml
+	if configFile, _ := commandFlags.GetString("config_file"); configFile != "" {
+		viper.SetConfigFile(configFile)
+	}
 	err := viper.ReadInConfig()
 	if err != nil {
 		slog.Info(err.Error())
@@ -58,13 +122,25 @@ func init() {
 }
 
 func main() {
+	serverMode, _ := commandFlags.GetBool("server")
+	// commands print their result to stdout, so they log to stderr and only warnings unless asked otherwise
+	commandMode := !serverMode && commandFlags.NArg() > 0
+
 	// configure logging
 	levelStr := viper.GetString("loglevel")
-	if levelStr == "" {
+	if levelStr == "" || commandMode && !commandFlags.Changed("loglevel") && !viper.InConfig("loglevel") {
 		levelStr = "info"
+		if commandMode {
+			levelStr = "warn"
+		}
 	}
-	fmt.Printf("Using loglevel: %v\n", levelStr)
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logOut := os.Stdout
+	if commandMode {
+		logOut = os.Stderr
+	} else {
+		fmt.Printf("Using loglevel: %v\n", levelStr)
+	}
+	logger := slog.New(slog.NewJSONHandler(logOut, &slog.HandlerOptions{Level: parseLevel(levelStr)}))
 	slog.SetLogLoggerLevel(parseLevel(levelStr))
 	slog.SetDefault(logger)
 	dtls.SetLogFunc(func(ts time.Time, level string, peer string, msg string) {
@@ -87,64 +163,137 @@ func main() {
 	}
 	psk := viper.GetString("psk")
 	clientID := viper.GetString("client_id")
-	serverMode, _ := commandFlags.GetBool("server")
 	authenticate, _ := commandFlags.GetBool("authenticate")
 	get, getErr := commandFlags.GetString("get")
 	put, putErr := commandFlags.GetString("put")
 	payload, _ := commandFlags.GetString("payload")
-	listenHost, _ := commandFlags.GetString("listen_host")
-	port, _ := commandFlags.GetInt("port")
-	grpcPort, _ := commandFlags.GetInt("grpc_port")
+	readable, _ := commandFlags.GetBool("readable")
 
 	// Handle the special authenticate use-case
 	if authenticate {
+		if viper.GetString("gateway_address") == "" && viper.GetString("gateway_ip") == "" {
+			gatewayAddress = discoverGateway()
+		}
 		performTokenExchange(gatewayAddress, clientID, psk)
 		return
 	}
+	if commandMode && commandFlags.Arg(0) == "secrets" {
+		os.Exit(runSecrets(commandFlags.Args()[1:]))
+	}
 
-	checkRequiredConfig(gatewayAddress, clientID, psk)
+	secretSource := loadSecrets()
+	psk = secretValue(secretSource, secrets.PSK, psk)
+	configs := loadGateways(gatewayAddress, clientID, psk, secretSource)
+	if !serverMode {
+		selected := selectGateway(configs)
+		gatewayAddress, clientID, psk = selected.Address(), selected.ClientID, selected.PSK
+	}
+
+	if commandMode && commandFlags.Arg(0) == "tui" {
+		os.Exit(runTUI(gatewayAddress, clientID, psk))
+	}
+	if commandMode {
+		os.Exit(runCommand(gatewayAddress, clientID, psk))
+	}
+
+	setup := serverMode && !viper.IsSet("gateways") && (clientID == "" || psk == "") && webUISetupAvailable()
+	if !setup && (!serverMode || !viper.IsSet("gateways")) {
+		checkRequiredConfig(gatewayAddress, clientID, psk)
+	}
 
 	// Check running mode...
 	if serverMode {
 		slog.Info("Running in server mode")
-
-		tc := tradfri.NewTradfriClient(gatewayAddress, clientID, psk)
-		wg := sync.WaitGroup{}
-		// REST
-		if port > 0 {
-			wg.Add(1)
-			slog.Info("REST server", slog.String("host", listenHost), slog.Int("port", port))
-			go func() {
-				defer wg.Done()
-				router.SetupChi(tc, fmt.Sprintf("%s:%d", listenHost, port))
-			}()
-		}
-		// gRPC
-		if grpcPort > 0 {
-			wg.Add(1)
-			slog.Info("gRPC server", slog.String("host", listenHost), slog.Int("port", grpcPort))
-			go func() {
-				defer wg.Done()
-				go registerGrpcServer(tc, fmt.Sprintf("%s:%d", listenHost, grpcPort))
-			}()
-		}
-
-		wg.Wait()
+		// built once, so that the setup and the server share the certificate reloader
+		listenHost, _ := commandFlags.GetString("listen_host")
+		tlsConfig := loadTLSConfig(listenHost)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		go func() {
+			// restore the default behaviour so that a second signal terminates immediately
+			<-ctx.Done()
+			stop()
+		}()
+		if setup {
+			config, err := runSetup(ctx, tlsConfig)
+			if err != nil {
+				slog.Error("Setup failed", slog.Any("error", err))
+				os.Exit(1)
+			}
+			if ctx.Err() != nil {
+				slog.Info("Shut down")
+				return
+			}
+			configs = []gateway.Config{config}
+		}
+		if err := runServer(ctx, configs, secretSource, tlsConfig); err != nil {
+			slog.Error("Server mode failed", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.Info("Shut down")
 	} else {
 		// client mode
 		if getErr == nil && get != "" {
 			resp, _ := tradfri.NewTradfriClient(gatewayAddress, clientID, psk).Get(get)
-			slog.Info(string(resp.Payload))
+			printPayload(resp.Payload, readable)
 		} else if putErr == nil && put != "" {
+			if readable {
+				numeric, err := model.NumericKeys([]byte(payload))
+				if err != nil {
+					fail("Unable to parse --payload: " + err.Error())
+				}
+				payload = string(numeric)
+			}
 			resp, _ := tradfri.NewTradfriClient(gatewayAddress, clientID, psk).Put(put, payload)
-			slog.Info(string(resp.Payload))
+			printPayload(resp.Payload, readable)
 		} else {
 			slog.Info("No client operation was specified, supported one(s) are: get, put, authenticate")
+			fmt.Print(cli.Usage())
+			fmt.Printf("  %-42s %s\n", "tui", "Show a live dashboard of all groups and devices")
+			fmt.Printf("  %-42s %s\n", "secrets keygen|list|set <name>|rotate", "Manage the encrypted secrets file")
 		}
 	}
 
 }
 
+// runCommand runs the command given as arguments, e.g. "devices list", and returns the exit code.
+func runCommand(gatewayAddress, clientID, psk string) int {
+	output, _ := commandFlags.GetString("output")
+	tmpl, _ := commandFlags.GetString("template")
+	transition, _ := commandFlags.GetDuration("transition")
+	discoveryTimeout, _ := commandFlags.GetDuration("discovery_timeout")
+	newClient := func() (cli.Client, error) {
+		checkRequiredConfig(gatewayAddress, clientID, psk)
+		return tradfri.DialTradfriClient(gatewayAddress, clientID, psk)
+	}
+	err := cli.Run(newClient, commandFlags.Args(), os.Stdout, cli.Options{Output: output, Template: tmpl, Transition: transition, DiscoveryTimeout: discoveryTimeout})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	return cli.ExitCode(err)
+}
+
+// runTUI shows the dashboard and returns the exit code.
+func runTUI(gatewayAddress, clientID, psk string) int {
+	checkRequiredConfig(gatewayAddress, clientID, psk)
+	tc, err := tradfri.DialTradfriClient(gatewayAddress, clientID, psk)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return cli.ExitCode(err)
+	}
+	defer tc.Close()
+	// the dashboard owns the terminal, errors are shown in its status line instead
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	interval, _ := commandFlags.GetDuration("watch_interval")
+	if err := tui.Run(ctx, tc, interval); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return cli.ExitError
+	}
+	return cli.ExitOK
+}
+
 func parseLevel(str string) slog.Level {
 	switch strings.ToLower(str) {
 	case "debug":
@@ -160,6 +309,163 @@ func parseLevel(str string) slog.Level {
 	}
 }
 
+// loadGateways returns the gateways of the "gateways" config section, or the gateway of the top-level
+// gateway_address, client_id and psk settings if there is no such section. PSKs found in src replace the
+// configured ones.
+func loadGateways(gatewayAddress, clientID, psk string, src secrets.Source) []gateway.Config {
+	if !viper.IsSet("gateways") {
+		return []gateway.Config{{Name: gateway.DefaultName, GatewayAddress: gatewayAddress, ClientID: clientID, PSK: psk}}
+	}
+	var configs []gateway.Config
+	if err := viper.UnmarshalKey("gateways", &configs); err != nil {
+		fail("Unable to parse gateways: " + err.Error())
+	}
+	for i := range configs {
+		configs[i].PSK = secretValue(src, secrets.GatewayPSK(configs[i].Name), configs[i].PSK)
+	}
+	if err := gateway.Validate(configs); err != nil {
+		fail(err.Error())
+	}
+	return configs
+}
+
+// selectGateway returns the gateway chosen by --gateway for the client modes.
+func selectGateway(configs []gateway.Config) gateway.Config {
+	name, _ := commandFlags.GetString("gateway")
+	selected, err := gateway.Find(configs, name)
+	if err != nil {
+		fail(err.Error())
+	}
+	return selected
+}
+
+// runServer runs the REST and gRPC servers until ctx is cancelled or one of them fails. On the way out the
+// servers drain their requests, the watchers end all subscriptions, the DTLS sessions with the gateways are
+// closed and pending spans are flushed. The first error of any component is returned.
+func runServer(ctx context.Context, configs []gateway.Config, src secrets.Source, tlsConfig *tls.Config) error {
+	listenHost, _ := commandFlags.GetString("listen_host")
+	port, _ := commandFlags.GetInt("port")
+	grpcPort, _ := commandFlags.GetInt("grpc_port")
+	watchInterval, _ := commandFlags.GetDuration("watch_interval")
+	shutdownTimeout, _ := commandFlags.GetDuration("shutdown_timeout")
+	traceExporter, _ := commandFlags.GetString("trace_exporter")
+	traceEndpoint, _ := commandFlags.GetString("trace_endpoint")
+	traceFile, _ := commandFlags.GetString("trace_file")
+	healthWindow, _ := commandFlags.GetDuration("health_window")
+	healthMaxErrorRate, _ := commandFlags.GetFloat64("health_max_error_rate")
+	healthMaxSilence, _ := commandFlags.GetDuration("health_max_silence")
+	metricsDeviceTTL, _ := commandFlags.GetDuration("metrics_device_ttl")
+
+	authenticator := loadAuthenticator(src, len(configs))
+	var ui http.Handler
+	if enabled, _ := commandFlags.GetBool("webui"); enabled {
+		ui = webui.Handler()
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, traceExporter, traceEndpoint, traceFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Warn("unable to flush traces", slog.Any("error", err))
+		}
+	}()
+
+	registry, err := gateway.Open(configs, gateway.Options{
+		WatchInterval: watchInterval,
+		NewTracker: func() *health.Tracker {
+			t := health.NewTracker()
+			t.Window, t.MaxErrorRate, t.MaxSilence = healthWindow, healthMaxErrorRate, healthMaxSilence
+			return t
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := registry.Close(); err != nil {
+			slog.Warn("unable to close the DTLS sessions", slog.Any("error", err))
+		}
+	}()
+	registerDeviceMetrics(registry.All(), metricsDeviceTTL)
+	def := registry.Default()
+	var restGateways []router.Gateway
+	var grpcGateways []grpc_server.Gateway
+	for _, gw := range registry.All() {
+		slog.Info("Serving gateway", slog.String("name", gw.Name))
+		restGateways = append(restGateways, router.Gateway{Name: gw.Name, Client: gw.Client, Watcher: gw.Watcher, Health: gw.Health})
+		grpcGateways = append(grpcGateways, grpc_server.Gateway{Name: gw.Name, Client: gw.Client, Watcher: gw.Watcher})
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		registry.Run(ctx)
+		return nil
+	})
+	if port > 0 {
+		slog.Info("REST server", slog.String("host", listenHost), slog.Int("port", port))
+		g.Go(func() error {
+			return router.SetupChi(ctx, def.Client, fmt.Sprintf("%s:%d", listenHost, port), router.Options{
+				Watcher:         def.Watcher,
+				Gateways:        restGateways,
+				Authenticator:   authenticator,
+				Connect:         grpc_server.NewGatewaysConnectHandler(grpcGateways, authenticator),
+				UI:              ui,
+				TLSConfig:       tlsConfig,
+				ShutdownTimeout: shutdownTimeout,
+			})
+		})
+	}
+	if grpcPort > 0 {
+		slog.Info("gRPC server", slog.String("host", listenHost), slog.Int("port", grpcPort))
+		g.Go(func() error {
+			return serveGrpc(ctx, grpcGateways, registry.Health(), authenticator, tlsConfig, fmt.Sprintf("%s:%d", listenHost, grpcPort), shutdownTimeout)
+		})
+	}
+	if broker := viper.GetString("mqtt_broker"); broker != "" {
+		password := secretValue(src, secrets.MQTTPassword, viper.GetString("mqtt_password"))
+		for _, gw := range registry.All() {
+			opts := homeassistant.Options{
+				Broker:          broker,
+				Username:        viper.GetString("mqtt_username"),
+				Password:        password,
+				ClientID:        viper.GetString("mqtt_client_id"),
+				DiscoveryPrefix: viper.GetString("mqtt_discovery_prefix"),
+				BaseTopic:       viper.GetString("mqtt_base_topic"),
+			}
+			// the gateways share the broker, so their topics, entities and clients need to be told apart
+			if len(registry.All()) > 1 {
+				opts.ClientID += "-" + gw.Name
+				opts.BaseTopic += "/" + gw.Name
+				opts.NodeID = "tradfri_" + gw.Name
+			}
+			slog.Info("Home Assistant MQTT bridge", slog.String("gateway", gw.Name), slog.String("broker", broker), slog.String("base_topic", opts.BaseTopic))
+			bridge := homeassistant.New(gw.Client, gw.Watcher, opts)
+			g.Go(func() error {
+				return bridge.Run(ctx)
+			})
+		}
+	}
+	return g.Wait()
+}
+
+// printPayload logs a gateway response payload, or prints it as indented JSON with readable keys.
+func printPayload(payload []byte, readable bool) {
+	if !readable {
+		slog.Info(string(payload))
+		return
+	}
+	out := bytes.Buffer{}
+	if translated, err := model.ReadableKeys(payload); err == nil && json.Indent(&out, translated, "", "  ") == nil {
+		fmt.Println(out.String())
+		return
+	}
+	fmt.Println(string(payload))
+}
+
 func checkRequiredConfig(gatewayAddress, clientID, psk string) {
 	if gatewayAddress == "" {
 		fail("Unable to resolve gatewayAddress from command-line flag or config.json file")
@@ -172,11 +478,31 @@ func checkRequiredConfig(gatewayAddress, clientID, psk string) {
 	}
 }
 
-func performTokenExchange(gatewayAddress, clientID, psk string) {
-	if len(clientID) < 1 || len(psk) < 10 {
-		fail("Both clientID and psk args must be specified when performing key exchange")
+// discoverGateway returns the address of the only gateway on the local network, failing if there is none or
+// more than one.
+func discoverGateway() string {
+	timeout, _ := commandFlags.GetDuration("discovery_timeout")
+	slog.Info("No gateway_address or gateway_ip configured, looking for the gateway on the local network", slog.Duration("timeout", timeout))
+	gateways, err := discovery.Discover(context.Background(), discovery.Options{Timeout: timeout})
+	if err != nil {
+		fail("Unable to discover the gateway: " + err.Error())
+	}
+	switch len(gateways) {
+	case 0:
+		fail("No gateway found on the local network, pass --gateway_ip")
+	case 1:
+		slog.Info("Found gateway", slog.String("id", gateways[0].ID), slog.String("address", gateways[0].Address()))
+		return gateways[0].Address()
+	}
+	found := make([]string, 0, len(gateways))
+	for _, g := range gateways {
+		found = append(found, g.ID+" at "+g.Address())
 	}
+	fail("Found several gateways, pass the one to use with --gateway_ip: " + strings.Join(found, ", "))
+	return ""
+}
 
+func performTokenExchange(gatewayAddress, clientID, psk string) {
 	done := make(chan bool)
 	defer func() { done <- true }()
 	go func() {
@@ -187,47 +513,473 @@ func performTokenExchange(gatewayAddress, clientID, psk string) {
 		}
 	}()
 
-	// Note that we hard-code "Client_identity" here before creating the DTLS client,
-	// required when performing token exchange
-	dtlsClient := tradfri.NewTradfriClient(gatewayAddress, "Client_identity", psk)
-
-	authToken, err := dtlsClient.AuthExchange(clientID)
+	creds, err := onboarding.Run(context.Background(), onboarding.Options{GatewayAddress: gatewayAddress, ClientID: clientID, SecurityCode: psk})
 	if err != nil {
+		fail("PSK exchange failed: " + err.Error())
+	}
+	if err := storeCredentials(creds); err != nil {
 		fail(err.Error())
 	}
-	viper.Set("client_id", clientID)
-	viper.Set("gateway_address", gatewayAddress)
-	viper.Set("psk", authToken.Token)
-	err = viper.WriteConfigAs("config.json")
+}
+
+// storeCredentials writes the settings of a registered client to the config file. The PSK goes to the
+// secrets file if one is configured, or is only printed with secrets_env_only.
+func storeCredentials(creds onboarding.Credentials) error {
+	viper.Set("client_id", creds.ClientID)
+	viper.Set("gateway_address", creds.GatewayAddress)
+	stored := "config file"
+	switch {
+	case viper.GetBool("secrets_env_only"):
+		// the PSK is only printed, the container gets it through its environment
+		viper.Set("psk", "")
+		stored = "environment variable"
+		fmt.Printf("%s=%s\n", secrets.EnvName(secrets.PSK), creds.PSK)
+	case viper.GetString("secrets_file") != "":
+		viper.Set("psk", "")
+		stored = "secrets file"
+		if err := setSecret(secrets.PSK, creds.PSK); err != nil {
+			return fmt.Errorf("unable to write the secrets file: %w", err)
+		}
+	default:
+		viper.Set("psk", creds.PSK)
+	}
+	path, _ := commandFlags.GetString("config_file")
+	if path == "" {
+		path = "config.json"
+	}
+	if err := writeConfig(path); err != nil {
+		return fmt.Errorf("unable to write the configuration: %w", err)
+	}
+	slog.Info("Your configuration including the new PSK and clientID has been written, keep this file safe!",
+		slog.String("file", path), slog.String("psk_in", stored), slog.String("client_id", creds.ClientID), slog.String("firmware", creds.FirmwareVersion))
+	return nil
+}
+
+// webUISetupAvailable reports whether the web UI sets up the gateway, which must be asked for with --setup and
+// needs the REST server and a place to store the PSK.
+func webUISetupAvailable() bool {
+	if setup, _ := commandFlags.GetBool("setup"); !setup {
+		return false
+	}
+	enabled, _ := commandFlags.GetBool("webui")
+	port, _ := commandFlags.GetInt("port")
+	return enabled && port > 0 && !viper.GetBool("secrets_env_only")
+}
+
+// runSetup serves the gateway setup of the web UI until a client is registered, whose credentials are then
+// stored like --authenticate does and returned, or until ctx is cancelled.
+func runSetup(ctx context.Context, tlsConfig *tls.Config) (gateway.Config, error) {
+	listenHost, _ := commandFlags.GetString("listen_host")
+	port, _ := commandFlags.GetInt("port")
+	discoveryTimeout, _ := commandFlags.GetDuration("discovery_timeout")
+	shutdownTimeout, _ := commandFlags.GetDuration("shutdown_timeout")
+
+	registeredCtx, registered := context.WithCancel(ctx)
+	defer registered()
+	var config gateway.Config
+	handler := webui.SetupHandler(webui.SetupOptions{DiscoveryTimeout: discoveryTimeout, Registered: func(creds onboarding.Credentials) error {
+		if err := storeCredentials(creds); err != nil {
+			return err
+		}
+		config = gateway.Config{Name: gateway.DefaultName, GatewayAddress: creds.GatewayAddress, ClientID: creds.ClientID, PSK: creds.PSK}
+		registered()
+		return nil
+	}})
+	srv := &http.Server{Addr: fmt.Sprintf("%s:%d", listenHost, port), Handler: handler, TLSConfig: tlsConfig}
+	slog.Warn("No gateway credentials configured, open the web UI to set up the gateway", slog.String("host", listenHost), slog.Int("port", port), slog.String("path", "/ui/"))
+
+	errs := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			errs <- srv.ListenAndServeTLS("", "")
+		} else {
+			errs <- srv.ListenAndServe()
+		}
+	}()
+	select {
+	case err := <-errs:
+		return gateway.Config{}, fmt.Errorf("setup server: %w", err)
+	case <-registeredCtx.Done():
+	}
+
+	// the response to the registration is still being written
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+	}
+	return config, nil
+}
+
+// writeConfig writes the settings to path, readable by the owner only as they include the PSK.
+func writeConfig(path string) error {
+	viper.SetConfigPermissions(0o600)
+	// an existing file keeps its permissions when overwritten, so they are restricted before writing the PSK
+	if err := os.Chmod(path, 0o600); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return viper.WriteConfigAs(path)
+}
+
+// loadSecrets returns where the PSKs and API keys are looked up instead of the configuration: the environment
+// with secrets_env_only, the decrypted secrets_file, or nil if neither is configured.
+func loadSecrets() secrets.Source {
+	path := viper.GetString("secrets_file")
+	switch {
+	case viper.GetBool("secrets_env_only"):
+		if path != "" {
+			fail("secrets_env_only and secrets_file can't be combined")
+		}
+		if names := configuredSecrets(); len(names) > 0 {
+			fail("secrets_env_only is set but the config file contains " + strings.Join(names, ", ") + ", move them to environment variables")
+		}
+		return secrets.Env{}
+	case path == "":
+		return nil
+	}
+	store, err := secrets.Load(path, secretsKey())
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("The secrets file does not exist yet, add secrets with \"secrets set\"", slog.String("file", path))
+		return secrets.Store{}
+	}
 	if err != nil {
-		fail(err.Error())
+		fail("Unable to read the secrets file: " + err.Error())
+	}
+	slog.Info("Read secrets file", slog.String("file", path), slog.Int("secrets", len(store)))
+	return store
+}
+
+// configuredSecrets returns the names of the PSKs and API keys set in the config file itself, leaving out
+// flags and environment variables.
+func configuredSecrets() []string {
+	if viper.ConfigFileUsed() == "" {
+		return nil
+	}
+	v := viper.New()
+	v.SetConfigFile(viper.ConfigFileUsed())
+	if err := v.ReadInConfig(); err != nil {
+		return nil
+	}
+	var names []string
+	if v.GetString("psk") != "" || v.GetString("pre_shared_key") != "" {
+		names = append(names, secrets.PSK)
+	}
+	var gateways []gateway.Config
+	_ = v.UnmarshalKey("gateways", &gateways)
+	for _, g := range gateways {
+		if g.PSK != "" {
+			names = append(names, secrets.GatewayPSK(g.Name))
+		}
+	}
+	var keys []auth.Key
+	_ = v.UnmarshalKey("api_keys", &keys)
+	for _, k := range keys {
+		if k.Key != "" {
+			names = append(names, secrets.APIKey(k.Name))
+		}
+	}
+	if v.GetString("mqtt_password") != "" {
+		names = append(names, secrets.MQTTPassword)
+	}
+	return names
+}
+
+// secretValue returns the named secret from src, or value if src is nil or doesn't have it.
+func secretValue(src secrets.Source, name, value string) string {
+	if src == nil {
+		return value
+	}
+	if v, ok := src.Lookup(name); ok {
+		return v
 	}
-	slog.Info("Your configuration including the new PSK and clientID has been written to config.json, keep this file safe!")
+	return value
 }
 
-func registerGrpcServer(tc *tradfri.Client, listenAddress string) {
+// secretsKey returns the key of secrets_file: the secrets_key_file if configured, otherwise the passphrase. It is
+// only read once, as the passphrase may be asked for.
+var secretsKey = sync.OnceValue(func() secrets.Key {
+	if keyFile := viper.GetString("secrets_key_file"); keyFile != "" {
+		k, err := secrets.ReadKeyFile(keyFile)
+		if err != nil {
+			fail("Unable to read the secrets key file: " + err.Error())
+		}
+		return k
+	}
+	passphrase, err := readPassphrase(secrets.PassphraseEnv, "Passphrase of the secrets file: ")
+	if err != nil {
+		fail("Unable to read the passphrase of the secrets file: " + err.Error())
+	}
+	return secrets.Passphrase(passphrase)
+})
+
+// readPassphrase returns the passphrase in the environment variable env, or asks for it if stdin is a
+// terminal.
+func readPassphrase(env, prompt string) (string, error) {
+	if v := os.Getenv(env); v != "" {
+		return v, nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("%s is not set", env)
+	}
+	return readSecret(prompt)
+}
+
+// readSecret reads a line from stdin, prompting for it without echo if stdin is a terminal.
+func readSecret(prompt string) (string, error) {
+	var line string
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprint(os.Stderr, prompt)
+		b, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		line = string(b)
+	} else {
+		var err error
+		line, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", errors.New("the value is empty")
+	}
+	return line, nil
+}
+
+// runSecrets runs the "secrets" commands managing secrets_file and returns the exit code.
+func runSecrets(args []string) int {
+	path := viper.GetString("secrets_file")
+	keyFile := viper.GetString("secrets_key_file")
+	if len(args) == 2 && (args[0] == "set" || args[0] == "delete") && !secrets.ValidName(args[1]) {
+		fmt.Fprintf(os.Stderr, "Error: invalid name %q, use %s, %s, %s or %s\n", args[1], secrets.PSK, secrets.GatewayPSK("<gateway>"), secrets.APIKey("<name>"), secrets.MQTTPassword)
+		return cli.ExitUsage
+	}
+	var err error
+	switch {
+	case len(args) == 1 && args[0] == "keygen":
+		if keyFile == "" {
+			fmt.Fprintln(os.Stderr, "Error: secrets keygen writes the key to --secrets_key_file, which is not set")
+			return cli.ExitUsage
+		}
+		_, err = secrets.GenerateKeyFile(keyFile)
+	case path == "" && len(args) > 0:
+		fmt.Fprintln(os.Stderr, "Error: secrets_file is not configured")
+		return cli.ExitUsage
+	case len(args) == 1 && args[0] == "list":
+		var store secrets.Store
+		if store, err = secrets.Load(path, secretsKey()); err == nil {
+			for _, name := range store.Names() {
+				fmt.Println(name)
+			}
+		}
+	case len(args) == 2 && args[0] == "set":
+		var value string
+		if value, err = readSecret("Value of " + args[1] + ": "); err == nil {
+			err = setSecret(args[1], value)
+		}
+	case len(args) == 2 && args[0] == "delete":
+		err = deleteSecret(args[1])
+	case len(args) == 1 && args[0] == "rotate":
+		err = rotateSecrets(path, keyFile)
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: secrets keygen|list|set <name>|delete <name>|rotate")
+		return cli.ExitUsage
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return cli.ExitError
+	}
+	return cli.ExitOK
+}
+
+// setSecret stores value as the named secret in secrets_file, creating the file if it doesn't exist.
+func setSecret(name, value string) error {
+	path := viper.GetString("secrets_file")
+	key := secretsKey()
+	store, err := secrets.Load(path, key)
+	if errors.Is(err, fs.ErrNotExist) {
+		store, err = secrets.Store{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	store[name] = value
+	return secrets.Save(path, key, store)
+}
+
+func deleteSecret(name string) error {
+	path := viper.GetString("secrets_file")
+	key := secretsKey()
+	store, err := secrets.Load(path, key)
+	if err != nil {
+		return err
+	}
+	if _, ok := store[name]; !ok {
+		return fmt.Errorf("no secret %q", name)
+	}
+	delete(store, name)
+	return secrets.Save(path, key, store)
+}
+
+// rotateSecrets re-encrypts secrets_file with a new passphrase, or a new key replacing the key file. The new
+// key is written next to the key file first and only replaces it once the secrets file has been re-encrypted.
+func rotateSecrets(path, keyFile string) error {
+	old := secretsKey()
+	if keyFile == "" {
+		next, err := readPassphrase(secrets.NewPassphraseEnv, "New passphrase: ")
+		if err != nil {
+			return err
+		}
+		return secrets.Rotate(path, old, secrets.Passphrase(next))
+	}
+	next, err := secrets.GenerateKeyFile(keyFile + ".new")
+	if err != nil {
+		return err
+	}
+	if err := secrets.Rotate(path, old, next); err != nil {
+		_ = os.Remove(keyFile + ".new")
+		return err
+	}
+	return os.Rename(keyFile+".new", keyFile)
+}
+
+// serveGrpc serves the gRPC API until ctx is cancelled and then stops gracefully, waiting at most
+// shutdownTimeout for running calls and streams.
+func serveGrpc(ctx context.Context, gateways []grpc_server.Gateway, trackers map[string]*health.Tracker, authenticator *auth.Authenticator, tlsConfig *tls.Config, listenAddress string, shutdownTimeout time.Duration) error {
 	logger := logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
 		slog.Log(ctx, slog.Level(lvl), msg, fields...)
 	})
 	opts := []logging.Option{logging.WithLogOnEvents(logging.StartCall, logging.FinishCall)}
-	s := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger, opts...)),
-		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger, opts...)),
-	)
-	pb.RegisterTradfriServiceServer(s, grpc_server.New(tc))
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			grpc_server.MetricsUnaryInterceptor(),
+			logging.UnaryServerInterceptor(logger, opts...),
+			grpc_server.AuthUnaryInterceptor(authenticator),
+		),
+		grpc.ChainStreamInterceptor(
+			grpc_server.MetricsStreamInterceptor(),
+			logging.StreamServerInterceptor(logger, opts...),
+			grpc_server.AuthStreamInterceptor(authenticator),
+		),
+	}
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	s := grpc.NewServer(serverOpts...)
+	pb.RegisterTradfriServiceServer(s, grpc_server.NewGateways(gateways))
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go grpc_server.UpdateHealth(ctx, healthServer, trackers, 5*time.Second)
+	reflection.Register(s)
 	lis, err := net.Listen("tcp", listenAddress)
 	if err != nil {
-		slog.Error("failed to listen on grpc", slog.String("address", listenAddress), slog.Any("error", err))
-		return
+		return fmt.Errorf("gRPC server: %w", err)
 	}
-	reflection.Register(s)
-	if err := s.Serve(lis); err != nil {
-		slog.Error("gRPC server error", slog.Any("error", err))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Serve(lis) }()
+	select {
+	case err := <-errs:
+		return fmt.Errorf("gRPC server: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down gRPC server")
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		slog.Warn("gRPC calls did not finish in time, closing connections")
+		s.Stop()
+	}
+	return nil
+}
+
+// loadAuthenticator creates the authenticator for the API keys in the api_keys config section, taking the
+// keys found in src instead of the configured ones. Without any keys, authentication is disabled. Keys
+// restricted to devices or groups are rejected if more than one gateway is served.
+func loadAuthenticator(src secrets.Source, gateways int) *auth.Authenticator {
+	var keys []auth.Key
+	if err := viper.UnmarshalKey("api_keys", &keys); err != nil {
+		fail("Unable to parse api_keys: " + err.Error())
+	}
+	for i := range keys {
+		keys[i].Key = secretValue(src, secrets.APIKey(keys[i].Name), keys[i].Key)
+	}
+	if len(keys) == 0 {
+		slog.Warn("No api_keys configured, the REST and gRPC servers allow anonymous access")
+		return nil
+	}
+	if err := auth.CheckGateways(keys, gateways); err != nil {
+		fail(err.Error())
+	}
+	authenticator, err := auth.New(keys)
+	if err != nil {
+		fail(err.Error())
+	}
+	slog.Info("API key authentication enabled", slog.Int("keys", len(keys)))
+	return authenticator
+}
+
+// loadTLSConfig returns the TLS configuration shared by the REST and gRPC servers, or nil if TLS is disabled.
+// A self-signed certificate for listenHost, localhost and the host name is generated if the certificate and
+// key files don't exist.
+func loadTLSConfig(listenHost string) *tls.Config {
+	if !viper.GetBool("tls") {
+		return nil
+	}
+	cfg := tlsutil.Config{
+		CertFile:     viper.GetString("tls_cert"),
+		KeyFile:      viper.GetString("tls_key"),
+		ClientCAFile: viper.GetString("tls_client_ca"),
+	}
+	hostname, _ := os.Hostname()
+	generated, err := tlsutil.EnsureCertificate(cfg.CertFile, cfg.KeyFile, []string{listenHost, "localhost", "127.0.0.1", "::1", hostname})
+	if err != nil {
+		fail(err.Error())
+	}
+	if generated {
+		slog.Warn("Generated a self-signed TLS certificate", slog.String("cert", cfg.CertFile), slog.String("key", cfg.KeyFile))
+	}
+	reloader, err := tlsutil.NewReloader(cfg)
+	if err != nil {
+		fail(err.Error())
+	}
+	slog.Info("TLS enabled", slog.String("cert", cfg.CertFile), slog.Bool("client_certificates", cfg.ClientCAFile != ""))
+	return reloader.ServerConfig()
+}
+
+// registerDeviceMetrics exports the device gauges of the gateways, labelled by gateway if there are several.
+// The watcher's state is used while it polls for other subscribers, otherwise the devices are read from the
+// gateway at most once per ttl, since reading them blocks the gateway for other calls.
+func registerDeviceMetrics(gateways []*gateway.Gateway, ttl time.Duration) {
+	for _, gw := range gateways {
+		read := metrics.CacheDevices(gw.Client.ListDevices, ttl)
+		devices := func() ([]model.Device, error) {
+			if gw.Watcher.Polling() {
+				devices, _ := gw.Watcher.Snapshot()
+				return devices, nil
+			}
+			return read()
+		}
+		if len(gateways) > 1 {
+			metrics.RegisterGatewayDeviceCollector(gw.Name, devices)
+		} else {
+			metrics.RegisterDeviceCollector(devices)
+		}
 	}
 }
 
 func fail(msg string) {
-	slog.Info(msg)
+	slog.Error(msg)
 	os.Exit(1)
 }
 
@@ -0,0 +1,217 @@
+// Package metrics defines the Prometheus metrics exported by tradfri-go on /metrics.
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	gatewayCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tradfri_gateway_call_duration_seconds",
+		Help:    "Latency of CoAP calls to the gateway, including reconnects.",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"gateway", "method", "path"})
+	gatewayCallErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tradfri_gateway_call_errors_total",
+		Help: "CoAP calls to the gateway that failed or were answered with an error code.",
+	}, []string{"gateway", "method", "path"})
+	dtlsReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tradfri_dtls_reconnects_total",
+		Help: "Number of times the DTLS session to the gateway was re-established.",
+	}, []string{"gateway"})
+
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tradfri_http_requests_total",
+		Help: "HTTP requests handled by the REST server.",
+	}, []string{"method", "route", "status"})
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tradfri_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests handled by the REST server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tradfri_grpc_requests_total",
+		Help: "gRPC calls handled by the gRPC server.",
+	}, []string{"method", "code"})
+	grpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tradfri_grpc_request_duration_seconds",
+		Help:    "Latency of gRPC calls handled by the gRPC server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Handler returns the HTTP handler serving all registered metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveGatewayCall records a CoAP call to the named gateway. The path must be a template as returned by
+// PathTemplate, so that the number of series stays bounded.
+func ObserveGatewayCall(gateway, method, path string, duration time.Duration, failed bool) {
+	gatewayCallDuration.WithLabelValues(gateway, method, path).Observe(duration.Seconds())
+	if failed {
+		gatewayCallErrors.WithLabelValues(gateway, method, path).Inc()
+	}
+}
+
+// RawPath is the path label of requests to arbitrary gateway paths.
+const RawPath = "raw"
+
+// PathTemplate returns the path label of a CoAP path, replacing the IDs of devices, groups and scenes with
+// "{id}", e.g. "15001/{id}" for "/15001/65538". Paths outside of the known resources are reported as RawPath.
+func PathTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch segments[0] {
+	case "15001", "15004", "15005":
+		for i := 1; i < len(segments); i++ {
+			if _, err := strconv.Atoi(segments[i]); err != nil || i > 2 {
+				return RawPath
+			}
+			segments[i] = "{id}"
+		}
+		return strings.Join(segments, "/")
+	case "15011":
+		if p := strings.Join(segments, "/"); p == "15011/15012" || p == "15011/9063" {
+			return p
+		}
+	}
+	return RawPath
+}
+
+// DTLSReconnected records that the DTLS session to the named gateway had to be re-established.
+func DTLSReconnected(gateway string) {
+	dtlsReconnects.WithLabelValues(gateway).Inc()
+}
+
+// ObserveHTTPRequest records an HTTP request handled by the route pattern.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGRPCRequest records a gRPC call by its full method name and status code.
+func ObserveGRPCRequest(method, code string, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code).Inc()
+	grpcRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// RegisterDeviceCollector exports gauges for the devices returned by the passed function, which is
+// called on every scrape. If it fails the device gauges are left out of that scrape.
+func RegisterDeviceCollector(devices func() ([]model.Device, error)) {
+	prometheus.MustRegister(deviceCollector{devices: devices})
+}
+
+// RegisterGatewayDeviceCollector is RegisterDeviceCollector for one of several gateways, whose name is added
+// as the "gateway" label. It must be used for all gateways, as the label is missing otherwise.
+func RegisterGatewayDeviceCollector(gateway string, devices func() ([]model.Device, error)) {
+	prometheus.MustRegister(deviceCollector{devices: devices, descs: newDeviceDescs(prometheus.Labels{"gateway": gateway})})
+}
+
+// CacheDevices returns a function for RegisterDeviceCollector that calls devices at most once per ttl and
+// returns the same result, including an error, to the scrapes in between.
+func CacheDevices(devices func() ([]model.Device, error), ttl time.Duration) func() ([]model.Device, error) {
+	var (
+		mu      sync.Mutex
+		read    time.Time
+		cached  []model.Device
+		lastErr error
+	)
+	return func() ([]model.Device, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if read.IsZero() || time.Since(read) >= ttl {
+			cached, lastErr = devices()
+			read = time.Now()
+		}
+		return cached, lastErr
+	}
+}
+
+var deviceLabels = []string{"device_id", "name", "type"}
+
+// deviceDescs describes the device gauges.
+type deviceDescs struct {
+	power, dimmer, position, battery, alive, lastSeen *prometheus.Desc
+}
+
+// defaultDeviceDescs are the descriptions of a single gateway, without a gateway label.
+var defaultDeviceDescs = newDeviceDescs(nil)
+
+func newDeviceDescs(constLabels prometheus.Labels) *deviceDescs {
+	return &deviceDescs{
+		power:    prometheus.NewDesc("tradfri_device_power", "Power state of a bulb or plug, 1 is on.", deviceLabels, constLabels),
+		dimmer:   prometheus.NewDesc("tradfri_device_dimmer", "Dimmer level of a bulb (0-254).", deviceLabels, constLabels),
+		position: prometheus.NewDesc("tradfri_device_blind_position", "Position of a blind (0-100).", deviceLabels, constLabels),
+		battery:  prometheus.NewDesc("tradfri_device_battery_percent", "Battery level of a battery powered device.", deviceLabels, constLabels),
+		alive:    prometheus.NewDesc("tradfri_device_alive", "Whether the gateway considers the device reachable.", deviceLabels, constLabels),
+		lastSeen: prometheus.NewDesc("tradfri_device_last_seen_age_seconds", "Seconds since the gateway last heard from the device.", deviceLabels, constLabels),
+	}
+}
+
+// deviceCollector computes the device gauges at scrape time, which keeps the last seen age current.
+type deviceCollector struct {
+	devices func() ([]model.Device, error)
+	// descs defaults to defaultDeviceDescs.
+	descs *deviceDescs
+}
+
+func (c deviceCollector) describe() *deviceDescs {
+	if c.descs == nil {
+		return defaultDeviceDescs
+	}
+	return c.descs
+}
+
+func (c deviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	descs := c.describe()
+	ch <- descs.power
+	ch <- descs.dimmer
+	ch <- descs.position
+	ch <- descs.battery
+	ch <- descs.alive
+	ch <- descs.lastSeen
+}
+
+func (c deviceCollector) Collect(ch chan<- prometheus.Metric) {
+	devices, err := c.devices()
+	if err != nil {
+		slog.Warn("unable to read the devices for the metrics", slog.Any("error", err))
+		return
+	}
+	descs := c.describe()
+	now := time.Now()
+	for _, d := range devices {
+		labels := []string{strconv.Itoa(d.DeviceId), d.Name, d.Metadata.TypeName}
+		gauge := func(desc *prometheus.Desc, value float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+		}
+		if len(d.LightControl) > 0 {
+			gauge(descs.power, float64(d.LightControl[0].Power))
+			gauge(descs.dimmer, float64(d.LightControl[0].Dimmer))
+		}
+		if len(d.OutletControl) > 0 {
+			gauge(descs.power, float64(d.OutletControl[0].Power))
+		}
+		if len(d.BlindControl) > 0 {
+			gauge(descs.position, float64(d.BlindControl[0].Position))
+		}
+		if d.Metadata.Battery > 0 {
+			gauge(descs.battery, float64(d.Metadata.Battery))
+		}
+		gauge(descs.alive, float64(d.Alive))
+		if d.LastSeen > 0 {
+			gauge(descs.lastSeen, now.Sub(time.Unix(int64(d.LastSeen), 0)).Seconds())
+		}
+	}
+}
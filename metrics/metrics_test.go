@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestDeviceCollector(t *testing.T) {
+	var devices []model.Device
+	payload := `[{"9003":65538,"9001":"Bulb","9019":1,"3":{"1":"TRADFRI bulb"},"3311":[{"5850":1,"5851":200}]},
+		{"9003":65552,"9001":"Blind","9019":0,"3":{"1":"FYRTUR","9":87},"15015":[{"5536":20}]}]`
+	if err := json.Unmarshal([]byte(payload), &devices); err != nil {
+		t.Fatal(err)
+	}
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(deviceCollector{devices: func() ([]model.Device, error) { return devices, nil }})
+
+	expected := `
+# HELP tradfri_device_blind_position Position of a blind (0-100).
+# TYPE tradfri_device_blind_position gauge
+tradfri_device_blind_position{device_id="65552",name="Blind",type="FYRTUR"} 20
+# HELP tradfri_device_battery_percent Battery level of a battery powered device.
+# TYPE tradfri_device_battery_percent gauge
+tradfri_device_battery_percent{device_id="65552",name="Blind",type="FYRTUR"} 87
+# HELP tradfri_device_dimmer Dimmer level of a bulb (0-254).
+# TYPE tradfri_device_dimmer gauge
+tradfri_device_dimmer{device_id="65538",name="Bulb",type="TRADFRI bulb"} 200
+# HELP tradfri_device_power Power state of a bulb or plug, 1 is on.
+# TYPE tradfri_device_power gauge
+tradfri_device_power{device_id="65538",name="Bulb",type="TRADFRI bulb"} 1
+# HELP tradfri_device_alive Whether the gateway considers the device reachable.
+# TYPE tradfri_device_alive gauge
+tradfri_device_alive{device_id="65538",name="Bulb",type="TRADFRI bulb"} 1
+tradfri_device_alive{device_id="65552",name="Blind",type="FYRTUR"} 0
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"tradfri_device_power", "tradfri_device_dimmer", "tradfri_device_blind_position",
+		"tradfri_device_battery_percent", "tradfri_device_alive"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeviceCollector_LastSeenAge(t *testing.T) {
+	devices := []model.Device{{DeviceId: 1, LastSeen: int(time.Now().Add(-time.Minute).Unix())}}
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(deviceCollector{devices: func() ([]model.Device, error) { return devices, nil }})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() == "tradfri_device_last_seen_age_seconds" {
+			if age := f.GetMetric()[0].GetGauge().GetValue(); age < 59 || age > 65 {
+				t.Fatalf("expected an age of about 60s, got %v", age)
+			}
+			return
+		}
+	}
+	t.Fatal("last seen age not exported")
+}
+
+func TestGatewayDeviceCollector(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	for _, gateway := range []string{"house", "garage"} {
+		devices := []model.Device{{DeviceId: 65538, Name: "Bulb", Alive: 1}}
+		registry.MustRegister(deviceCollector{devices: func() ([]model.Device, error) { return devices, nil }, descs: newDeviceDescs(prometheus.Labels{"gateway": gateway})})
+	}
+
+	expected := `
+# HELP tradfri_device_alive Whether the gateway considers the device reachable.
+# TYPE tradfri_device_alive gauge
+tradfri_device_alive{device_id="65538",gateway="garage",name="Bulb",type=""} 1
+tradfri_device_alive{device_id="65538",gateway="house",name="Bulb",type=""} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "tradfri_device_alive"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeviceCollector_Error(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(deviceCollector{devices: func() ([]model.Device, error) { return nil, errors.New("gateway unreachable") }})
+
+	if n, err := testutil.GatherAndCount(registry); err != nil || n != 0 {
+		t.Fatalf("expected no device gauges and no error, got %d, %v", n, err)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/15001/":              "15001",
+		"/15001/65538":         "15001/{id}",
+		"/15004/131073":        "15004/{id}",
+		"/15005/131073/196608": "15005/{id}/{id}",
+		"/15011/15012":         "15011/15012",
+		"/15011/9063":          "15011/9063",
+		"/15001/name":          RawPath,
+		"/15011/15013":         RawPath,
+		"/15006/1":             RawPath,
+		"":                     RawPath,
+	} {
+		if got := PathTemplate(path); got != expected {
+			t.Errorf("%q: expected %q, got %q", path, expected, got)
+		}
+	}
+}
+
+func TestCacheDevices(t *testing.T) {
+	calls := 0
+	devices := CacheDevices(func() ([]model.Device, error) {
+		calls++
+		return []model.Device{{DeviceId: calls}}, nil
+	}, time.Hour)
+	for range 3 {
+		if d, err := devices(); err != nil || d[0].DeviceId != 1 {
+			t.Fatalf("expected the cached devices, got %v, %v", d, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the gateway to be read once, got %d", calls)
+	}
+
+	devices = CacheDevices(func() ([]model.Device, error) {
+		calls++
+		return nil, nil
+	}, 0)
+	_, _ = devices()
+	_, _ = devices()
+	if calls != 3 {
+		t.Fatalf("expected every call to read the gateway without a ttl, got %d", calls-1)
+	}
+}
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// keyEntry maps a numeric IKEA/LWM2M JSON key to a readable name. Entries with a parent only apply to
+// keys nested in the object (or array of objects) under the parent key.
+type keyEntry struct {
+	parent string
+	code   string
+	name   string
+}
+
+// keyRegistry lists the known gateway keys. Codes that mean different things depending on where they
+// appear, like the LWM2M device object "3", are registered with a parent.
+var keyRegistry = []keyEntry{
+	// LWM2M objects
+	{code: "3", name: "device"},
+	{code: "3311", name: "lightControl"},
+	{code: "3312", name: "outletControl"},
+	{code: "15009", name: "switchControl"},
+	{code: "15015", name: "blindControl"},
+	{code: "15025", name: "airPurifierControl"},
+
+	// LWM2M device object
+	{parent: "3", code: "0", name: "manufacturer"},
+	{parent: "3", code: "1", name: "modelNumber"},
+	{parent: "3", code: "2", name: "serialNumber"},
+	{parent: "3", code: "3", name: "firmwareVersion"},
+	{parent: "3", code: "6", name: "powerSource"},
+	{parent: "3", code: "9", name: "batteryLevel"},
+
+	// lights, outlets and blinds
+	{code: "5536", name: "position"},
+	{code: "5706", name: "colorHex"},
+	{code: "5707", name: "colorHue"},
+	{code: "5708", name: "colorSaturation"},
+	{code: "5709", name: "colorX"},
+	{code: "5710", name: "colorY"},
+	{code: "5711", name: "colorTemperature"},
+	{code: "5712", name: "transitionTime"},
+	{code: "5750", name: "deviceType"},
+	{code: "5850", name: "power"},
+	{code: "5851", name: "dimmer"},
+
+	// air purifiers
+	{code: "5900", name: "fanMode"},
+	{code: "5902", name: "filterRuntime"},
+	{code: "5903", name: "filterStatus"},
+	{code: "5904", name: "filterLifetimeTotal"},
+	{code: "5905", name: "controlsLocked"},
+	{code: "5906", name: "ledsOff"},
+	{code: "5907", name: "airQuality"},
+	{code: "5908", name: "fanSpeed"},
+	{code: "5909", name: "motorRuntimeTotal"},
+	{code: "5910", name: "filterLifetimeRemaining"},
+
+	// common
+	{code: "9001", name: "name"},
+	{code: "9002", name: "createdAt"},
+	{code: "9003", name: "instanceId"},
+	{code: "9019", name: "alive"},
+	{code: "9020", name: "lastSeen"},
+	{code: "9054", name: "otaUpdateState"},
+
+	// groups and scenes
+	{code: "9018", name: "groupMembers"},
+	{code: "15002", name: "deviceList"},
+	{code: "9039", name: "sceneId"},
+	{code: "9108", name: "groupType"},
+
+	// gateway
+	{code: "9023", name: "ntpServer"},
+	{code: "9029", name: "firmwareVersion"},
+	{code: "9059", name: "currentTimeUnix"},
+	{code: "9060", name: "currentTimeIso8601"},
+	{code: "9081", name: "gatewayId"},
+	{code: "9090", name: "identity"},
+	{code: "9091", name: "preSharedKey"},
+}
+
+// keyScope holds the lookups for keys nested under one parent code, "" being the global scope.
+type keyScope struct {
+	names map[string]string
+	codes map[string]string
+}
+
+var keyScopes = buildKeyScopes()
+
+func buildKeyScopes() map[string]keyScope {
+	scopes := map[string]keyScope{}
+	for _, e := range keyRegistry {
+		s, ok := scopes[e.parent]
+		if !ok {
+			s = keyScope{names: map[string]string{}, codes: map[string]string{}}
+			scopes[e.parent] = s
+		}
+		s.names[e.code] = e.name
+		s.codes[e.name] = e.code
+	}
+	return scopes
+}
+
+// ReadableKeys translates the numeric keys of a gateway JSON payload into readable names, e.g.
+// {"3311":[{"5850":1}]} into {"lightControl":[{"power":1}]}. Unknown keys and all values are preserved.
+func ReadableKeys(payload []byte) ([]byte, error) {
+	return translateKeys(payload, true)
+}
+
+// NumericKeys is the inverse of ReadableKeys, translating readable names back into the numeric keys
+// understood by the gateway. Numeric keys already present are preserved, so both forms may be mixed.
+func NumericKeys(payload []byte) ([]byte, error) {
+	return translateKeys(payload, false)
+}
+
+// ReadableOrRaw returns the payload with readable keys, or unchanged if it isn't JSON. Meant for logging.
+func ReadableOrRaw(payload []byte) string {
+	if readable, err := ReadableKeys(payload); err == nil {
+		return string(readable)
+	}
+	return string(payload)
+}
+
+func translateKeys(payload []byte, toNames bool) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	// keep numbers exactly as sent by the gateway
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(translateValue(v, "", toNames))
+}
+
+// translateValue translates the keys of all objects in v. parent is the numeric key v is the value of.
+func translateValue(v any, parent string, toNames bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			code, key := k, k
+			if toNames {
+				key = lookupKey(parent, k, func(s keyScope) map[string]string { return s.names })
+			} else {
+				code = lookupKey(parent, k, func(s keyScope) map[string]string { return s.codes })
+				key = code
+			}
+			out[key] = translateValue(child, code, toNames)
+		}
+		return out
+	case []any:
+		for i := range v {
+			v[i] = translateValue(v[i], parent, toNames)
+		}
+		return v
+	}
+	return v
+}
+
+// lookupKey translates k using the scope of parent, falling back to the global scope. Unknown keys are
+// returned unchanged.
+func lookupKey(parent, k string, table func(keyScope) map[string]string) string {
+	if s, ok := keyScopes[parent]; ok && parent != "" {
+		if t, ok := table(s)[k]; ok {
+			return t
+		}
+	}
+	if t, ok := table(keyScopes[""])[k]; ok {
+		return t
+	}
+	return k
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const rawBulb = `{"3":{"0":"IKEA of Sweden","1":"TRADFRI bulb E27 CWS opal 600lm","3":"1.3.009","6":1},` +
+	`"3311":[{"5706":"f1e0b5","5850":1,"5851":254,"9003":0}],"5750":2,"9001":"Färgglad","9003":65538,` +
+	`"9020":1546446645,"99999":{"1":"unknown"}}`
+
+func TestReadableKeys(t *testing.T) {
+	readable, err := ReadableKeys([]byte(rawBulb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, readable, `{"device":{"manufacturer":"IKEA of Sweden","modelNumber":"TRADFRI bulb E27 CWS opal 600lm",`+
+		`"firmwareVersion":"1.3.009","powerSource":1},"lightControl":[{"colorHex":"f1e0b5","power":1,"dimmer":254,"instanceId":0}],`+
+		`"deviceType":2,"name":"Färgglad","instanceId":65538,"lastSeen":1546446645,"99999":{"1":"unknown"}}`)
+}
+
+func TestNumericKeys_RoundTrip(t *testing.T) {
+	readable, err := ReadableKeys([]byte(rawBulb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	numeric, err := NumericKeys(readable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, numeric, rawBulb)
+}
+
+func TestNumericKeys_Mixed(t *testing.T) {
+	numeric, err := NumericKeys([]byte(`{"blindControl":[{"position":20.5}],"9001":"Blind","custom":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, numeric, `{"15015":[{"5536":20.5}],"9001":"Blind","custom":true}`)
+}
+
+func TestReadableOrRaw(t *testing.T) {
+	if got := ReadableOrRaw([]byte("not json")); got != "not json" {
+		t.Fatalf("expected the raw payload, got %q", got)
+	}
+	if got := ReadableOrRaw([]byte(`{"5850":1}`)); got != `{"power":1}` {
+		t.Fatalf("unexpected payload %q", got)
+	}
+}
+
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ToDeviceResponse transforms the passed device into either a BulbResponse or BlindResponse. (more needed)
@@ -54,37 +55,97 @@ func ToDeviceResponse(device Device) interface{} {
 	return nil
 }
 
-// ToDeviceResponseProto transforms the passed device into a protobuf device having
-// its type-specific fields populated. Supports Light controls (bulbs) and blinds.
+// ToDeviceResponseProto transforms the passed device into a protobuf device with full metadata and the state
+// of its kind set. The deprecated flat fields are still populated for lights and blinds.
 func ToDeviceResponseProto(device Device) *pb.Device {
-	if device.LightControl != nil && len(device.LightControl) > 0 {
-		return &pb.Device{
-			Metadata: &pb.DeviceMetadata{
-				Name:   device.Name,
-				Id:     int32(device.DeviceId),
-				Type:   device.Metadata.TypeName,
-				Vendor: device.Metadata.Vendor,
-			},
-			Power:  device.LightControl[0].Power == 1,
-			Xcolor: int32(device.LightControl[0].CIE_1931_X),
-			Ycolor: int32(device.LightControl[0].CIE_1931_Y),
-			Rgb:    device.LightControl[0].RGBHex,
-			Dimmer: int32(device.LightControl[0].Dimmer),
-		}
+	d := &pb.Device{Metadata: &pb.DeviceMetadata{
+		Id:              int32(device.DeviceId),
+		Name:            device.Name,
+		Vendor:          device.Metadata.Vendor,
+		Type:            device.Metadata.TypeName,
+		SerialNumber:    device.Metadata.SerialNumber,
+		FirmwareVersion: device.Metadata.TypeId,
+		PowerSource:     toPowerSourceProto(device.Metadata.PowerType),
+		Alive:           device.Alive == 1,
+		LastSeen:        toTimestampProto(device.LastSeen),
+		Created:         toTimestampProto(device.CreatedAt),
+		OtaUpdateState:  int32(device.OtaUpdateState),
+	}}
+	switch device.Metadata.PowerType {
+	case 1, 2, 3:
+		d.Metadata.Bat = int32(device.Metadata.Battery)
 	}
-	if device.BlindControl != nil && len(device.BlindControl) > 0 {
-		return &pb.Device{
-			Metadata: &pb.DeviceMetadata{
-				Id:     int32(device.DeviceId),
-				Name:   device.Name,
-				Vendor: device.Metadata.Vendor,
-				Type:   device.Metadata.TypeName,
-				Bat:    int32(device.Metadata.Battery),
-			},
-			Position: device.BlindControl[0].Position,
+
+	switch deviceKind(device) {
+	case KindLight:
+		if len(device.LightControl) == 0 {
+			d.Kind = &pb.Device_Light{Light: &pb.Light{}}
+			break
+		}
+		lc := device.LightControl[0]
+		d.Kind = &pb.Device_Light{Light: &pb.Light{
+			Power:            lc.Power == 1,
+			Dimmer:           int32(lc.Dimmer),
+			Xcolor:           int32(lc.CIE_1931_X),
+			Ycolor:           int32(lc.CIE_1931_Y),
+			Rgb:              lc.RGBHex,
+			Hue:              int32(lc.Hue),
+			Saturation:       int32(lc.Saturation),
+			ColorTemperature: int32(lc.ColorTemperature),
+		}}
+		d.Power, d.Dimmer, d.Xcolor, d.Ycolor, d.Rgb = lc.Power == 1, int32(lc.Dimmer), int32(lc.CIE_1931_X), int32(lc.CIE_1931_Y), lc.RGBHex
+	case KindOutlet:
+		plug := &pb.Plug{}
+		if len(device.OutletControl) > 0 {
+			plug.Power, plug.Dimmer = device.OutletControl[0].Power == 1, int32(device.OutletControl[0].Dimmer)
 		}
+		d.Kind = &pb.Device_Plug{Plug: plug}
+	case KindBlind:
+		blind := &pb.Blind{}
+		if len(device.BlindControl) > 0 {
+			blind.Position = device.BlindControl[0].Position
+			d.Position = blind.Position
+		}
+		d.Kind = &pb.Device_Blind{Blind: blind}
+	case KindPurifier:
+		purifier := &pb.AirPurifier{}
+		if len(device.AirPurifierControl) > 0 {
+			ap := device.AirPurifierControl[0]
+			purifier = &pb.AirPurifier{
+				Mode:                           int32(ap.FanMode),
+				FanSpeed:                       int32(ap.FanSpeed),
+				AirQuality:                     int32(ap.AirQuality),
+				ControlsLocked:                 ap.ControlsLocked == 1,
+				LedsOff:                        ap.LedsOff == 1,
+				FilterRuntimeMinutes:           int32(ap.FilterRuntime),
+				FilterLifetimeRemainingMinutes: int32(ap.FilterLifetimeRemaining),
+				MotorRuntimeMinutes:            int32(ap.MotorRuntimeTotal),
+			}
+		}
+		d.Kind = &pb.Device_AirPurifier{AirPurifier: purifier}
+	case KindRemote:
+		d.Kind = &pb.Device_Remote{Remote: &pb.Remote{}}
+	case KindSensor:
+		d.Kind = &pb.Device_Sensor{Sensor: &pb.Sensor{}}
+	case KindRepeater:
+		d.Kind = &pb.Device_Repeater{Repeater: &pb.Repeater{}}
+	}
+	return d
+}
+
+func toPowerSourceProto(powerType int) pb.PowerSource {
+	if _, ok := powerSources[powerType]; !ok {
+		return pb.PowerSource_POWER_SOURCE_UNSPECIFIED
+	}
+	// the enum values are the LWM2M codes shifted by one to keep zero for unknown sources
+	return pb.PowerSource(powerType + 1)
+}
+
+func toTimestampProto(sec int) *timestamppb.Timestamp {
+	if sec <= 0 {
+		return nil
 	}
-	return &pb.Device{}
+	return timestamppb.New(time.Unix(int64(sec), 0))
 }
 
 // ToGroupResponse transforms a group into a response format more suitable for JSON serialization
@@ -105,9 +166,13 @@ func ToGroupResponseProto(group Group) *pb.Group {
 		ids = append(ids, int32(v))
 	}
 	return &pb.Group{
-		Id:      int32(group.DeviceId),
-		Power:   int32(group.Power),
-		Created: time.Unix(int64(group.CreatedAt), 0).Format(time.RFC3339),
-		Devices: ids,
+		Id:        int32(group.DeviceId),
+		Power:     int32(group.Power),
+		Created:   time.Unix(int64(group.CreatedAt), 0).Format(time.RFC3339),
+		Devices:   ids,
+		Name:      group.Name,
+		Dimmer:    int32(group.Dimmer),
+		SceneId:   int32(group.SceneId),
+		GroupType: int32(group.GroupType),
 	}
 }
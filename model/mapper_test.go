@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "github.com/eriklupander/tradfri-go/grpc_server/golang"
+)
+
+func TestToDeviceResponseProto(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		check   func(*pb.Device) bool
+	}{
+		{"bulb", `{"3":{"2":"SN1","3":"2.3.086","6":1},"3311":[{"5706":"f1e0b5","5711":370,"5850":1,"5851":127}],"5750":2,"9003":65538,"9019":1,"9020":1700000000}`,
+			func(d *pb.Device) bool {
+				m := d.GetMetadata()
+				return d.GetLight().GetDimmer() == 127 && d.GetLight().GetColorTemperature() == 370 && d.GetRgb() == "f1e0b5" &&
+					m.GetSerialNumber() == "SN1" && m.GetFirmwareVersion() == "2.3.086" && m.GetAlive() && m.GetLastSeen().GetSeconds() == 1700000000 &&
+					m.GetPowerSource() == pb.PowerSource_POWER_SOURCE_INTERNAL_BATTERY
+			}},
+		{"plug", `{"3":{"6":6},"3312":[{"5850":1}],"5750":3,"9003":65540}`,
+			func(d *pb.Device) bool {
+				return d.GetPlug().GetPower() && d.GetMetadata().GetPowerSource() == pb.PowerSource_POWER_SOURCE_MAINS
+			}},
+		{"blind", `{"3":{"6":3,"9":87},"15015":[{"5536":40}],"5750":7,"9003":65541}`,
+			func(d *pb.Device) bool {
+				return d.GetBlind().GetPosition() == 40 && d.GetPosition() == 40 && d.GetMetadata().GetBat() == 87
+			}},
+		{"air purifier", `{"3":{"6":6},"15025":[{"5900":1,"5905":1,"5907":6,"5908":10,"5910":259200}],"5750":10,"9003":65543}`,
+			func(d *pb.Device) bool {
+				ap := d.GetAirPurifier()
+				return ap.GetMode() == 1 && ap.GetControlsLocked() && ap.GetAirQuality() == 6 && ap.GetFilterLifetimeRemainingMinutes() == 259200
+			}},
+		{"remote", `{"3":{"6":3,"9":50},"5750":0,"9003":65542}`, func(d *pb.Device) bool { return d.GetRemote() != nil }},
+		{"sensor", `{"3":{"6":3},"5750":4,"9003":65544}`, func(d *pb.Device) bool { return d.GetSensor() != nil }},
+		{"repeater", `{"3":{"6":6},"5750":6,"9003":65545}`, func(d *pb.Device) bool { return d.GetRepeater() != nil }},
+	}
+	for _, tt := range tests {
+		var device Device
+		if err := json.Unmarshal([]byte(tt.payload), &device); err != nil {
+			t.Fatal(err)
+		}
+		if d := ToDeviceResponseProto(device); !tt.check(d) {
+			t.Errorf("%s: unexpected conversion %v", tt.name, d)
+		}
+	}
+}
+
+func TestToGroupResponseProto(t *testing.T) {
+	var group Group
+	_ = json.Unmarshal([]byte(`{"5850":1,"5851":200,"9001":"Kitchen","9003":131073,"9018":{"15002":{"9003":[65538]}},"9039":196608,"9108":0}`), &group)
+	g := ToGroupResponseProto(group)
+	if g.GetName() != "Kitchen" || g.GetDimmer() != 200 || g.GetSceneId() != 196608 || len(g.GetDevices()) != 1 {
+		t.Fatalf("unexpected conversion %v", g)
+	}
+}
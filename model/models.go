@@ -31,6 +31,17 @@ type Device struct {
 		Position float32 `json:"5536"`
 		DeviceId int     `json:"9003"`
 	} `json:"15015"`
+	AirPurifierControl []struct {
+		FanMode                 int `json:"5900"`
+		FilterRuntime           int `json:"5902"`
+		ControlsLocked          int `json:"5905"`
+		LedsOff                 int `json:"5906"`
+		AirQuality              int `json:"5907"`
+		FanSpeed                int `json:"5908"`
+		MotorRuntimeTotal       int `json:"5909"`
+		FilterLifetimeRemaining int `json:"5910"`
+		DeviceId                int `json:"9003"`
+	} `json:"15025"`
 	Type           int    `json:"5750"`
 	Name           string `json:"9001"`
 	CreatedAt      int    `json:"9002"`
@@ -56,6 +67,13 @@ type Group struct {
 	GroupType int `json:"9108"`
 }
 
+// Scene defines (with JSON tags) a scene of a group, called mood by the gateway.
+type Scene struct {
+	Name      string `json:"9001"`
+	CreatedAt int    `json:"9002"`
+	SceneId   int    `json:"9003"`
+}
+
 // RemoteControl defines (with JSON tags) a IKEA remote control.
 type RemoteControl struct {
 	Metadata struct {
@@ -127,11 +145,35 @@ type BulbResponse struct {
 	Power          bool           `json:"power"`
 }
 
+// GatewayInfo defines (with JSON tags) the details of the gateway.
+type GatewayInfo struct {
+	NTPServer       string `json:"9023"`
+	FirmwareVersion string `json:"9029"`
+	CurrentTime     int    `json:"9059"`
+	GatewayId       string `json:"9081"`
+}
+
 // Result is a generic result containing a plain text message
 type Result struct {
 	Msg string
 }
 
+// RawResponse is a gateway response returned as is by the raw CoAP passthrough.
+type RawResponse struct {
+	// Code is the CoAP response code in class.detail notation, e.g. "2.05".
+	Code     string      `json:"code"`
+	CodeName string      `json:"codeName"`
+	Options  []RawOption `json:"options"`
+	Payload  string      `json:"payload"`
+}
+
+// RawOption is a CoAP option of a RawResponse. Opaque values are hex encoded.
+type RawOption struct {
+	Number int    `json:"number"`
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value"`
+}
+
 // TokenExchange maps the human-readable Token and TypeIdentifies into their IKEA specific numeric codes.
 type TokenExchange struct {
 	Token           string `json:"9091"`
@@ -156,27 +198,105 @@ type BlindResponse struct {
 
 // RgbColorRequest allows (trying to) set a bulb color using classic hex RGB string.
 type RgbColorRequest struct {
-	RGBcolor string `json:"rgbcolor"`
+	RGBcolor string `json:"rgbcolor" validate:"rgbhex"`
+}
+
+// ColorXYRequest sets a bulb color using CIE 1931 x and y coordinates.
+type ColorXYRequest struct {
+	X int `json:"x" validate:"min=0,max=65535"`
+	Y int `json:"y" validate:"min=0,max=65535"`
 }
 
-// DimmingRequest allows setting the dimmer level from 0-255.
+// DimmingRequest allows setting the dimmer level from 0-254.
 type DimmingRequest struct {
-	Dimming int `json:"dimming"`
+	Dimming int `json:"dimming" validate:"min=0,max=254"`
 }
 
 // PowerRequest contains a Power state int, 1 == on, 0 == off.
 type PowerRequest struct {
-	Power int `json:"power"`
+	Power int `json:"power" validate:"oneof=0 1"`
 }
 
 // StateRequest allows setting both color, dimmer and power setting in a single PUT.
 type StateRequest struct {
-	RGBcolor string `json:"rgbcolor"`
-	Dimmer   int    `json:"dimmer"`
-	Power    int    `json:"power"`
+	RGBcolor string `json:"rgbcolor,omitempty" validate:"omitempty,rgbhex"`
+	Dimmer   int    `json:"dimmer" validate:"min=0,max=254"`
+	Power    int    `json:"power" validate:"oneof=0 1"`
 }
 
 // PositioningRequest allows setting the position from 0-100.
 type PositioningRequest struct {
-	Positioning float32 `json:"positioning"`
+	Positioning float32 `json:"positioning" validate:"min=0,max=100"`
+}
+
+// BatchRequest is a list of operations executed concurrently by POST /api/batch. With StopOnError, operations
+// not yet started when one fails are skipped.
+type BatchRequest struct {
+	Operations  []BatchOperation `json:"operations" validate:"min=1,max=100"`
+	StopOnError bool             `json:"stopOnError,omitempty"`
+}
+
+// BatchOperation changes the state of a single device or group, exactly one of DeviceId and GroupId must be
+// set. All set attributes are applied, in the order power, dimmer, color, position and scene. Color and
+// position operations on a group are applied to each of its devices.
+type BatchOperation struct {
+	DeviceId    int      `json:"deviceId,omitempty"`
+	GroupId     int      `json:"groupId,omitempty"`
+	Power       *int     `json:"power,omitempty" validate:"omitempty,oneof=0 1"`
+	Dimmer      *int     `json:"dimmer,omitempty" validate:"omitempty,min=0,max=254"`
+	RGBcolor    string   `json:"rgbcolor,omitempty" validate:"omitempty,rgbhex"`
+	X           *int     `json:"x,omitempty" validate:"omitempty,min=0,max=65535"`
+	Y           *int     `json:"y,omitempty" validate:"omitempty,min=0,max=65535"`
+	Positioning *float32 `json:"positioning,omitempty" validate:"omitempty,min=0,max=100"`
+	SceneId     *int     `json:"sceneId,omitempty"`
+}
+
+// Batch operation statuses.
+const (
+	BatchStatusOK      = "ok"
+	BatchStatusFailed  = "failed"
+	BatchStatusSkipped = "skipped"
+)
+
+// BatchResult is the outcome of the BatchOperation at Index.
+type BatchResult struct {
+	Index      int     `json:"index"`
+	Status     string  `json:"status"`
+	Result     string  `json:"result,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// BatchResponse holds the results of all operations of a BatchRequest, in the order of the request.
+type BatchResponse struct {
+	Results    []BatchResult `json:"results"`
+	DurationMs float64       `json:"durationMs"`
+}
+
+// WsRequest is a message sent by a client over the /api/ws WebSocket. Type selects the operation, one of
+// subscribe, unsubscribe, power, dimmer, color, rgb, state or position. Commands target DeviceId while
+// subscriptions are filtered by DeviceIds and GroupIds, leaving both empty subscribes to everything.
+type WsRequest struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	DeviceId    int     `json:"deviceId,omitempty"`
+	DeviceIds   []int   `json:"deviceIds,omitempty"`
+	GroupIds    []int   `json:"groupIds,omitempty"`
+	Power       int     `json:"power,omitempty"`
+	Dimmer      int     `json:"dimmer,omitempty"`
+	X           int     `json:"x,omitempty"`
+	Y           int     `json:"y,omitempty"`
+	RGBcolor    string  `json:"rgbcolor,omitempty"`
+	Positioning float32 `json:"positioning,omitempty"`
+}
+
+// WsResponse is a message sent by the server over the /api/ws WebSocket. Acknowledgements ("ack") and
+// errors ("error") carry the ID of the request they answer, state changes are pushed as "device" or "group".
+type WsResponse struct {
+	ID     string         `json:"id,omitempty"`
+	Type   string         `json:"type"`
+	Result *Result        `json:"result,omitempty"`
+	Error  string         `json:"error,omitempty"`
+	Device interface{}    `json:"device,omitempty"`
+	Group  *GroupResponse `json:"group,omitempty"`
 }
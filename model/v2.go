@@ -0,0 +1,287 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Models of the /api/v2 REST API. All kinds of devices share the DeviceV2 schema, with a state block for
+// each capability of the device. Brightness and position are percentages and colors use CIE 1931
+// coordinates between 0 and 1.
+
+// Device kinds, derived from the IKEA device type.
+const (
+	KindLight    = "light"
+	KindOutlet   = "outlet"
+	KindBlind    = "blind"
+	KindRemote   = "remote"
+	KindSensor   = "sensor"
+	KindRepeater = "repeater"
+	KindPurifier = "airPurifier"
+	KindUnknown  = "unknown"
+)
+
+var deviceKinds = map[int]string{
+	0:  KindRemote,
+	1:  KindRemote,
+	2:  KindLight,
+	3:  KindOutlet,
+	4:  KindSensor,
+	6:  KindRepeater,
+	7:  KindBlind,
+	8:  KindRemote,
+	10: KindPurifier,
+}
+
+// power sources of the LWM2M device object
+var powerSources = map[int]string{
+	0: "dc",
+	1: "internalBattery",
+	2: "externalBattery",
+	3: "battery",
+	4: "poe",
+	5: "usb",
+	6: "mains",
+	7: "solar",
+}
+
+// DeviceV2 is a device of any kind. Light, Outlet and Blind are set for devices having the capability.
+type DeviceV2 struct {
+	Id       int              `json:"id"`
+	Name     string           `json:"name"`
+	Kind     string           `json:"kind"`
+	Metadata DeviceMetadataV2 `json:"metadata"`
+	Light    *LightState      `json:"light,omitempty"`
+	Outlet   *OutletState     `json:"outlet,omitempty"`
+	Blind    *BlindState      `json:"blind,omitempty"`
+}
+
+// DeviceMetadataV2 describes the hardware of a device. BatteryLevel is only set for battery powered devices.
+type DeviceMetadataV2 struct {
+	Vendor          string     `json:"vendor"`
+	Model           string     `json:"model"`
+	SerialNumber    string     `json:"serialNumber"`
+	FirmwareVersion string     `json:"firmwareVersion"`
+	PowerSource     string     `json:"powerSource"`
+	BatteryLevel    *int       `json:"batteryLevel,omitempty"`
+	Alive           bool       `json:"alive"`
+	LastSeen        *time.Time `json:"lastSeen,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+}
+
+// LightState is the state of a bulb, Brightness is a percentage.
+type LightState struct {
+	On         bool  `json:"on"`
+	Brightness int   `json:"brightness"`
+	Color      Color `json:"color"`
+}
+
+// Color is a bulb color as hex RGB string and as CIE 1931 x and y coordinates between 0 and 1.
+type Color struct {
+	Hex string  `json:"hex"`
+	X   float64 `json:"x"`
+	Y   float64 `json:"y"`
+}
+
+// OutletState is the state of a power outlet.
+type OutletState struct {
+	On bool `json:"on"`
+}
+
+// BlindState is the state of a blind, Position is a percentage.
+type BlindState struct {
+	Position float32 `json:"position"`
+}
+
+// GroupV2 is a group of devices. Brightness is a percentage.
+type GroupV2 struct {
+	Id         int        `json:"id"`
+	Name       string     `json:"name"`
+	Type       int        `json:"type"`
+	On         bool       `json:"on"`
+	Brightness int        `json:"brightness"`
+	SceneId    int        `json:"sceneId"`
+	DeviceIds  []int      `json:"deviceIds"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+}
+
+// SceneV2 is a scene of a group, activated by patching the group with its ID.
+type SceneV2 struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DevicePatch changes the state of a device, only the set attributes are changed. The blocks must match
+// the capabilities of the device.
+type DevicePatch struct {
+	Light  *LightPatch  `json:"light,omitempty"`
+	Outlet *OutletPatch `json:"outlet,omitempty"`
+	Blind  *BlindPatch  `json:"blind,omitempty"`
+}
+
+// LightPatch changes the state of a bulb. A color is set either by Hex or by both X and Y.
+type LightPatch struct {
+	On         *bool       `json:"on,omitempty"`
+	Brightness *int        `json:"brightness,omitempty" validate:"omitempty,min=0,max=100"`
+	Color      *ColorPatch `json:"color,omitempty"`
+}
+
+// ColorPatch sets a bulb color.
+type ColorPatch struct {
+	Hex string   `json:"hex,omitempty" validate:"omitempty,rgbhex"`
+	X   *float64 `json:"x,omitempty" validate:"omitempty,min=0,max=1"`
+	Y   *float64 `json:"y,omitempty" validate:"omitempty,min=0,max=1"`
+}
+
+// OutletPatch changes the state of a power outlet.
+type OutletPatch struct {
+	On *bool `json:"on,omitempty"`
+}
+
+// BlindPatch changes the position of a blind.
+type BlindPatch struct {
+	Position *float32 `json:"position,omitempty" validate:"omitempty,min=0,max=100"`
+}
+
+// GroupPatch changes the state of all devices of a group, only the set attributes are changed.
+type GroupPatch struct {
+	On         *bool `json:"on,omitempty"`
+	Brightness *int  `json:"brightness,omitempty" validate:"omitempty,min=0,max=100"`
+	SceneId    *int  `json:"sceneId,omitempty"`
+}
+
+// GatewayV2 is a gateway served by the server.
+type GatewayV2 struct {
+	Name string `json:"name"`
+	// Default is set for the gateway serving the paths without a gateway name.
+	Default bool `json:"default"`
+}
+
+// GatewayDeviceV2 is a device of a listing across gateways.
+type GatewayDeviceV2 struct {
+	Gateway string `json:"gateway"`
+	DeviceV2
+}
+
+// GatewayGroupV2 is a group of a listing across gateways.
+type GatewayGroupV2 struct {
+	Gateway string `json:"gateway"`
+	GroupV2
+}
+
+// ToDeviceV2 transforms a device of any kind into its v2 representation.
+func ToDeviceV2(device Device) DeviceV2 {
+	d := DeviceV2{
+		Id:   device.DeviceId,
+		Name: device.Name,
+		Kind: deviceKind(device),
+		Metadata: DeviceMetadataV2{
+			Vendor:       device.Metadata.Vendor,
+			Model:        device.Metadata.TypeName,
+			SerialNumber: device.Metadata.SerialNumber,
+			// the gateway reports the firmware version under the key named TypeId
+			FirmwareVersion: device.Metadata.TypeId,
+			PowerSource:     powerSource(device.Metadata.PowerType),
+			Alive:           device.Alive == 1,
+			LastSeen:        unixTime(device.LastSeen),
+			CreatedAt:       unixTime(device.CreatedAt),
+		},
+	}
+	switch device.Metadata.PowerType {
+	case 1, 2, 3:
+		battery := device.Metadata.Battery
+		d.Metadata.BatteryLevel = &battery
+	}
+	if len(device.LightControl) > 0 {
+		lc := device.LightControl[0]
+		d.Light = &LightState{
+			On:         lc.Power == 1,
+			Brightness: DimmerToPercent(lc.Dimmer),
+			Color:      Color{Hex: lc.RGBHex, X: cieToUnit(lc.CIE_1931_X), Y: cieToUnit(lc.CIE_1931_Y)},
+		}
+	}
+	if len(device.OutletControl) > 0 {
+		d.Outlet = &OutletState{On: device.OutletControl[0].Power == 1}
+	}
+	if len(device.BlindControl) > 0 {
+		d.Blind = &BlindState{Position: device.BlindControl[0].Position}
+	}
+	return d
+}
+
+// ToGroupV2 transforms a group into its v2 representation.
+func ToGroupV2(group Group) GroupV2 {
+	ids := group.Content.DeviceList.DeviceIds
+	if ids == nil {
+		ids = []int{}
+	}
+	return GroupV2{
+		Id:         group.DeviceId,
+		Name:       group.Name,
+		Type:       group.GroupType,
+		On:         group.Power == 1,
+		Brightness: DimmerToPercent(group.Dimmer),
+		SceneId:    group.SceneId,
+		DeviceIds:  ids,
+		CreatedAt:  unixTime(group.CreatedAt),
+	}
+}
+
+// ToSceneV2 transforms a scene into its v2 representation.
+func ToSceneV2(scene Scene) SceneV2 {
+	return SceneV2{Id: scene.SceneId, Name: scene.Name}
+}
+
+// deviceKind derives the kind from the capabilities of the device, falling back to the IKEA device type for
+// devices without state, since a missing type reads as 0, the type of remotes.
+func deviceKind(device Device) string {
+	switch {
+	case len(device.LightControl) > 0:
+		return KindLight
+	case len(device.OutletControl) > 0:
+		return KindOutlet
+	case len(device.BlindControl) > 0:
+		return KindBlind
+	case len(device.AirPurifierControl) > 0:
+		return KindPurifier
+	}
+	if kind, ok := deviceKinds[device.Type]; ok {
+		return kind
+	}
+	return KindUnknown
+}
+
+func powerSource(powerType int) string {
+	if source, ok := powerSources[powerType]; ok {
+		return source
+	}
+	return "unknown"
+}
+
+// DimmerToPercent converts a dimmer level of 0-254 to a percentage.
+func DimmerToPercent(dimmer int) int {
+	return int(math.Round(float64(dimmer) * 100 / 254))
+}
+
+// PercentToDimmer converts a percentage to a dimmer level of 0-254.
+func PercentToDimmer(percent int) int {
+	return int(math.Round(float64(percent) * 254 / 100))
+}
+
+// cieToUnit scales a CIE 1931 coordinate of 0-65535 to 0-1, rounded to four decimals.
+func cieToUnit(v int) float64 {
+	return math.Round(float64(v)/65535*10000) / 10000
+}
+
+// UnitToCIE scales a CIE 1931 coordinate of 0-1 to 0-65535.
+func UnitToCIE(v float64) int {
+	return int(math.Round(v * 65535))
+}
+
+func unixTime(sec int) *time.Time {
+	if sec <= 0 {
+		return nil
+	}
+	t := time.Unix(int64(sec), 0).UTC()
+	return &t
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToDeviceV2(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		check   func(DeviceV2) bool
+	}{
+		{"bulb", `{"3":{"6":1},"3311":[{"5709":32768,"5850":1,"5851":254}],"5750":2,"9003":65538,"9019":1}`, func(d DeviceV2) bool {
+			return d.Kind == KindLight && d.Light.On && d.Light.Brightness == 100 && d.Light.Color.X == 0.5 && d.Metadata.Alive
+		}},
+		{"outlet", `{"3":{"6":6},"3312":[{"5850":0}],"5750":3,"9003":65540}`, func(d DeviceV2) bool {
+			return d.Kind == KindOutlet && d.Outlet != nil && !d.Outlet.On && d.Light == nil && d.Metadata.PowerSource == "mains" && d.Metadata.BatteryLevel == nil
+		}},
+		{"blind", `{"3":{"6":3,"9":87},"15015":[{"5536":40}],"5750":7,"9003":65541,"9020":1700000000}`, func(d DeviceV2) bool {
+			return d.Kind == KindBlind && d.Blind.Position == 40 && *d.Metadata.BatteryLevel == 87 && d.Metadata.LastSeen != nil
+		}},
+		{"remote", `{"3":{"6":3,"9":50},"5750":0,"9003":65542}`, func(d DeviceV2) bool {
+			return d.Kind == KindRemote && d.Light == nil && d.Outlet == nil && d.Blind == nil && d.Metadata.CreatedAt == nil
+		}},
+	}
+	for _, tt := range tests {
+		var device Device
+		if err := json.Unmarshal([]byte(tt.payload), &device); err != nil {
+			t.Fatal(err)
+		}
+		if d := ToDeviceV2(device); !tt.check(d) {
+			t.Errorf("%s: unexpected conversion %+v", tt.name, d)
+		}
+	}
+}
+
+func TestPercentConversion(t *testing.T) {
+	for _, p := range []int{0, 1, 40, 50, 99, 100} {
+		if got := DimmerToPercent(PercentToDimmer(p)); got != p {
+			t.Errorf("round trip of %d%% gave %d%%", p, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package onboarding registers a new client with a gateway: it exchanges the security code printed on the
+// gateway for a PSK of the client's own and checks that the gateway accepts it.
+package onboarding
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// exchangeIdentity is the client ID the gateway accepts with its security code, used only for the exchange.
+const exchangeIdentity = "Client_identity"
+
+// DefaultAttempts is the number of client IDs tried unless told otherwise.
+const DefaultAttempts = 5
+
+// ErrVerification is returned if no session could be opened with the new PSK.
+var ErrVerification = errors.New("the gateway did not accept the new PSK")
+
+// Options configures Run.
+type Options struct {
+	// GatewayAddress is the address of the gateway including the port.
+	GatewayAddress string
+	// ClientID is the ID to register, a random suffix is appended if it is already registered.
+	ClientID string
+	// SecurityCode is the code printed on the bottom of the gateway.
+	SecurityCode string
+	// Attempts limits the client IDs tried, defaults to DefaultAttempts.
+	Attempts int
+}
+
+// Credentials are the settings of a registered client.
+type Credentials struct {
+	GatewayAddress string
+	// ClientID is the registered ID, which differs from the requested one if that was taken.
+	ClientID        string
+	PSK             string
+	FirmwareVersion string
+}
+
+// client is the part of tradfri.Client used for onboarding.
+type client interface {
+	AuthExchange(clientId string) (model.TokenExchange, error)
+	GetGatewayInfo() (model.GatewayInfo, error)
+	Close() error
+}
+
+// dial opens a DTLS session with the gateway, replaced in tests.
+var dial = func(ctx context.Context, address, clientID, psk string) (client, error) {
+	c, err := tradfri.DialTradfriClient(address, clientID, psk)
+	if err != nil {
+		return nil, err
+	}
+	return c.WithContext(ctx), nil
+}
+
+// Run registers the client. If the gateway already knows the client ID, the exchange is retried with a
+// random suffix appended, e.g. "tradfri-go-3fa2". The new PSK is verified by opening a session with it.
+func Run(ctx context.Context, opts Options) (Credentials, error) {
+	switch {
+	case opts.GatewayAddress == "":
+		return Credentials{}, errors.New("the gateway address is required")
+	case opts.ClientID == "":
+		return Credentials{}, errors.New("the client ID is required")
+	case len(opts.SecurityCode) < 10:
+		return Credentials{}, errors.New("the security code printed on the gateway is required")
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultAttempts
+	}
+
+	clientID := opts.ClientID
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return Credentials{}, err
+		}
+		token, err := exchange(ctx, opts, clientID)
+		if errors.Is(err, tradfri.ErrIdentityExists) && attempt < opts.Attempts {
+			next := opts.ClientID + "-" + randomSuffix()
+			slog.Warn("The client ID is already registered, retrying with a new one", slog.String("client_id", clientID), slog.String("next", next))
+			clientID = next
+			continue
+		}
+		if err != nil {
+			return Credentials{}, err
+		}
+		creds := Credentials{GatewayAddress: opts.GatewayAddress, ClientID: clientID, PSK: token.Token, FirmwareVersion: token.FirmwareVersion}
+		if err := verify(ctx, creds); err != nil {
+			return Credentials{}, fmt.Errorf("%w: %w", ErrVerification, err)
+		}
+		return creds, nil
+	}
+}
+
+func exchange(ctx context.Context, opts Options, clientID string) (model.TokenExchange, error) {
+	c, err := dial(ctx, opts.GatewayAddress, exchangeIdentity, opts.SecurityCode)
+	if err != nil {
+		return model.TokenExchange{}, fmt.Errorf("unable to connect with the security code, check it and the gateway address: %w", err)
+	}
+	defer c.Close()
+	return c.AuthExchange(clientID)
+}
+
+func verify(ctx context.Context, creds Credentials) error {
+	c, err := dial(ctx, creds.GatewayAddress, creds.ClientID, creds.PSK)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	_, err = c.GetGatewayInfo()
+	return err
+}
+
+func randomSuffix() string {
+	b := make([]byte, 2)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// fakeGateway stands in for the gateway, registering client IDs and accepting sessions with their PSKs.
+type fakeGateway struct {
+	registered map[string]string
+	// rejectPSKs makes sessions with the new PSKs fail.
+	rejectPSKs bool
+}
+
+type fakeClient struct {
+	gw       *fakeGateway
+	clientID string
+}
+
+func (g *fakeGateway) dial(_ context.Context, _, clientID, psk string) (client, error) {
+	switch {
+	case clientID == exchangeIdentity && psk == "security-code":
+	case clientID != exchangeIdentity && psk == g.registered[clientID] && !g.rejectPSKs:
+	default:
+		return nil, fmt.Errorf("%w: handshake failed", dtlscoap.ErrUnavailable)
+	}
+	return fakeClient{gw: g, clientID: clientID}, nil
+}
+
+func (c fakeClient) AuthExchange(clientID string) (model.TokenExchange, error) {
+	if _, ok := c.gw.registered[clientID]; ok {
+		return model.TokenExchange{}, fmt.Errorf("%w: %w", tradfri.ErrIdentityExists, tradfri.GatewayError{Code: coap.BadRequest})
+	}
+	c.gw.registered[clientID] = "psk-" + clientID
+	return model.TokenExchange{Token: "psk-" + clientID, FirmwareVersion: "1.19.26"}, nil
+}
+
+func (c fakeClient) GetGatewayInfo() (model.GatewayInfo, error) { return model.GatewayInfo{}, nil }
+func (c fakeClient) Close() error                               { return nil }
+
+func withGateway(t *testing.T, gw *fakeGateway) {
+	t.Helper()
+	d := dial
+	dial = gw.dial
+	t.Cleanup(func() { dial = d })
+}
+
+var options = Options{GatewayAddress: "192.168.1.19:5684", ClientID: "tradfri-go", SecurityCode: "security-code"}
+
+func TestRun(t *testing.T) {
+	withGateway(t, &fakeGateway{registered: map[string]string{}})
+	creds, err := Run(context.Background(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.ClientID != "tradfri-go" || creds.PSK != "psk-tradfri-go" || creds.FirmwareVersion != "1.19.26" || creds.GatewayAddress != options.GatewayAddress {
+		t.Fatalf("unexpected credentials %+v", creds)
+	}
+}
+
+func TestRun_IdentityExists(t *testing.T) {
+	gw := &fakeGateway{registered: map[string]string{"tradfri-go": "old"}}
+	withGateway(t, gw)
+	creds, err := Run(context.Background(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(creds.ClientID, "tradfri-go-") || creds.PSK != gw.registered[creds.ClientID] {
+		t.Fatalf("expected a new client ID, got %+v", creds)
+	}
+
+	opts := options
+	opts.Attempts = 1
+	if _, err := Run(context.Background(), opts); !errors.Is(err, tradfri.ErrIdentityExists) {
+		t.Fatalf("expected ErrIdentityExists without retries, got %v", err)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	withGateway(t, &fakeGateway{registered: map[string]string{}, rejectPSKs: true})
+	if _, err := Run(context.Background(), options); !errors.Is(err, ErrVerification) {
+		t.Errorf("expected ErrVerification, got %v", err)
+	}
+
+	opts := options
+	opts.SecurityCode = "wrong-security-code"
+	if _, err := Run(context.Background(), opts); !errors.Is(err, dtlscoap.ErrUnavailable) {
+		t.Errorf("expected the connection to fail, got %v", err)
+	}
+	opts.SecurityCode = ""
+	if _, err := Run(context.Background(), opts); err == nil {
+		t.Error("expected an error without a security code")
+	}
+}
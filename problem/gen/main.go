@@ -0,0 +1,18 @@
+// Command gen writes the error catalog of the REST API to a markdown file.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/eriklupander/tradfri-go/problem"
+)
+
+func main() {
+	out := flag.String("o", "errors.md", "file to write the catalog to")
+	flag.Parse()
+	if err := os.WriteFile(*out, problem.Markdown(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,26 @@
+package problem
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+// Markdown renders the Catalog as the error documentation in docs/errors.md.
+func Markdown() []byte {
+	var b bytes.Buffer
+	b.WriteString("<!-- Code generated by go generate ./problem; DO NOT EDIT. -->\n\n")
+	b.WriteString("# REST API errors\n\n")
+	b.WriteString("Failed requests are answered with an [RFC 7807](https://www.rfc-editor.org/rfc/rfc7807) problem ")
+	b.WriteString("document of media type `" + ContentType + "`. The `type` member links to one of the sections ")
+	b.WriteString("below, `detail` describes the individual occurrence and `instance` is the request path.\n\n")
+	b.WriteString("| Type | Status |\n|---|---|\n")
+	for _, t := range Catalog {
+		fmt.Fprintf(&b, "| [%s](#%s) | %d %s |\n", t.Slug, t.Slug, t.Status, http.StatusText(t.Status))
+	}
+	for _, t := range Catalog {
+		fmt.Fprintf(&b, "\n## %s\n\n", t.Slug)
+		fmt.Fprintf(&b, "**%s** (%d)\n\n%s\n", t.Title, t.Status, t.Description)
+	}
+	return b.Bytes()
+}
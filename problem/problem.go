@@ -0,0 +1,137 @@
+// Package problem implements RFC 7807 problem details, the error format of the REST API. Every problem
+// refers to a Type of the Catalog, which is documented in docs/errors.md.
+package problem
+
+//go:generate go run ./gen -o ../docs/errors.md
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// typeBase is the location of the error catalog, problem type URIs point to its sections.
+const typeBase = "https://github.com/eriklupander/tradfri-go/blob/master/docs/errors.md#"
+
+// Type is a kind of problem, identified by its slug.
+type Type struct {
+	Slug        string
+	Title       string
+	Status      int
+	Description string
+}
+
+// URI returns the problem type URI, which links to the documentation of the type.
+func (t Type) URI() string {
+	return typeBase + t.Slug
+}
+
+var (
+	InvalidRequest = Type{"invalid-request", "Invalid request", http.StatusBadRequest,
+		"The request could not be parsed, e.g. because the body is not valid JSON or an identifier in the path is not a number."}
+	Unauthenticated = Type{"unauthenticated", "Unauthenticated", http.StatusUnauthorized,
+		"The request carries no API key or an unknown one. Pass the key as `Authorization: Bearer <key>` or `X-API-Key: <key>`."}
+	Forbidden = Type{"forbidden", "Forbidden", http.StatusForbidden,
+		"The API key lacks the scope required by the endpoint, or may not access the device or group."}
+	NotFound = Type{"not-found", "Not found", http.StatusNotFound,
+		"The gateway does not know the device, group or resource."}
+	Conflict = Type{"conflict", "Conflict", http.StatusConflict,
+		"The gateway refused the change in the current state of the resource, e.g. because the device does not support it."}
+	PayloadTooLarge = Type{"payload-too-large", "Payload too large", http.StatusRequestEntityTooLarge,
+		"The request body exceeds the size accepted by the endpoint."}
+	ValidationFailed = Type{"validation-failed", "Validation failed", http.StatusUnprocessableEntity,
+		"The request is well-formed but some fields have invalid values. The `errors` member lists each field with the reason."}
+	Internal = Type{"internal", "Internal error", http.StatusInternalServerError,
+		"An unexpected error occurred in tradfri-go. The server log has the details."}
+	GatewayError = Type{"gateway-error", "Gateway error", http.StatusBadGateway,
+		"The gateway answered with an unexpected CoAP error code, which is included in the detail."}
+	GatewayUnavailable = Type{"gateway-unavailable", "Gateway unavailable", http.StatusServiceUnavailable,
+		"The gateway could not be reached, or the connection to it is being shut down. Retrying later may succeed."}
+	GatewayTimeout = Type{"gateway-timeout", "Gateway timeout", http.StatusGatewayTimeout,
+		"The gateway did not respond in time."}
+)
+
+// Catalog lists all problem types, in the order they are documented.
+var Catalog = []Type{
+	InvalidRequest, Unauthenticated, Forbidden, NotFound, Conflict, PayloadTooLarge, ValidationFailed,
+	Internal, GatewayError, GatewayUnavailable, GatewayTimeout,
+}
+
+// FieldError describes why the value of a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// New returns a problem of type t.
+func New(t Type, detail string) Problem {
+	return Problem{Type: t.URI(), Title: t.Title, Status: t.Status, Detail: detail}
+}
+
+// Validation returns a validation-failed problem listing the rejected fields.
+func Validation(detail string, errs []FieldError) Problem {
+	p := New(ValidationFailed, detail)
+	p.Errors = errs
+	return p
+}
+
+// FromError returns the problem describing err, which is derived from the gateway's CoAP response code or
+// the state of the connection to the gateway. Unknown errors are internal errors.
+func FromError(err error) Problem {
+	var gwErr tradfri.GatewayError
+	if errors.As(err, &gwErr) {
+		switch gwErr.Code {
+		case coap.NotFound:
+			return New(NotFound, err.Error())
+		case coap.MethodNotAllowed, coap.PreconditionFailed:
+			return New(Conflict, err.Error())
+		case coap.ServiceUnavailable:
+			return New(GatewayUnavailable, err.Error())
+		case coap.GatewayTimeout:
+			return New(GatewayTimeout, err.Error())
+		}
+		return New(GatewayError, err.Error())
+	}
+	switch {
+	case errors.Is(err, dtlscoap.ErrUnavailable), errors.Is(err, dtlscoap.ErrClosed):
+		return New(GatewayUnavailable, err.Error())
+	case errors.Is(err, dtlscoap.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return New(GatewayTimeout, err.Error())
+	}
+	return New(Internal, err.Error())
+}
+
+// Write writes p as the response to r. The request path is used as the instance unless p has one.
+func Write(w http.ResponseWriter, r *http.Request, p Problem) {
+	if p.Instance == "" && r != nil {
+		p.Instance = r.URL.Path
+	}
+	if p.Status >= http.StatusInternalServerError {
+		slog.Error("request failed", slog.String("type", p.Type), slog.String("detail", p.Detail))
+	} else {
+		slog.Info("request rejected", slog.String("type", p.Type), slog.String("detail", p.Detail))
+	}
+	body, _ := json.Marshal(p)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(body)
+}
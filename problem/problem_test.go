@@ -0,0 +1,65 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{tradfri.GatewayError{Code: coap.NotFound, Path: "/15001/1"}, http.StatusNotFound},
+		{tradfri.GatewayError{Code: coap.MethodNotAllowed}, http.StatusConflict},
+		{tradfri.GatewayError{Code: coap.PreconditionFailed}, http.StatusConflict},
+		{tradfri.GatewayError{Code: coap.ServiceUnavailable}, http.StatusServiceUnavailable},
+		{tradfri.GatewayError{Code: coap.GatewayTimeout}, http.StatusGatewayTimeout},
+		{tradfri.GatewayError{Code: coap.BadRequest}, http.StatusBadGateway},
+		{fmt.Errorf("wrapped: %w", tradfri.GatewayError{Code: coap.NotFound}), http.StatusNotFound},
+		{fmt.Errorf("%w: connection refused", dtlscoap.ErrUnavailable), http.StatusServiceUnavailable},
+		{dtlscoap.ErrClosed, http.StatusServiceUnavailable},
+		{dtlscoap.ErrTimeout, http.StatusGatewayTimeout},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := FromError(tt.err); got.Status != tt.want {
+			t.Errorf("FromError(%v) = %d, want %d", tt.err, got.Status, tt.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, httptest.NewRequest(http.MethodPut, "/api/device/7/dimmer", nil),
+		Validation("invalid dimming request", []FieldError{{Field: "dimming", Message: "must be at most 254"}}))
+
+	if rec.Code != http.StatusUnprocessableEntity || rec.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var p Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &p)
+	if p.Type != ValidationFailed.URI() || p.Instance != "/api/device/7/dimmer" || len(p.Errors) != 1 {
+		t.Fatalf("unexpected problem %s", rec.Body.String())
+	}
+}
+
+func TestDocsUpToDate(t *testing.T) {
+	docs, err := os.ReadFile("../docs/errors.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(docs, Markdown()) {
+		t.Fatal("docs/errors.md is out of date, run go generate ./problem")
+	}
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/eriklupander/tradfri-go/validation"
+)
+
+// The functions below validate and apply state changes to a single device. They are shared by the
+// REST handlers and the WebSocket channel, so both accept and reject exactly the same input.
+
+// validationError signals that a request was rejected before it was sent to the gateway.
+type validationError struct {
+	msg    string
+	fields []problem.FieldError
+}
+
+func (e validationError) Error() string {
+	if len(e.fields) == 0 {
+		return e.msg
+	}
+	msgs := make([]string, 0, len(e.fields))
+	for _, f := range e.fields {
+		msgs = append(msgs, f.Field+" "+f.Message)
+	}
+	return strings.Join(msgs, ", ")
+}
+
+func invalid(format string, args ...any) error {
+	return validationError{msg: fmt.Sprintf(format, args...)}
+}
+
+// check validates req, returning a validationError listing every rejected field.
+func check(req any) error {
+	err := validation.Check(req)
+	var ve *validation.Error
+	if errors.As(err, &ve) {
+		return validationError{msg: "invalid request", fields: ve.Fields}
+	}
+	return err
+}
+
+func applyColorXY(client TradfriClient, deviceId int, req model.ColorXYRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDeviceColor(deviceId, req.X, req.Y)
+}
+
+func applyColorRGB(client TradfriClient, deviceId int, req model.RgbColorRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDeviceColorRGB(deviceId, req.RGBcolor)
+}
+
+func applyDimming(client TradfriClient, deviceId int, req model.DimmingRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDeviceDimming(deviceId, req.Dimming)
+}
+
+func applyPower(client TradfriClient, deviceId int, req model.PowerRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDevicePower(deviceId, req.Power)
+}
+
+func applyState(client TradfriClient, deviceId int, req model.StateRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDeviceState(deviceId, req.Power, req.Dimmer)
+}
+
+func applyPositioning(client TradfriClient, deviceId int, req model.PositioningRequest) (model.Result, error) {
+	if err := check(req); err != nil {
+		return model.Result{}, err
+	}
+	return client.PutDevicePositioning(deviceId, req.Positioning)
+}
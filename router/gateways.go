@@ -0,0 +1,150 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/gateway"
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/sync/errgroup"
+)
+
+const gatewayParam = "gateway"
+
+// Gateway is a named gateway, whose API is served below /api/gw/{gateway}.
+type Gateway struct {
+	Name   string
+	Client TradfriClient
+	// Watcher enables state subscriptions over the WebSocket of the gateway.
+	Watcher *tradfri.Watcher
+	// Health is reported by the health endpoints, nil reports the gateway as always healthy.
+	Health *health.Tracker
+}
+
+// gateways are the served gateways, the first one also serves the paths without a gateway name.
+var gateways []Gateway
+
+func lookupGateway(name string) (Gateway, bool) {
+	for _, g := range gateways {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return Gateway{}, false
+}
+
+// gatewayFor returns the gateway named by the path of r, or the default one.
+func gatewayFor(r *http.Request) Gateway {
+	if g, ok := lookupGateway(chi.URLParam(r, gatewayParam)); ok {
+		return g
+	}
+	return gateways[0]
+}
+
+// withGateway rejects requests for gateways that are not configured.
+func withGateway(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, gatewayParam)
+		if _, ok := lookupGateway(name); !ok {
+			problem.Write(w, r, problem.New(problem.NotFound, fmt.Sprintf("gateway %q is not configured", name)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gatewayRoutes serves the API of a single gateway, except the listings across gateways.
+func gatewayRoutes(r chi.Router) {
+	r.Use(withGateway)
+	for _, rt := range apiRouteDefs {
+		if !rt.acrossGateways() {
+			r.With(require(rt.scope), validateRequest(rt)).Method(rt.method, rt.pattern, rt.handler)
+		}
+	}
+}
+
+func listGateways(w http.ResponseWriter, r *http.Request) {
+	res := make([]model.GatewayV2, 0, len(gateways))
+	for i, g := range gateways {
+		res = append(res, model.GatewayV2{Name: g.Name, Default: i == 0})
+	}
+	respond(w, r, res, nil)
+}
+
+func listGatewayDevices(w http.ResponseWriter, r *http.Request) {
+	principal := auth.FromContext(r.Context())
+	perGateway, err := acrossGateways(r.Context(), func(c TradfriClient) ([]model.Device, error) { return c.ListDevices() })
+	res := make([]model.GatewayDeviceV2, 0)
+	for i, devices := range perGateway {
+		for _, d := range devices {
+			if principal.AllowsDevice(d.DeviceId) {
+				res = append(res, model.GatewayDeviceV2{Gateway: gateways[i].Name, DeviceV2: model.ToDeviceV2(d)})
+			}
+		}
+	}
+	respond(w, r, res, err)
+}
+
+func listGatewayGroups(w http.ResponseWriter, r *http.Request) {
+	principal := auth.FromContext(r.Context())
+	perGateway, err := acrossGateways(r.Context(), func(c TradfriClient) ([]model.Group, error) { return c.ListGroups() })
+	res := make([]model.GatewayGroupV2, 0)
+	for i, groups := range perGateway {
+		for _, g := range groups {
+			if principal.AllowsGroup(g.DeviceId) {
+				res = append(res, model.GatewayGroupV2{Gateway: gateways[i].Name, GroupV2: model.ToGroupV2(g)})
+			}
+		}
+	}
+	respond(w, r, res, err)
+}
+
+// acrossGateways queries all gateways concurrently and returns their results in the order of gateways. The
+// listing fails if any gateway fails, naming the gateway.
+func acrossGateways[T any](ctx context.Context, list func(c TradfriClient) ([]T, error)) ([][]T, error) {
+	res := make([][]T, len(gateways))
+	g := errgroup.Group{}
+	for i, gw := range gateways {
+		g.Go(func() error {
+			c := gw.Client
+			if tc, ok := c.(*tradfri.Client); ok {
+				c = tc.WithContext(ctx)
+			}
+			items, err := list(c)
+			if err != nil {
+				return gatewayError{name: gw.Name, err: err}
+			}
+			res[i] = items
+			return nil
+		})
+	}
+	return res, g.Wait()
+}
+
+// gatewayError names the gateway an error of a listing across gateways came from, keeping the error for
+// its mapping to a problem.
+type gatewayError struct {
+	name string
+	err  error
+}
+
+func (e gatewayError) Error() string {
+	return fmt.Sprintf("gateway %q: %v", e.name, e.err)
+}
+
+func (e gatewayError) Unwrap() error {
+	return e.err
+}
+
+func defaultGateways(client TradfriClient, opts Options) []Gateway {
+	if len(opts.Gateways) > 0 {
+		return opts.Gateways
+	}
+	return []Gateway{{Name: gateway.DefaultName, Client: client, Watcher: opts.Watcher, Health: opts.Health}}
+}
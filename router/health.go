@@ -0,0 +1,81 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/go-chi/chi/v5"
+)
+
+// healthTrackers returns the health trackers of the served gateways by name. Gateways without a tracker are
+// reported as always live and ready.
+func healthTrackers() map[string]*health.Tracker {
+	trackers := map[string]*health.Tracker{}
+	for _, g := range gateways {
+		if g.Health != nil {
+			trackers[g.Name] = g.Health
+		}
+	}
+	return trackers
+}
+
+// serveHealth responds "OK" while the server is ready, for healthchecks that only look at the status code.
+func serveHealth(w http.ResponseWriter, r *http.Request) {
+	if !health.CheckAll(healthTrackers()).Ready {
+		http.Error(w, "NOT READY", http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("OK"))
+}
+
+func serveLiveness(w http.ResponseWriter, r *http.Request) {
+	status := health.CheckAll(healthTrackers())
+	writeHealth(w, status, status.Live)
+}
+
+func serveReadiness(w http.ResponseWriter, r *http.Request) {
+	status := health.CheckAll(healthTrackers())
+	writeHealth(w, status, status.Ready)
+}
+
+// gatewayReport returns the health report of the gateway named by the path of r.
+func gatewayReport(r *http.Request) health.Report {
+	if tracker := gatewayFor(r).Health; tracker != nil {
+		return tracker.Check()
+	}
+	return health.Report{Live: true, Ready: true}
+}
+
+func serveGatewayLiveness(w http.ResponseWriter, r *http.Request) {
+	report := gatewayReport(r)
+	writeHealth(w, report, report.Live)
+}
+
+func serveGatewayReadiness(w http.ResponseWriter, r *http.Request) {
+	report := gatewayReport(r)
+	writeHealth(w, report, report.Ready)
+}
+
+// writeHealth writes the status or report, with status 503 unless ok. Unlike respondWithJSON it does not log,
+// since probes call frequently.
+func writeHealth(w http.ResponseWriter, v any, ok bool) {
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+	}
+	body, _ := json.Marshal(v)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// healthRoutes serves the health of the server and of each gateway, below /health/{live,ready}/{gateway}.
+func healthRoutes(r chi.Router) {
+	r.Get("/health", serveHealth)
+	r.Get("/health/live", serveLiveness)
+	r.Get("/health/ready", serveReadiness)
+	r.With(withGateway).Get("/health/live/{"+gatewayParam+"}", serveGatewayLiveness)
+	r.With(withGateway).Get("/health/ready/{"+gatewayParam+"}", serveGatewayReadiness)
+}
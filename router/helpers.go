@@ -2,32 +2,66 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
+
+	"github.com/eriklupander/tradfri-go/problem"
 )
 
-func respond(w http.ResponseWriter, payload interface{}, err error) {
+// maxBody limits the size of JSON request bodies.
+const maxBody = 1 << 20
+
+func respond(w http.ResponseWriter, r *http.Request, payload interface{}, err error) {
 	if err != nil {
-		respondWithError(w, 500, err.Error())
-	} else {
-		respondWithJSON(w, 200, payload)
+		respondWithError(w, r, err)
+		return
+	}
+	respondWithJSON(w, 200, payload)
+}
+
+// respondWithError responds with the problem describing err. Validation errors are reported with the
+// rejected fields, other errors are mapped from the gateway's response.
+func respondWithError(w http.ResponseWriter, r *http.Request, err error) {
+	var ve validationError
+	if errors.As(err, &ve) {
+		problem.Write(w, r, problem.Validation(ve.Error(), ve.fields))
+		return
 	}
+	problem.Write(w, r, problem.FromError(err))
 }
 
-func badRequest(w http.ResponseWriter, err error) {
+func badRequest(w http.ResponseWriter, r *http.Request, err error) {
 	slog.Error("error processing request body", slog.Any("error", err))
-	respondWithError(w, http.StatusBadRequest, err.Error())
+	problem.Write(w, r, problem.New(problem.InvalidRequest, err.Error()))
 }
 
-func badIdentifierError(w http.ResponseWriter, val interface{}, err error) {
+func badIdentifierError(w http.ResponseWriter, r *http.Request, val interface{}, err error) {
 	slog.Error("bad request, could not parse identifier", slog.Any("identifier", val), slog.Any("error", err))
-	respondWithError(w, http.StatusBadRequest, err.Error())
+	problem.Write(w, r, problem.New(problem.InvalidRequest, fmt.Sprintf("identifier %v is not a number", val)))
 }
 
-// respondwithError return error message
-func respondWithError(w http.ResponseWriter, code int, msg string) {
-	respondWithJSON(w, code, map[string]string{"message": msg})
+// decode reads the JSON request body into v. If the body cannot be read or parsed, a problem is written
+// and false is returned.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		problem.Write(w, r, problem.New(problem.PayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBody)))
+		return false
+	}
+	if err != nil {
+		badRequest(w, r, fmt.Errorf("reading request body failed: %w", err))
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		badRequest(w, r, fmt.Errorf("request body is not valid JSON: %w", err))
+		return false
+	}
+	return true
 }
 
 // respondWithJSON write json response format
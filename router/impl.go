@@ -1,9 +1,12 @@
 package router
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/batch"
 	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
 	"github.com/go-chi/chi/v5"
 	"io"
 	"net/http"
@@ -13,136 +16,135 @@ import (
 func setColorXY(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-	xStr := chi.URLParam(r, "x")
-	yStr := chi.URLParam(r, "y")
-	x, _ := strconv.Atoi(xStr)
-	y, _ := strconv.Atoi(yStr)
-	res, err := tradfriClient.PutDeviceColor(deviceId, x, y)
-	respond(w, res, err)
+	colorReq := model.ColorXYRequest{}
+	if !decode(w, r, &colorReq) {
+		return
+	}
+	res, err := applyColorXY(clientFor(r), deviceId, colorReq)
+	respond(w, r, res, err)
 }
 
 func setColorRGBHex(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-
-	body, _ := io.ReadAll(r.Body)
-
 	rgbColorRequest := model.RgbColorRequest{}
-	if err := json.Unmarshal(body, &rgbColorRequest); err != nil {
-		badRequest(w, err)
+	if !decode(w, r, &rgbColorRequest) {
 		return
 	}
-	result, err := tradfriClient.PutDeviceColorRGB(deviceId, rgbColorRequest.RGBcolor)
-	respond(w, result, err)
+	result, err := applyColorRGB(clientFor(r), deviceId, rgbColorRequest)
+	respond(w, r, result, err)
 }
 
 func setDimming(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-
-	body, _ := io.ReadAll(r.Body)
-
 	dimmingRequest := model.DimmingRequest{}
-	if err := json.Unmarshal(body, &dimmingRequest); err != nil {
-		badRequest(w, err)
+	if !decode(w, r, &dimmingRequest) {
 		return
 	}
-	res, err := tradfriClient.PutDeviceDimming(deviceId, dimmingRequest.Dimming)
-	respond(w, res, err)
+	res, err := applyDimming(clientFor(r), deviceId, dimmingRequest)
+	respond(w, r, res, err)
 }
 
 func setPower(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-	body, _ := io.ReadAll(r.Body)
-
 	powerRequest := model.PowerRequest{}
-	if err := json.Unmarshal(body, &powerRequest); err != nil {
-		badRequest(w, err)
+	if !decode(w, r, &powerRequest) {
 		return
 	}
-	res, err := tradfriClient.PutDevicePower(deviceId, powerRequest.Power)
-	respond(w, res, err)
+	res, err := applyPower(clientFor(r), deviceId, powerRequest)
+	respond(w, r, res, err)
 }
 
 func setState(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-	body, _ := io.ReadAll(r.Body)
-
 	stateReq := model.StateRequest{}
-	if err := json.Unmarshal(body, &stateReq); err != nil {
-		badRequest(w, err)
+	if !decode(w, r, &stateReq) {
 		return
 	}
-	res, err := tradfriClient.PutDeviceState(deviceId, stateReq.Power, stateReq.Dimmer)
-	respond(w, res, err)
+	res, err := applyState(clientFor(r), deviceId, stateReq)
+	respond(w, r, res, err)
 }
 
 func setPositioning(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-
-	body, _ := io.ReadAll(r.Body)
 	positioningReq := model.PositioningRequest{}
-	if err := json.Unmarshal(body, &positioningReq); err != nil {
-		badRequest(w, fmt.Errorf("unmarshalling of positioning JSON body failed: %w", err))
+	if !decode(w, r, &positioningReq) {
 		return
 	}
-
-	res, err := tradfriClient.PutDevicePositioning(deviceId, positioningReq.Positioning)
-	respond(w, res, err)
+	res, err := applyPositioning(clientFor(r), deviceId, positioningReq)
+	respond(w, r, res, err)
 }
 
-func listGroups(w http.ResponseWriter, _ *http.Request) {
-	groups, err := tradfriClient.ListGroups()
+func listGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := clientFor(r).ListGroups()
+	principal := auth.FromContext(r.Context())
 	groupResponses := make([]model.GroupResponse, 0)
 	for _, g := range groups {
+		if !principal.AllowsGroup(g.DeviceId) {
+			continue
+		}
 		groupResponses = append(groupResponses, model.ToGroupResponse(g))
 	}
-	respond(w, groupResponses, err)
+	respond(w, r, groupResponses, err)
 }
 
 func getGroup(w http.ResponseWriter, r *http.Request) {
 	groupId, err := paramToInt(chi.URLParam(r, groupParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, groupParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
 		return
 	}
 
-	group, err := tradfriClient.GetGroup(groupId)
-	respond(w, model.ToGroupResponse(group), err)
+	group, err := clientFor(r).GetGroup(groupId)
+	respond(w, r, model.ToGroupResponse(group), err)
 }
 
 func getDevicesOnGroup(w http.ResponseWriter, r *http.Request) {
 	groupId, err := paramToInt(chi.URLParam(r, groupParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, groupParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
 		return
 	}
 
-	group, _ := tradfriClient.GetGroup(groupId)
+	client := clientFor(r)
+	principal := auth.FromContext(r.Context())
+	group, err := client.GetGroup(groupId)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
 	devices := make([]interface{}, 0)
 	for _, deviceID := range group.Content.DeviceList.DeviceIds {
-		device, _ := tradfriClient.GetDevice(deviceID)
+		if !principal.AllowsDevice(deviceID) {
+			continue
+		}
+		device, err := client.GetDevice(deviceID)
+		if err != nil {
+			respondWithError(w, r, err)
+			return
+		}
 		devices = append(devices, model.ToDeviceResponse(device))
 	}
 	respondWithJSON(w, 200, devices)
@@ -151,22 +153,84 @@ func getDevicesOnGroup(w http.ResponseWriter, r *http.Request) {
 func getDeviceIdsOnGroup(w http.ResponseWriter, r *http.Request) {
 	groupId, err := paramToInt(chi.URLParam(r, groupParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, groupParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
 		return
 	}
 
-	group, _ := tradfriClient.GetGroup(groupId)
+	group, err := clientFor(r).GetGroup(groupId)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	principal := auth.FromContext(r.Context())
 	deviceIds := make([]int, 0)
-	deviceIds = append(deviceIds, group.Content.DeviceList.DeviceIds...)
+	for _, deviceID := range group.Content.DeviceList.DeviceIds {
+		if principal.AllowsDevice(deviceID) {
+			deviceIds = append(deviceIds, deviceID)
+		}
+	}
 	respondWithJSON(w, 200, deviceIds)
 }
 
 func getDevice(w http.ResponseWriter, r *http.Request) {
 	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
 	if err != nil {
-		badIdentifierError(w, chi.URLParam(r, deviceParam), err)
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
 		return
 	}
-	device, _ := tradfriClient.GetDevice(deviceId)
-	respondWithJSON(w, 200, model.ToDeviceResponse(device))
+	device, err := clientFor(r).GetDevice(deviceId)
+	respond(w, r, model.ToDeviceResponse(device), err)
+}
+
+// runBatch validates the operations of a model.BatchRequest and executes them concurrently, responding with
+// the result of each operation.
+func runBatch(w http.ResponseWriter, r *http.Request) {
+	req := model.BatchRequest{}
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := batch.Validate(req); err != nil {
+		var ve batch.ValidationError
+		field := "operations"
+		if errors.As(err, &ve) && ve.Index >= 0 {
+			field = fmt.Sprintf("operations[%d]", ve.Index)
+		}
+		problem.Write(w, r, problem.Validation("invalid batch", []problem.FieldError{{Field: field, Message: ve.Msg}}))
+		return
+	}
+	if err := batch.Authorize(auth.FromContext(r.Context()), req); err != nil {
+		problem.Write(w, r, problem.New(problem.Forbidden, err.Error()))
+		return
+	}
+	respondWithJSON(w, http.StatusOK, batch.Run(r.Context(), clientFor(r), req))
+}
+
+// maxRawPayload limits the size of request bodies forwarded by the raw passthrough.
+const maxRawPayload = 64 << 10
+
+// rawRequest forwards the request method, path and body to the gateway path following /api/raw/ and
+// responds with the CoAP code, options and payload of the gateway's response. With ?readable=true the
+// body may use readable JSON keys and the response payload is returned with readable keys.
+func rawRequest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRawPayload+1))
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	if len(body) > maxRawPayload {
+		problem.Write(w, r, problem.New(problem.PayloadTooLarge, fmt.Sprintf("payload exceeds %d bytes", maxRawPayload)))
+		return
+	}
+	readable, _ := strconv.ParseBool(r.URL.Query().Get("readable"))
+	if readable && len(body) > 0 {
+		if body, err = model.NumericKeys(body); err != nil {
+			badRequest(w, r, err)
+			return
+		}
+	}
+	res, err := clientFor(r).RawRequest(r.Method, "/"+chi.URLParam(r, "*"), body)
+	if err == nil && readable {
+		res.Payload = model.ReadableOrRaw([]byte(res.Payload))
+	}
+	respond(w, r, res, err)
 }
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/metrics"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// instrument records request metrics labelled with the matched route pattern rather than the raw path,
+// which keeps the number of time series independent of device and group IDs.
+func instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.ObserveHTTPRequest(r.Method, routePattern(r), status, time.Since(start))
+	})
+}
+
+// trace starts a span for every request, continuing any trace propagated by the caller.
+func trace(next http.Handler) http.Handler {
+	return otelhttp.NewMiddleware("tradfri-go", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		// called again once the request has been routed, when the route pattern is known
+		return r.Method + " " + routePattern(r)
+	}))(next)
+}
+
+func routePattern(r *http.Request) string {
+	if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
+
+// queryKeyAllowed is the context key marking the routes that accept the api_key query parameter.
+type queryKeyAllowed struct{}
+
+// allowQueryKey marks a route as accepting the API key in the api_key query parameter, since browsers cannot
+// set headers on WebSocket connections. It must precede authenticate.
+func allowQueryKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), queryKeyAllowed{}, true)))
+	})
+}
+
+// authenticate resolves the API key of the request to a principal, rejecting requests without a valid key.
+// Keys are passed as "Authorization: Bearer <key>" or "X-API-Key: <key>", or as an api_key query parameter on
+// the routes marked by allowQueryKey.
+func authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authenticator == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := auth.TokenFromHeader(r.Header.Get("Authorization"))
+		if key == "" {
+			key = r.Header.Get("X-API-Key")
+		}
+		if allowed, _ := r.Context().Value(queryKeyAllowed{}).(bool); key == "" && allowed {
+			key = r.URL.Query().Get("api_key")
+		}
+		principal, err := authenticator.Authenticate(key)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="tradfri-go"`)
+			problem.Write(w, r, problem.New(problem.Unauthenticated, err.Error()))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+	})
+}
+
+// require rejects requests whose principal lacks the scope or may not access the device or group in the URL.
+// It must be applied to routes, since the URL parameters are only known once the request has been routed.
+func require(scope auth.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := auth.FromContext(r.Context())
+			if !principal.Allows(scope) {
+				problem.Write(w, r, problem.New(problem.Forbidden, "API key lacks the "+scope.String()+" scope"))
+				return
+			}
+			if id, err := paramToInt(chi.URLParam(r, deviceParam)); err == nil && !principal.AllowsDevice(id) {
+				problem.Write(w, r, problem.New(problem.Forbidden, "API key may not access this device"))
+				return
+			}
+			if id, err := paramToInt(chi.URLParam(r, groupParam)); err == nil && !principal.AllowsGroup(id) {
+				problem.Write(w, r, problem.New(problem.Forbidden, "API key may not access this group"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,424 @@
+package router
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/eriklupander/tradfri-go/validation"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+)
+
+// apiRoute is an endpoint below /api. apiRoutes registers the endpoints and the OpenAPI document is generated
+// from the same definitions, so the documentation can't drift from the router.
+type apiRoute struct {
+	method  string
+	pattern string
+	id      string
+	summary string
+	scope   auth.Scope
+	// request and response are instances of the body models, or a *openapi3.SchemaRef for bodies that are
+	// not described by a single type. A nil request means that the endpoint takes no body.
+	request  any
+	response any
+	query    openapi3.Parameters
+	// raw marks bodies that are forwarded as is, they are documented but not validated.
+	raw     bool
+	handler http.HandlerFunc
+}
+
+// device is the schema of the device representations returned by model.ToDeviceResponse.
+var device = &openapi3.SchemaRef{Value: &openapi3.Schema{OneOf: openapi3.SchemaRefs{
+	openapi3.NewSchemaRef("#/components/schemas/BulbResponse", nil),
+	openapi3.NewSchemaRef("#/components/schemas/BlindResponse", nil),
+	openapi3.NewSchemaRef("#/components/schemas/PowerPlugResponse", nil),
+}}}
+
+var gatewayParameter = openapi3.NewPathParameter(gatewayParam).
+	WithDescription("Name of a configured gateway, see listGateways.").WithSchema(openapi3.NewStringSchema())
+
+var (
+	anyJSON  = &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+	readable = &openapi3.Parameter{Name: "readable", In: openapi3.ParameterInQuery,
+		Description: "Translate the numeric IKEA keys of the payloads to readable names and back.",
+		Schema:      openapi3.NewBoolSchema().NewRef()}
+)
+
+var apiRouteDefs = []apiRoute{
+	{method: http.MethodGet, pattern: "/groups", id: "listGroups", summary: "List groups", scope: auth.ScopeRead, response: []model.GroupResponse{}, handler: listGroups},
+	{method: http.MethodGet, pattern: "/groups/{groupId}", id: "getGroup", summary: "Get a group", scope: auth.ScopeRead, response: model.GroupResponse{}, handler: getGroup},
+	{method: http.MethodGet, pattern: "/groups/{groupId}/deviceIds", id: "getDeviceIdsOnGroup", summary: "List the device IDs of a group", scope: auth.ScopeRead, response: []int{}, handler: getDeviceIdsOnGroup},
+	{method: http.MethodGet, pattern: "/groups/{groupId}/devices", id: "getDevicesOnGroup", summary: "List the devices of a group", scope: auth.ScopeRead, response: &openapi3.SchemaRef{Value: openapi3.NewArraySchema().WithItems(device.Value)}, handler: getDevicesOnGroup},
+	{method: http.MethodGet, pattern: "/device/{deviceId}", id: "getDevice", summary: "Get a device", scope: auth.ScopeRead, response: device, handler: getDevice},
+	{method: http.MethodPut, pattern: "/device/{deviceId}/color", id: "setColorXY", summary: "Set the color of a bulb in CIE 1931 coordinates", scope: auth.ScopeControl, request: model.ColorXYRequest{}, response: model.Result{}, handler: setColorXY},
+	{method: http.MethodPut, pattern: "/device/{deviceId}/rgb", id: "setColorRGB", summary: "Set the color of a bulb as hex RGB", scope: auth.ScopeControl, request: model.RgbColorRequest{}, response: model.Result{}, handler: setColorRGBHex},
+	{method: http.MethodPut, pattern: "/device/{deviceId}/dimmer", id: "setDimming", summary: "Set the dimmer level of a bulb", scope: auth.ScopeControl, request: model.DimmingRequest{}, response: model.Result{}, handler: setDimming},
+	{method: http.MethodPut, pattern: "/device/{deviceId}/power", id: "setPower", summary: "Turn a device on or off", scope: auth.ScopeControl, request: model.PowerRequest{}, response: model.Result{}, handler: setPower},
+	{method: http.MethodPut, pattern: "/device/{deviceId}", id: "setState", summary: "Set power and dimmer level of a bulb", scope: auth.ScopeControl, request: model.StateRequest{}, response: model.Result{}, handler: setState},
+	{method: http.MethodPut, pattern: "/device/{deviceId}/position", id: "setPositioning", summary: "Set the position of a blind", scope: auth.ScopeControl, request: model.PositioningRequest{}, response: model.Result{}, handler: setPositioning},
+	{method: http.MethodPost, pattern: "/batch", id: "runBatch", summary: "Execute several operations concurrently", scope: auth.ScopeControl, request: model.BatchRequest{}, response: model.BatchResponse{}, handler: runBatch},
+	{method: http.MethodGet, pattern: "/v2/devices", id: "listDevicesV2", summary: "List all devices", scope: auth.ScopeRead, response: []model.DeviceV2{}, handler: listDevicesV2},
+	{method: http.MethodGet, pattern: "/v2/devices/{deviceId}", id: "getDeviceV2", summary: "Get a device", scope: auth.ScopeRead, response: model.DeviceV2{}, handler: getDeviceV2},
+	{method: http.MethodPatch, pattern: "/v2/devices/{deviceId}", id: "patchDeviceV2", summary: "Change the state of a device", scope: auth.ScopeControl, request: model.DevicePatch{}, response: model.DeviceV2{}, handler: patchDeviceV2},
+	{method: http.MethodGet, pattern: "/v2/groups", id: "listGroupsV2", summary: "List groups", scope: auth.ScopeRead, response: []model.GroupV2{}, handler: listGroupsV2},
+	{method: http.MethodGet, pattern: "/v2/groups/{groupId}", id: "getGroupV2", summary: "Get a group", scope: auth.ScopeRead, response: model.GroupV2{}, handler: getGroupV2},
+	{method: http.MethodGet, pattern: "/v2/groups/{groupId}/devices", id: "getGroupDevicesV2", summary: "List the devices of a group", scope: auth.ScopeRead, response: []model.DeviceV2{}, handler: getGroupDevicesV2},
+	{method: http.MethodGet, pattern: "/v2/groups/{groupId}/scenes", id: "listGroupScenesV2", summary: "List the scenes of a group", scope: auth.ScopeRead, response: []model.SceneV2{}, handler: listGroupScenesV2},
+	{method: http.MethodPatch, pattern: "/v2/groups/{groupId}", id: "patchGroupV2", summary: "Change the state of all devices of a group", scope: auth.ScopeControl, request: model.GroupPatch{}, response: model.GroupV2{}, handler: patchGroupV2},
+	{method: http.MethodGet, pattern: "/gateways", id: "listGateways", summary: "List the configured gateways", scope: auth.ScopeRead, response: []model.GatewayV2{}, handler: listGateways},
+	{method: http.MethodGet, pattern: "/gateways/devices", id: "listGatewayDevices", summary: "List the devices of all gateways", scope: auth.ScopeRead, response: []model.GatewayDeviceV2{}, handler: listGatewayDevices},
+	{method: http.MethodGet, pattern: "/gateways/groups", id: "listGatewayGroups", summary: "List the groups of all gateways", scope: auth.ScopeRead, response: []model.GatewayGroupV2{}, handler: listGatewayGroups},
+	{method: http.MethodGet, pattern: "/raw/*", id: "rawGet", summary: "Send a GET request to the gateway", scope: auth.ScopeAdmin, response: model.RawResponse{}, query: openapi3.Parameters{{Value: readable}}, raw: true, handler: rawRequest},
+	{method: http.MethodPut, pattern: "/raw/*", id: "rawPut", summary: "Send a PUT request to the gateway", scope: auth.ScopeAdmin, request: anyJSON, response: model.RawResponse{}, query: openapi3.Parameters{{Value: readable}}, raw: true, handler: rawRequest},
+	{method: http.MethodPost, pattern: "/raw/*", id: "rawPost", summary: "Send a POST request to the gateway", scope: auth.ScopeAdmin, request: anyJSON, response: model.RawResponse{}, query: openapi3.Parameters{{Value: readable}}, raw: true, handler: rawRequest},
+	{method: http.MethodDelete, pattern: "/raw/*", id: "rawDelete", summary: "Send a DELETE request to the gateway", scope: auth.ScopeAdmin, response: model.RawResponse{}, query: openapi3.Parameters{{Value: readable}}, raw: true, handler: rawRequest},
+}
+
+// acrossGateways reports whether the endpoint spans all gateways, which is not served per gateway.
+func (rt apiRoute) acrossGateways() bool {
+	return strings.HasPrefix(rt.pattern, "/gateways")
+}
+
+// specPath converts a chi route pattern to an OpenAPI path template.
+func specPath(pattern string) string {
+	return "/api" + strings.Replace(pattern, "/*", "/{path}", 1)
+}
+
+// openAPI returns the OpenAPI document of the REST API, both as loaded document with resolved references
+// and in its serialized form.
+var openAPI = sync.OnceValues(func() (*openapi3.T, []byte) {
+	doc, err := buildOpenAPI(apiRouteDefs)
+	if err != nil {
+		panic(fmt.Sprintf("generating the OpenAPI document failed: %v", err))
+	}
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("serializing the OpenAPI document failed: %v", err))
+	}
+	loaded, err := openapi3.NewLoader().LoadFromData(body)
+	if err != nil {
+		panic(fmt.Sprintf("loading the OpenAPI document failed: %v", err))
+	}
+	return loaded, body
+})
+
+func buildOpenAPI(defs []apiRoute) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "tradfri-go REST API",
+			Version: "1.0",
+			Description: "Query and control the devices and groups of an IKEA Trådfri gateway. " +
+				"Errors are RFC 7807 problem documents, see https://github.com/eriklupander/tradfri-go/blob/master/docs/errors.md. " +
+				"The paths below /api address the default gateway, the same paths below /api/gw/{gateway} a named gateway.",
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"bearer": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme().WithBearerFormat("API key")},
+				"apiKey": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-API-Key")},
+			},
+		},
+		// anonymous access is allowed unless API keys are configured
+		Security: openapi3.SecurityRequirements{{"bearer": {}}, {"apiKey": {}}, {}},
+	}
+
+	gen := openapi3gen.NewGenerator(
+		openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{ExportComponentSchemas: true}),
+		openapi3gen.SchemaCustomizer(customizeSchema))
+	// the generator only exports nested structs as component schemas, body models are exported here
+	schemaFor := func(v any) (*openapi3.SchemaRef, error) {
+		if ref, ok := v.(*openapi3.SchemaRef); ok {
+			return ref, nil
+		}
+		ref, err := gen.NewSchemaRefForValue(v, doc.Components.Schemas)
+		if err != nil || ref.Ref != "" || reflect.TypeOf(v).Kind() != reflect.Struct {
+			return ref, err
+		}
+		name := reflect.TypeOf(v).Name()
+		doc.Components.Schemas[name] = ref
+		return openapi3.NewSchemaRef("#/components/schemas/"+name, nil), nil
+	}
+	for _, v := range []any{problem.Problem{}, model.BulbResponse{}, model.BlindResponse{}, model.PowerPlugResponse{}} {
+		if _, err := schemaFor(v); err != nil {
+			return nil, err
+		}
+	}
+	problemResponse := openapi3.NewResponse().
+		WithDescription("Problem document, see the error catalog for the types.").
+		WithContent(openapi3.Content{problem.ContentType: openapi3.NewMediaType().
+			WithSchemaRef(openapi3.NewSchemaRef("#/components/schemas/Problem", nil))})
+
+	for _, rt := range defs {
+		op := openapi3.NewOperation()
+		op.OperationID = rt.id
+		op.Summary = rt.summary
+		op.Description = "Requires the `" + rt.scope.String() + "` scope when API keys are configured."
+		op.Parameters = append(pathParameters(rt.pattern), rt.query...)
+		if rt.request != nil {
+			schema, err := schemaFor(rt.request)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", rt.id, err)
+			}
+			op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+				WithRequired(!rt.raw).WithJSONSchemaRef(schema)}
+		}
+		schema, err := schemaFor(rt.response)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rt.id, err)
+		}
+		op.Responses = openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+				WithDescription(rt.summary).WithJSONSchemaRef(schema)}),
+			openapi3.WithName("default", problemResponse))
+		doc.AddOperation(specPath(rt.pattern), rt.method, op)
+		if !rt.acrossGateways() {
+			gwOp := *op
+			gwOp.OperationID = rt.id + "OnGateway"
+			gwOp.Parameters = append(openapi3.Parameters{{Value: gatewayParameter}}, op.Parameters...)
+			doc.AddOperation(specPath("/gw/{gateway}"+rt.pattern), rt.method, &gwOp)
+		}
+	}
+	return doc, nil
+}
+
+func pathParameters(pattern string) openapi3.Parameters {
+	var params openapi3.Parameters
+	for _, segment := range strings.Split(pattern, "/") {
+		switch {
+		case segment == "*":
+			params = append(params, &openapi3.ParameterRef{Value: openapi3.NewPathParameter("path").
+				WithDescription("Gateway path, e.g. 15001/65538.").WithSchema(openapi3.NewStringSchema())})
+		case strings.HasPrefix(segment, "{"):
+			name := strings.Trim(segment, "{}")
+			params = append(params, &openapi3.ParameterRef{Value: openapi3.NewPathParameter(name).
+				WithSchema(openapi3.NewIntegerSchema())})
+		}
+	}
+	return params
+}
+
+// requiredFields returns the JSON names of the fields of t that are not omitted when empty, including those
+// of embedded structs.
+func requiredFields(t reflect.Type) []string {
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct:
+			required = append(required, requiredFields(f.Type)...)
+		case name != "" && name != "-" && !strings.Contains(opts, "omitempty"):
+			required = append(required, name)
+		}
+	}
+	return required
+}
+
+// customizeSchema derives schema constraints from the validate tags of the models, so that the OpenAPI
+// document enforces the same rules as the validator. Fields are required unless they are omitted when empty.
+func customizeSchema(_ string, t reflect.Type, tag reflect.StructTag, s *openapi3.Schema) error {
+	if t.Kind() == reflect.Struct && s.Type.Is(openapi3.TypeObject) {
+		s.Required = append(s.Required, requiredFields(t)...)
+	}
+	for _, rule := range strings.Split(tag.Get("validate"), ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return err
+			}
+			switch {
+			case s.Type.Is(openapi3.TypeArray) && key == "min":
+				s.MinItems = uint64(n)
+			case s.Type.Is(openapi3.TypeArray):
+				s.MaxItems = openapi3.Ptr(uint64(n))
+			case s.Type.Includes(openapi3.TypeNumber) || s.Type.Includes(openapi3.TypeInteger):
+				if key == "min" {
+					s.Min = &n
+				} else {
+					s.Max = &n
+				}
+			}
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return err
+				}
+				s.Enum = append(s.Enum, n)
+			}
+		case "rgbhex":
+			s.Pattern = validation.RGBPattern.String()
+		}
+	}
+	return nil
+}
+
+var validationOptions = &openapi3filter.Options{MultiError: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+// validateRequest checks the parameters and body of requests to rt against the OpenAPI document before they
+// reach the handler.
+func validateRequest(rt apiRoute) func(http.Handler) http.Handler {
+	doc, _ := openAPI()
+	path := specPath(rt.pattern)
+	pathItem := doc.Paths.Value(path)
+	route := &routers.Route{Spec: doc, Path: path, PathItem: pathItem, Method: rt.method, Operation: pathItem.GetOperation(rt.method)}
+	opts := validationOptions
+	if rt.raw {
+		opts = &openapi3filter.Options{ExcludeRequestBody: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			params := map[string]string{}
+			urlParams := chi.RouteContext(r.Context()).URLParams
+			for i, k := range urlParams.Keys {
+				if k == "*" {
+					k = "path"
+				}
+				params[k] = urlParams.Values[i]
+			}
+			if !rt.raw {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+			}
+			// the handlers parse bodies as JSON whatever the Content-Type, so validate them as JSON too
+			vr := r.WithContext(r.Context())
+			vr.Header = r.Header.Clone()
+			vr.Header.Set("Content-Type", "application/json")
+			err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request: vr, PathParams: params, Route: route, Options: opts})
+			// the validator buffers the body it has read
+			r.Body = vr.Body
+			if err != nil {
+				problem.Write(w, r, requestProblem(err))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestProblem converts a request validation error to a problem. Bodies violating the schema fail
+// validation with the offending fields, other errors are invalid requests.
+func requestProblem(err error) problem.Problem {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return problem.New(problem.PayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBody))
+	}
+	var fields []problem.FieldError
+	if schemaErrors(err, &fields) && len(fields) > 0 {
+		ve := validationError{fields: fields}
+		return problem.Validation(ve.Error(), fields)
+	}
+	return problem.New(problem.InvalidRequest, err.Error())
+}
+
+// schemaErrors collects the schema violations of the body in fields, reporting false if err has other errors.
+func schemaErrors(err error, fields *[]problem.FieldError) bool {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		ok := true
+		for _, err := range e {
+			ok = schemaErrors(err, fields) && ok
+		}
+		return ok
+	case *openapi3filter.RequestError:
+		return e.RequestBody != nil && e.Err != nil && schemaErrors(e.Err, fields)
+	case *openapi3.SchemaError:
+		*fields = append(*fields, problem.FieldError{Field: fieldPath(e.JSONPointer()), Message: schemaMessage(e)})
+		return true
+	}
+	return false
+}
+
+// fieldPath formats a JSON pointer into the body like the validator does, e.g. operations[0].dimmer.
+func fieldPath(pointer []string) string {
+	if len(pointer) == 0 {
+		return "body"
+	}
+	var b strings.Builder
+	for _, p := range pointer {
+		if _, err := strconv.Atoi(p); err == nil {
+			b.WriteString("[" + p + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// schemaMessage phrases the common schema violations like fieldMessage.
+func schemaMessage(e *openapi3.SchemaError) string {
+	switch e.SchemaField {
+	case "required":
+		return "is required"
+	case "minimum":
+		return fmt.Sprintf("must be at least %v, got %v", *e.Schema.Min, e.Value)
+	case "maximum":
+		return fmt.Sprintf("must be at most %v, got %v", *e.Schema.Max, e.Value)
+	case "enum":
+		values := make([]string, 0, len(e.Schema.Enum))
+		for _, v := range e.Schema.Enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		return fmt.Sprintf("must be one of %s, got %v", strings.Join(values, ", "), e.Value)
+	case "pattern":
+		if e.Schema.Pattern == validation.RGBPattern.String() {
+			return fmt.Sprintf("must be a 6 digit hex string, got %q", e.Value)
+		}
+	}
+	return e.Reason
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	_, body := openAPI()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+//go:embed swagger-ui
+var swaggerUIFiles embed.FS
+
+// swaggerUI are the files of swagger-ui-dist 5.18.2, served below /api/docs/ so the docs work without internet
+// access.
+var swaggerUI, _ = fs.Sub(swaggerUIFiles, "swagger-ui")
+
+// docsPage renders the OpenAPI document with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>tradfri-go REST API</title>
+  <link rel="stylesheet" href="/api/docs/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/api/docs/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+func serveDocs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(docsPage))
+}
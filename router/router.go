@@ -1,11 +1,17 @@
 package router
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 	"time"
 
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/grpc_server"
+	"github.com/eriklupander/tradfri-go/health"
+	"github.com/eriklupander/tradfri-go/metrics"
 	"github.com/eriklupander/tradfri-go/model"
 	"github.com/eriklupander/tradfri-go/tradfri"
 	"github.com/go-chi/chi/v5"
@@ -22,52 +28,143 @@ type TradfriClient interface {
 	GetDevice(deviceId int) (model.Device, error)
 	GetGroup(groupId int) (model.Group, error)
 	ListGroups() ([]model.Group, error)
+	ListDevices() ([]model.Device, error)
+	ListScenes(groupId int) ([]model.Scene, error)
 	PutDeviceColor(deviceId int, x, y int) (model.Result, error)
 	PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error)
 	PutDeviceDimming(deviceId int, dimming int) (model.Result, error)
 	PutDevicePower(deviceId int, power int) (model.Result, error)
 	PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error)
 	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+	PutOutletPower(deviceId int, power int) (model.Result, error)
+	PutGroupPower(groupId int, power int) (model.Result, error)
+	PutGroupDimming(groupId int, dimming int) (model.Result, error)
+	PutGroupScene(groupId int, sceneId int) (model.Result, error)
+	RawRequest(method, path string, payload []byte) (model.RawResponse, error)
 }
 
-var tradfriClient TradfriClient
+// clientFor returns the client of the gateway addressed by r. The gateway client is bound to the request
+// context so that CoAP exchanges become part of the request's trace.
+func clientFor(r *http.Request) TradfriClient {
+	client := gatewayFor(r).Client
+	if c, ok := client.(*tradfri.Client); ok {
+		return c.WithContext(r.Context())
+	}
+	return client
+}
+
+// authenticator resolves API keys, nil disables authentication.
+var authenticator *auth.Authenticator
+
+// Options holds the optional collaborators of the REST server.
+type Options struct {
+	// Watcher enables state subscriptions over the /api/ws WebSocket.
+	Watcher *tradfri.Watcher
+	// Gateways serves several named gateways, the first one being the default. Unless set, the client and
+	// watcher are served as the gateway named "default".
+	Gateways []Gateway
+	// Authenticator enables API key authentication, nil allows anonymous access to everything.
+	Authenticator *auth.Authenticator
+	// Connect serves TradfriService over the Connect and gRPC-Web protocols, see grpc_server.NewConnectHandler.
+	Connect http.Handler
+	// Health makes the health endpoints report the connectivity of the gateway served unless Gateways is set,
+	// nil reports it as always healthy.
+	Health *health.Tracker
+	// UI serves the web UI below /ui/, see webui.Handler. The root path then redirects to it.
+	UI http.Handler
+	// TLSConfig makes the server serve HTTPS instead of plain HTTP.
+	TLSConfig *tls.Config
+	// ShutdownTimeout bounds how long in-flight requests are drained on shutdown, defaults to 10 seconds.
+	ShutdownTimeout time.Duration
+}
 
-// newRouter builds and returns the chi router wired to the provided client.
-func newRouter(client TradfriClient) chi.Router {
-	tradfriClient = client
+// newRouter builds and returns the chi router wired to the provided client and options.
+func newRouter(client TradfriClient, opts Options) chi.Router {
+	gateways = defaultGateways(client, opts)
+	authenticator = opts.Authenticator
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(trace)
+	r.Use(instrument)
 
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte("OK"))
-	})
+	// long-lived WebSocket connections must not be subject to the request timeout below
+	r.With(allowQueryKey, authenticate, require(auth.ScopeRead)).Get("/api/ws", serveWs)
+	r.With(allowQueryKey, authenticate, require(auth.ScopeRead), withGateway).Get("/api/gw/{gateway}/ws", serveWs)
+	if opts.Connect != nil {
+		// authenticates itself, like the gRPC server, and serves streams
+		r.Handle(grpc_server.ConnectPath+"*", opts.Connect)
+	}
 
-	r.Route("/api", func(r chi.Router) {
-		r.Get("/groups", listGroups)
-		r.Get("/groups/{groupId}", getGroup)
-		r.Get("/groups/{groupId}/deviceIds", getDeviceIdsOnGroup)
-		r.Get("/groups/{groupId}/devices", getDevicesOnGroup)
-		r.Get("/device/{deviceId}", getDevice)
-		r.Put("/device/{deviceId}/color", setColorXY)
-		r.Put("/device/{deviceId}/rgb", setColorRGBHex)
-		r.Put("/device/{deviceId}/dimmer", setDimming)
-		r.Put("/device/{deviceId}/power", setPower)
-		r.Put("/device/{deviceId}", setState)
-		r.Put("/device/{deviceId}/position", setPositioning)
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(60 * time.Second))
+		healthRoutes(r)
+		r.With(authenticate, require(auth.ScopeRead)).Handle("/metrics", metrics.Handler())
+		r.Get("/api/openapi.json", serveOpenAPI)
+		r.Get("/api/docs", serveDocs)
+		r.Handle("/api/docs/*", http.StripPrefix("/api/docs/", http.FileServerFS(swaggerUI)))
+		r.Route("/api", apiRoutes)
+		if opts.UI != nil {
+			// the UI is public, it asks for an API key when the API requires one
+			r.Handle("/ui", opts.UI)
+			r.Handle("/ui/*", opts.UI)
+			r.Get("/", http.RedirectHandler("/ui/", http.StatusFound).ServeHTTP)
+		}
 	})
 	return r
 }
 
-// SetupChi sets up our HTTP router/muxer using Chi, a pointer to a Client must be passed.
-func SetupChi(client *tradfri.Client, listenAddress string) {
-	r := newRouter(client)
-	// Blocks here!
-	if err := http.ListenAndServe(listenAddress, r); err != nil {
-		slog.Error("error starting HTTP server", slog.Any("error", err))
-		os.Exit(1)
+func apiRoutes(r chi.Router) {
+	r.Use(authenticate)
+	for _, rt := range apiRouteDefs {
+		r.With(require(rt.scope), validateRequest(rt)).Method(rt.method, rt.pattern, rt.handler)
+	}
+	r.Route("/gw/{gateway}", gatewayRoutes)
+}
+
+// SetupChi sets up our HTTP router/muxer using Chi, a pointer to a Client must be passed, which is ignored if
+// Options.Gateways is set. It serves requests until ctx is cancelled and then shuts the server down
+// gracefully, draining in-flight requests and closing WebSocket connections. An error is returned if the
+// server could not be started or failed.
+func SetupChi(ctx context.Context, client *tradfri.Client, listenAddress string, opts Options) error {
+	srv := &http.Server{Addr: listenAddress, Handler: newRouter(client, opts), TLSConfig: opts.TLSConfig}
+	if opts.Connect != nil {
+		// gRPC clients need HTTP/2, which plain HTTP only offers with prior knowledge
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetHTTP2(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	srv.RegisterOnShutdown(closeWebSockets)
+
+	errs := make(chan error, 1)
+	go func() {
+		if opts.TLSConfig != nil {
+			// the certificate is provided by the TLS config
+			errs <- srv.ListenAndServeTLS("", "")
+		} else {
+			errs <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("HTTP server: %w", err)
+	case <-ctx.Done():
+	}
+
+	timeout := opts.ShutdownTimeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	slog.Info("shutting down HTTP server")
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return fmt.Errorf("HTTP server shutdown: %w", err)
 	}
+	return nil
 }
@@ -2,35 +2,72 @@ package router
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/health"
 	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 // mockClient implements TradfriClient without any DTLS or gateway dependency.
 type mockClient struct {
-	device model.Device
-	group  model.Group
-	groups []model.Group
-	result model.Result
-	err    error
-}
-
-func (m *mockClient) GetDevice(_ int) (model.Device, error)                        { return m.device, m.err }
-func (m *mockClient) GetGroup(_ int) (model.Group, error)                          { return m.group, m.err }
-func (m *mockClient) ListGroups() ([]model.Group, error)                           { return m.groups, m.err }
-func (m *mockClient) PutDeviceColor(_ int, _, _ int) (model.Result, error)         { return m.result, m.err }
-func (m *mockClient) PutDeviceColorRGB(_ int, _ string) (model.Result, error)      { return m.result, m.err }
-func (m *mockClient) PutDeviceDimming(_ int, _ int) (model.Result, error)          { return m.result, m.err }
-func (m *mockClient) PutDevicePower(_ int, _ int) (model.Result, error)            { return m.result, m.err }
-func (m *mockClient) PutDeviceState(_ int, _, _ int) (model.Result, error)         { return m.result, m.err }
-func (m *mockClient) PutDevicePositioning(_ int, _ float32) (model.Result, error)  { return m.result, m.err }
+	device  model.Device
+	devices []model.Device
+	group   model.Group
+	groups  []model.Group
+	scenes  []model.Scene
+	result  model.Result
+	raw     model.RawResponse
+	err     error
+
+	rawMethod, rawPath, rawPayload string
+}
+
+func (m *mockClient) ListDevices() ([]model.Device, error) { return m.devices, m.err }
+
+func (m *mockClient) GetDevice(_ int) (model.Device, error)                   { return m.device, m.err }
+func (m *mockClient) GetGroup(_ int) (model.Group, error)                     { return m.group, m.err }
+func (m *mockClient) ListGroups() ([]model.Group, error)                      { return m.groups, m.err }
+func (m *mockClient) ListScenes(_ int) ([]model.Scene, error)                 { return m.scenes, m.err }
+func (m *mockClient) PutDeviceColor(_ int, _, _ int) (model.Result, error)    { return m.result, m.err }
+func (m *mockClient) PutDeviceColorRGB(_ int, _ string) (model.Result, error) { return m.result, m.err }
+func (m *mockClient) PutDeviceDimming(_ int, _ int) (model.Result, error)     { return m.result, m.err }
+func (m *mockClient) PutDevicePower(_ int, _ int) (model.Result, error)       { return m.result, m.err }
+func (m *mockClient) PutDeviceState(_ int, _, _ int) (model.Result, error)    { return m.result, m.err }
+func (m *mockClient) PutDevicePositioning(_ int, _ float32) (model.Result, error) {
+	return m.result, m.err
+}
+
+func (m *mockClient) PutOutletPower(_ int, _ int) (model.Result, error) { return m.result, m.err }
+
+func (m *mockClient) PutGroupPower(_ int, _ int) (model.Result, error)   { return m.result, m.err }
+func (m *mockClient) PutGroupDimming(_ int, _ int) (model.Result, error) { return m.result, m.err }
+func (m *mockClient) PutGroupScene(_ int, _ int) (model.Result, error)   { return m.result, m.err }
+
+func (m *mockClient) RawRequest(method, path string, payload []byte) (model.RawResponse, error) {
+	m.rawMethod, m.rawPath, m.rawPayload = method, path, string(payload)
+	return m.raw, m.err
+}
 
 func newTestRouter(mc *mockClient) http.Handler {
-	return newRouter(mc)
+	return newRouter(mc, Options{})
 }
 
 func TestHealth(t *testing.T) {
@@ -45,6 +82,95 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestHealth_Gateway(t *testing.T) {
+	tracker := health.NewTracker()
+	r := newRouter(&mockClient{}, Options{Health: tracker})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// the DTLS session is not up yet
+	if rec := get("/health"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	if rec := get("/health/live"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	rec := get("/health/ready")
+	status := health.Status{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &status)
+	report := status.Gateways["default"]
+	if rec.Code != http.StatusServiceUnavailable || report.Session != health.SessionDown || len(report.Reasons) != 1 {
+		t.Fatalf("expected 503 with the session down, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	tracker.SetSession(health.SessionUp)
+	tracker.ObserveCall(nil)
+	for _, path := range []string{"/health", "/health/live", "/health/ready", "/health/ready/default"} {
+		if rec := get(path); rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", path, rec.Code)
+		}
+	}
+
+	tracker.SetSession(health.SessionClosed)
+	if rec := get("/health/live"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
+
+func TestHealth_Gateways(t *testing.T) {
+	house, garage := health.NewTracker(), health.NewTracker()
+	house.SetSession(health.SessionUp)
+	r := newRouter(nil, Options{Gateways: []Gateway{
+		{Name: "house", Client: &mockClient{}, Health: house},
+		{Name: "garage", Client: &mockClient{}, Health: garage},
+	}})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// a gateway that is down makes the server not ready, and is named in the body
+	rec := get("/health/ready")
+	status := health.Status{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &status)
+	if rec.Code != http.StatusServiceUnavailable || !status.Gateways["house"].Ready || status.Gateways["garage"].Ready {
+		t.Fatalf("expected the server not to be ready without the garage, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := get("/health/live"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the server to stay live, got %d", rec.Code)
+	}
+	if rec := get("/health/ready/house"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the house to be ready, got %d", rec.Code)
+	}
+	if rec := get("/health/ready/garage"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected the garage not to be ready, got %d", rec.Code)
+	}
+	if rec := get("/health/live/attic"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected an unknown gateway to be rejected, got %d", rec.Code)
+	}
+
+	garage.SetSession(health.SessionUp)
+	if rec := get("/health"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the server to be ready with all gateways, got %d", rec.Code)
+	}
+}
+
+func TestConnectMounted(t *testing.T) {
+	var path string
+	r := newRouter(&mockClient{}, Options{Connect: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	})})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/grpc_server.TradfriService/GetDevice", strings.NewReader(`{"id":7}`)))
+	if path != "/grpc_server.TradfriService/GetDevice" {
+		t.Fatalf("expected the Connect handler to serve the procedure, got path %q and status %d", path, rec.Code)
+	}
+}
+
 func TestListGroups(t *testing.T) {
 	mc := &mockClient{
 		groups: []model.Group{
@@ -197,3 +323,599 @@ func TestBadGroupId(t *testing.T) {
 		t.Fatalf("expected 400, got %d", rec.Code)
 	}
 }
+
+func TestSetDimming_OutOfRange(t *testing.T) {
+	r := newTestRouter(&mockClient{})
+	body, _ := json.Marshal(model.DimmingRequest{Dimming: 300})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/device/7/dimmer", bytes.NewReader(body)))
+	if rec.Code != http.StatusUnprocessableEntity || rec.Header().Get("Content-Type") != problem.ContentType {
+		t.Fatalf("expected 422 problem, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var p problem.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &p)
+	if len(p.Errors) != 1 || p.Errors[0].Field != "dimming" || p.Instance != "/api/device/7/dimmer" {
+		t.Fatalf("unexpected problem %s", rec.Body.String())
+	}
+}
+
+func TestSetState_FieldErrors(t *testing.T) {
+	r := newTestRouter(&mockClient{})
+	rec := httptest.NewRecorder()
+	body := `{"power": 2, "dimmer": -1, "rgbcolor": "red"}`
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/device/7", strings.NewReader(body)))
+	var p problem.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &p)
+	if rec.Code != http.StatusUnprocessableEntity || len(p.Errors) != 3 {
+		t.Fatalf("expected 422 with three field errors, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSetColorXY(t *testing.T) {
+	mc := &mockClient{result: model.Result{Msg: "2.04 Changed"}}
+	rec := httptest.NewRecorder()
+	newTestRouter(mc).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/device/7/color", strings.NewReader(`{"x": 30015, "y": 26870}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMalformedBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter(&mockClient{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/device/7/power", strings.NewReader(`{"power":`)))
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != problem.ContentType {
+		t.Fatalf("expected 400 problem, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{tradfri.GatewayError{Code: coap.NotFound, Path: "/15001/7"}, http.StatusNotFound},
+		{tradfri.GatewayError{Code: coap.MethodNotAllowed, Path: "/15001/7"}, http.StatusConflict},
+		{fmt.Errorf("%w: no route to host", dtlscoap.ErrUnavailable), http.StatusServiceUnavailable},
+		{dtlscoap.ErrTimeout, http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		newTestRouter(&mockClient{err: tt.err}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/device/7", nil))
+		if rec.Code != tt.want {
+			t.Errorf("%v: expected %d, got %d", tt.err, tt.want, rec.Code)
+		}
+	}
+}
+
+func TestWebSocketCommand(t *testing.T) {
+	conn := dialTestWebSocket(t, newTestRouter(&mockClient{result: model.Result{Msg: "2.04 Changed"}}))
+	writeWs(t, conn, model.WsRequest{ID: "1", Type: "power", DeviceId: 7, Power: 1})
+	resp := readWs(t, conn)
+	if resp.ID != "1" || resp.Type != "ack" || resp.Result == nil || resp.Result.Msg != "2.04 Changed" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestWebSocketCommand_Invalid(t *testing.T) {
+	conn := dialTestWebSocket(t, newTestRouter(&mockClient{}))
+	writeWs(t, conn, model.WsRequest{ID: "2", Type: "dimmer", DeviceId: 7, Dimmer: 300})
+	resp := readWs(t, conn)
+	if resp.ID != "2" || resp.Type != "error" {
+		t.Fatalf("expected error response, got %+v", resp)
+	}
+}
+
+func TestWebSocketSubscribe(t *testing.T) {
+	var devices []model.Device
+	_ = json.Unmarshal([]byte(`[{"9003":7,"9001":"Plug","3312":[{"5850":1}]},{"9003":8,"9001":"Other","3312":[{"5850":0}]}]`), &devices)
+	mc := &mockClient{devices: devices}
+	watcher := tradfri.NewWatcher(mc, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	conn := dialTestWebSocket(t, newRouter(mc, Options{Watcher: watcher}))
+	writeWs(t, conn, model.WsRequest{ID: "3", Type: "subscribe", DeviceIds: []int{7}})
+	if resp := readWs(t, conn); resp.ID != "3" || resp.Type != "ack" {
+		t.Fatalf("expected ack, got %+v", resp)
+	}
+	resp := readWs(t, conn)
+	plug, _ := resp.Device.(map[string]interface{})
+	if resp.Type != "device" || plug == nil || plug["deviceMetadata"].(map[string]interface{})["id"] != 7.0 {
+		t.Fatalf("expected event for device 7, got %+v", resp)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if err := conn.ReadJSON(&resp); err == nil {
+		t.Fatalf("expected no further events, got %+v", resp)
+	}
+}
+
+func TestWebSocketSubscribe_NoWatcher(t *testing.T) {
+	conn := dialTestWebSocket(t, newTestRouter(&mockClient{}))
+	writeWs(t, conn, model.WsRequest{ID: "4", Type: "subscribe"})
+	if resp := readWs(t, conn); resp.Type != "error" {
+		t.Fatalf("expected error, got %+v", resp)
+	}
+}
+
+func TestWebSocketClosedOnShutdown(t *testing.T) {
+	srv := httptest.NewUnstartedServer(newTestRouter(&mockClient{}))
+	srv.Config.RegisterOnShutdown(closeWebSockets)
+	srv.Start()
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/api/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := srv.Config.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Fatalf("expected a going away close message, got %v", err)
+	}
+}
+
+func dialTestWebSocket(t *testing.T, h http.Handler) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/api/ws", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func writeWs(t *testing.T, conn *websocket.Conn, req model.WsRequest) {
+	t.Helper()
+	if err := conn.WriteJSON(req); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func readWs(t *testing.T, conn *websocket.Conn) model.WsResponse {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	resp := model.WsResponse{}
+	if err := conn.ReadJSON(&resp); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return resp
+}
+
+func TestMetrics(t *testing.T) {
+	r := newTestRouter(&mockClient{})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/device/7", nil))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	expected := `tradfri_http_requests_total{method="GET",route="/api/device/{deviceId}",status="200"}`
+	if !strings.Contains(rec.Body.String(), expected) {
+		t.Fatalf("expected metrics to contain %s", expected)
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	r := newTestRouter(&mockClient{})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/device/7", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "GET /api/device/{deviceId}" {
+		t.Fatalf("expected a single span named after the route, got %+v", spans)
+	}
+}
+
+func TestRawRequest(t *testing.T) {
+	mc := &mockClient{raw: model.RawResponse{Code: "2.04", CodeName: "Changed", Options: []model.RawOption{}}}
+	req := httptest.NewRequest(http.MethodPut, "/api/raw/15001/65538", strings.NewReader(`{"3311":[{"5850":1}]}`))
+	rec := httptest.NewRecorder()
+	newTestRouter(mc).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if mc.rawMethod != "PUT" || mc.rawPath != "/15001/65538" || mc.rawPayload != `{"3311":[{"5850":1}]}` {
+		t.Fatalf("unexpected forwarded request %s %s %s", mc.rawMethod, mc.rawPath, mc.rawPayload)
+	}
+	var resp model.RawResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Code != "2.04" || resp.CodeName != "Changed" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestRawRequest_Readable(t *testing.T) {
+	mc := &mockClient{raw: model.RawResponse{Code: "2.05", CodeName: "Content", Payload: `{"5850":1,"9003":65538}`}}
+	req := httptest.NewRequest(http.MethodPut, "/api/raw/15001/65538?readable=true", strings.NewReader(`{"lightControl":[{"power":1}]}`))
+	rec := httptest.NewRecorder()
+	newTestRouter(mc).ServeHTTP(rec, req)
+
+	if mc.rawPayload != `{"3311":[{"5850":1}]}` {
+		t.Fatalf("expected numeric keys to be forwarded, got %s", mc.rawPayload)
+	}
+	var resp model.RawResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Payload != `{"instanceId":65538,"power":1}` {
+		t.Fatalf("expected readable keys in the response, got %s", resp.Payload)
+	}
+}
+
+func TestRawRequest_TooLarge(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/raw/15011/9063", bytes.NewReader(make([]byte, maxRawPayload+1)))
+	rec := httptest.NewRecorder()
+	newTestRouter(&mockClient{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rec.Code)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	body := `{"operations": [{"deviceId": 65538, "power": 1, "dimmer": 100}, {"groupId": 131073, "sceneId": 196608}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	newTestRouter(&mockClient{result: model.Result{Msg: "Changed"}}).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp model.BatchResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Results) != 2 || resp.Results[0].Status != model.BatchStatusOK || resp.Results[1].Result != "Changed" {
+		t.Fatalf("unexpected response %s", rec.Body.String())
+	}
+}
+
+func TestBatch_Invalid(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(`{"operations": [{"deviceId": 1, "dimmer": 300}]}`))
+	rec := httptest.NewRecorder()
+	newTestRouter(&mockClient{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"field":"operations[0].dimmer"`) {
+		t.Fatalf("expected 422 naming the operation, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func newAuthTestRouter(t *testing.T, mc *mockClient) http.Handler {
+	t.Helper()
+	a, err := auth.New([]auth.Key{
+		{Name: "reader", Key: "read-key", Scope: "read", Groups: []int{1}},
+		{Name: "controller", Key: "control-key", Scope: "control", Devices: []int{7}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newRouter(mc, Options{Authenticator: a})
+}
+
+func TestAuth(t *testing.T) {
+	r := newAuthTestRouter(t, &mockClient{result: model.Result{Msg: "2.04 Changed"}})
+	body := `{"power": 1}`
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		header string
+		value  string
+		code   int
+	}{
+		{"no key", http.MethodGet, "/api/device/7", "", "", http.StatusUnauthorized},
+		{"unknown key", http.MethodGet, "/api/device/7", "Authorization", "Bearer nope", http.StatusUnauthorized},
+		{"read with bearer", http.MethodGet, "/api/device/7", "Authorization", "Bearer read-key", http.StatusOK},
+		{"read with x-api-key", http.MethodGet, "/api/device/7", "X-API-Key", "read-key", http.StatusOK},
+		{"read key cannot control", http.MethodPut, "/api/device/7/power", "X-API-Key", "read-key", http.StatusForbidden},
+		{"read key group allow-list", http.MethodGet, "/api/groups/2", "X-API-Key", "read-key", http.StatusForbidden},
+		{"control", http.MethodPut, "/api/device/7/power", "X-API-Key", "control-key", http.StatusOK},
+		{"control device allow-list", http.MethodPut, "/api/device/8/power", "X-API-Key", "control-key", http.StatusForbidden},
+		{"health is public", http.MethodGet, "/health", "", "", http.StatusOK},
+		{"metrics require a key", http.MethodGet, "/metrics", "", "", http.StatusUnauthorized},
+		{"raw requires admin", http.MethodGet, "/api/raw/15001", "X-API-Key", "control-key", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(body))
+		if tc.header != "" {
+			req.Header.Set(tc.header, tc.value)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != tc.code {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.code, rec.Code)
+		}
+	}
+}
+
+func TestAuth_Batch(t *testing.T) {
+	r := newAuthTestRouter(t, &mockClient{})
+	for deviceId, code := range map[int]int{7: http.StatusOK, 8: http.StatusForbidden} {
+		body := fmt.Sprintf(`{"operations": [{"deviceId": %d, "power": 1}]}`, deviceId)
+		req := httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(body))
+		req.Header.Set("X-API-Key", "control-key")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != code {
+			t.Errorf("device %d: expected %d, got %d", deviceId, code, rec.Code)
+		}
+	}
+}
+
+func TestAuth_ListGroupsFiltered(t *testing.T) {
+	r := newAuthTestRouter(t, &mockClient{groups: []model.Group{{DeviceId: 1}, {DeviceId: 2}}})
+	req := httptest.NewRequest(http.MethodGet, "/api/groups", nil)
+	req.Header.Set("X-API-Key", "read-key")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	var resp []model.GroupResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp) != 1 || resp[0].Id != 1 {
+		t.Fatalf("expected only group 1, got %+v", resp)
+	}
+}
+
+func TestAuth_GroupDeviceIdsFiltered(t *testing.T) {
+	group := model.Group{DeviceId: 1}
+	group.Content.DeviceList.DeviceIds = []int{7, 8}
+	r := newAuthTestRouter(t, &mockClient{group: group})
+	req := httptest.NewRequest(http.MethodGet, "/api/groups/1/deviceIds", nil)
+	req.Header.Set("X-API-Key", "control-key")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	var resp []int
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if rec.Code != http.StatusOK || len(resp) != 1 || resp[0] != 7 {
+		t.Fatalf("expected only device 7, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAuth_WebSocket(t *testing.T) {
+	srv := httptest.NewServer(newAuthTestRouter(t, &mockClient{}))
+	defer srv.Close()
+	for _, path := range []string{"/api/ws", "/api/gw/default/ws"} {
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") + path
+		if _, resp, err := websocket.DefaultDialer.Dial(url, nil); err == nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("%s: expected 401 without key, got %v", path, err)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?api_key=read-key", nil)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		writeWs(t, conn, model.WsRequest{ID: "1", Type: "power", DeviceId: 7, Power: 1})
+		if resp := readWs(t, conn); resp.Type != "error" {
+			t.Fatalf("%s: expected read-only key to be rejected, got %+v", path, resp)
+		}
+		writeWs(t, conn, model.WsRequest{ID: "2", Type: "blink", DeviceId: 7})
+		if resp := readWs(t, conn); resp.Type != "error" || !strings.Contains(resp.Error, "unknown message type") {
+			t.Fatalf("%s: expected an unknown type to be reported as such, got %+v", path, resp)
+		}
+		_ = conn.Close()
+	}
+}
+
+func TestAuth_QueryKeyOnlyForWebSockets(t *testing.T) {
+	r := newAuthTestRouter(t, &mockClient{})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/device?api_key=read-key", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the query parameter to be ignored, got %d", rec.Code)
+	}
+}
+
+func TestOpenAPI_MatchesRouter(t *testing.T) {
+	doc, _ := openAPI()
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+	documented := 0
+	for _, item := range doc.Paths.Map() {
+		documented += len(item.Operations())
+	}
+	routes := 0
+	err := chi.Walk(newTestRouter(&mockClient{}).(chi.Router), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		switch {
+		case !strings.HasPrefix(route, "/api/"), route == "/api/ws", route == "/api/gw/{gateway}/ws", route == "/api/docs", route == "/api/docs/*", route == "/api/openapi.json":
+			return nil
+		}
+		routes++
+		item := doc.Paths.Value(specPath(strings.TrimPrefix(route, "/api")))
+		if item == nil || item.GetOperation(method) == nil {
+			t.Errorf("%s %s is not documented", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if routes != documented {
+		t.Fatalf("%d routes but %d documented operations", routes, documented)
+	}
+}
+
+func TestOpenAPI_PublicDocs(t *testing.T) {
+	r := newAuthTestRouter(t, &mockClient{})
+	for _, path := range []string{"/api/openapi.json", "/api/docs", "/api/docs/swagger-ui.css", "/api/docs/swagger-ui-bundle.js"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	tests := []struct {
+		path, body string
+		field      string
+	}{
+		{"/api/device/7/dimmer", `{}`, "dimming"},
+		{"/api/device/7/dimmer", `{"dimming": "bright"}`, "dimming"},
+		{"/api/device/7/rgb", `{"rgbcolor": "red"}`, "rgbcolor"},
+		{"/api/device/7/color", `{"x": 30015}`, "y"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+		// curl's default, bodies are parsed as JSON regardless
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		newTestRouter(&mockClient{}).ServeHTTP(rec, req)
+		var p problem.Problem
+		_ = json.Unmarshal(rec.Body.Bytes(), &p)
+		if rec.Code != http.StatusUnprocessableEntity || len(p.Errors) != 1 || p.Errors[0].Field != tt.field {
+			t.Errorf("%s %s: expected 422 for %s, got %d %s", tt.path, tt.body, tt.field, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func mustDevice(t *testing.T, payload string) model.Device {
+	t.Helper()
+	var d model.Device
+	if err := json.Unmarshal([]byte(payload), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+const bulbJSON = `{"3":{"0":"IKEA of Sweden","1":"TRADFRI bulb E27 CWS opal 600lm","2":"","3":"2.3.086","6":1},
+	"3311":[{"5706":"f1e0b5","5709":30015,"5710":26870,"5850":1,"5851":127}],
+	"5750":2,"9001":"Färgglad","9002":1550000000,"9003":65538,"9019":1,"9020":1700000000}`
+
+func TestV2_GetDevice(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestRouter(&mockClient{device: mustDevice(t, bulbJSON)}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/devices/65538", nil))
+	var d model.DeviceV2
+	_ = json.Unmarshal(rec.Body.Bytes(), &d)
+	if rec.Code != http.StatusOK || d.Kind != model.KindLight || d.Light == nil || d.Light.Brightness != 50 || d.Metadata.FirmwareVersion != "2.3.086" {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestV2_PatchDevice(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"light": {"on": true, "brightness": 40, "color": {"hex": "8f2686"}}}`, http.StatusOK},
+		{`{"light": {"color": {"x": 0.45, "y": 0.41}}}`, http.StatusOK},
+		{`{"light": {"brightness": 101}}`, http.StatusUnprocessableEntity},
+		{`{"light": {"color": {"hex": "8f2686", "x": 0.45}}}`, http.StatusUnprocessableEntity},
+		{`{}`, http.StatusUnprocessableEntity},
+		{`{"blind": {"position": 50}}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		mc := &mockClient{device: mustDevice(t, bulbJSON), result: model.Result{Msg: "Changed"}}
+		rec := httptest.NewRecorder()
+		newTestRouter(mc).ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v2/devices/65538", strings.NewReader(tt.body)))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d %s", tt.body, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestV2_PatchGroup(t *testing.T) {
+	mc := &mockClient{group: model.Group{DeviceId: 131073, Name: "Kitchen", Power: 1, Dimmer: 254}}
+	rec := httptest.NewRecorder()
+	newTestRouter(mc).ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v2/groups/131073", strings.NewReader(`{"on": true, "brightness": 100}`)))
+	var g model.GroupV2
+	_ = json.Unmarshal(rec.Body.Bytes(), &g)
+	if rec.Code != http.StatusOK || g.Name != "Kitchen" || g.Brightness != 100 || !g.On {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestV2_ListGroupScenes(t *testing.T) {
+	mc := &mockClient{scenes: []model.Scene{{SceneId: 196608, Name: "Relax"}, {SceneId: 196609, Name: "Focus"}}}
+	rec := httptest.NewRecorder()
+	newTestRouter(mc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/groups/131073/scenes", nil))
+	var scenes []model.SceneV2
+	_ = json.Unmarshal(rec.Body.Bytes(), &scenes)
+	if rec.Code != http.StatusOK || len(scenes) != 2 || scenes[0].Id != 196608 || scenes[1].Name != "Focus" {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUI(t *testing.T) {
+	ui := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ui " + r.URL.Path)) })
+	r := newRouter(&mockClient{}, Options{UI: ui})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/ui/" {
+		t.Fatalf("expected a redirect to the UI, got %d %v", rec.Code, rec.Header())
+	}
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ui/app.js", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ui /ui/app.js" {
+		t.Fatalf("expected the UI to serve its files, got %d %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	newTestRouter(&mockClient{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ui/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected no UI unless configured, got %d", rec.Code)
+	}
+}
+
+func newGatewaysTestRouter(t *testing.T) http.Handler {
+	return newRouter(nil, Options{Gateways: []Gateway{
+		{Name: "house", Client: &mockClient{device: mustDevice(t, bulbJSON), devices: []model.Device{mustDevice(t, bulbJSON)}}},
+		{Name: "garage", Client: &mockClient{devices: []model.Device{{DeviceId: 65550, Name: "Door"}}, groups: []model.Group{{DeviceId: 131073, Name: "Garage"}}}},
+	}})
+}
+
+func TestGateways(t *testing.T) {
+	r := newGatewaysTestRouter(t)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	var gateways []model.GatewayV2
+	rec := get("/api/gateways")
+	_ = json.Unmarshal(rec.Body.Bytes(), &gateways)
+	if rec.Code != http.StatusOK || len(gateways) != 2 || !gateways[0].Default || gateways[1].Name != "garage" {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+
+	var devices []model.GatewayDeviceV2
+	rec = get("/api/gateways/devices")
+	_ = json.Unmarshal(rec.Body.Bytes(), &devices)
+	if rec.Code != http.StatusOK || len(devices) != 2 || devices[0].Gateway != "house" || devices[1].Gateway != "garage" || devices[1].Name != "Door" {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+
+	var groups []model.GatewayGroupV2
+	rec = get("/api/gateways/groups")
+	_ = json.Unmarshal(rec.Body.Bytes(), &groups)
+	if rec.Code != http.StatusOK || len(groups) != 1 || groups[0].Gateway != "garage" || groups[0].Name != "Garage" {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec = get("/api/gw/garage/v2/groups"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"Garage"`) {
+		t.Fatalf("expected the groups of the garage, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = get("/api/v2/devices/65538"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Färgglad") {
+		t.Fatalf("expected the default gateway, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = get("/api/gw/shed/v2/devices"); rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), `\"shed\"`) {
+		t.Fatalf("expected 404 for an unknown gateway, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = get("/api/gw/garage/gateways"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the listings across gateways only at the top level, got %d", rec.Code)
+	}
+}
+
+func TestGateways_ListingFails(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newRouter(nil, Options{Gateways: []Gateway{
+		{Name: "house", Client: &mockClient{}},
+		{Name: "garage", Client: &mockClient{err: dtlscoap.ErrTimeout}},
+	}}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/gateways/devices", nil))
+	if rec.Code != http.StatusGatewayTimeout || !strings.Contains(rec.Body.String(), `gateway \"garage\"`) {
+		t.Fatalf("expected a timeout naming the garage, got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,241 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handlers of the /api/v2 endpoints, which represent devices and groups by the unified model.DeviceV2 and
+// model.GroupV2 schemas and change them with PATCH requests.
+
+func listDevicesV2(w http.ResponseWriter, r *http.Request) {
+	devices, err := clientFor(r).ListDevices()
+	principal := auth.FromContext(r.Context())
+	res := make([]model.DeviceV2, 0, len(devices))
+	for _, d := range devices {
+		if !principal.AllowsDevice(d.DeviceId) {
+			continue
+		}
+		res = append(res, model.ToDeviceV2(d))
+	}
+	respond(w, r, res, err)
+}
+
+func getDeviceV2(w http.ResponseWriter, r *http.Request) {
+	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
+		return
+	}
+	device, err := clientFor(r).GetDevice(deviceId)
+	respond(w, r, model.ToDeviceV2(device), err)
+}
+
+// patchDeviceV2 applies the attributes of a model.DevicePatch and responds with the device as reported by the
+// gateway afterwards.
+func patchDeviceV2(w http.ResponseWriter, r *http.Request) {
+	deviceId, err := paramToInt(chi.URLParam(r, deviceParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, deviceParam), err)
+		return
+	}
+	patch := model.DevicePatch{}
+	if !decode(w, r, &patch) {
+		return
+	}
+	if err := checkDevicePatch(patch); err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	client := clientFor(r)
+	device, err := client.GetDevice(deviceId)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	if msg := unsupportedBlock(device, patch); msg != "" {
+		problem.Write(w, r, problem.New(problem.Conflict, msg))
+		return
+	}
+	if err := applyDevicePatch(client, deviceId, patch); err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	device, err = client.GetDevice(deviceId)
+	respond(w, r, model.ToDeviceV2(device), err)
+}
+
+func listGroupsV2(w http.ResponseWriter, r *http.Request) {
+	groups, err := clientFor(r).ListGroups()
+	principal := auth.FromContext(r.Context())
+	res := make([]model.GroupV2, 0, len(groups))
+	for _, g := range groups {
+		if !principal.AllowsGroup(g.DeviceId) {
+			continue
+		}
+		res = append(res, model.ToGroupV2(g))
+	}
+	respond(w, r, res, err)
+}
+
+func getGroupV2(w http.ResponseWriter, r *http.Request) {
+	groupId, err := paramToInt(chi.URLParam(r, groupParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
+		return
+	}
+	group, err := clientFor(r).GetGroup(groupId)
+	respond(w, r, model.ToGroupV2(group), err)
+}
+
+func getGroupDevicesV2(w http.ResponseWriter, r *http.Request) {
+	groupId, err := paramToInt(chi.URLParam(r, groupParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
+		return
+	}
+	client := clientFor(r)
+	group, err := client.GetGroup(groupId)
+	if err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	principal := auth.FromContext(r.Context())
+	res := make([]model.DeviceV2, 0, len(group.Content.DeviceList.DeviceIds))
+	for _, id := range group.Content.DeviceList.DeviceIds {
+		if !principal.AllowsDevice(id) {
+			continue
+		}
+		device, err := client.GetDevice(id)
+		if err != nil {
+			respondWithError(w, r, err)
+			return
+		}
+		res = append(res, model.ToDeviceV2(device))
+	}
+	respondWithJSON(w, http.StatusOK, res)
+}
+
+func listGroupScenesV2(w http.ResponseWriter, r *http.Request) {
+	groupId, err := paramToInt(chi.URLParam(r, groupParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
+		return
+	}
+	scenes, err := clientFor(r).ListScenes(groupId)
+	res := make([]model.SceneV2, 0, len(scenes))
+	for _, s := range scenes {
+		res = append(res, model.ToSceneV2(s))
+	}
+	respond(w, r, res, err)
+}
+
+// patchGroupV2 applies the attributes of a model.GroupPatch to all devices of the group and responds with the
+// group as reported by the gateway afterwards.
+func patchGroupV2(w http.ResponseWriter, r *http.Request) {
+	groupId, err := paramToInt(chi.URLParam(r, groupParam))
+	if err != nil {
+		badIdentifierError(w, r, chi.URLParam(r, groupParam), err)
+		return
+	}
+	patch := model.GroupPatch{}
+	if !decode(w, r, &patch) {
+		return
+	}
+	client := clientFor(r)
+	if err := applyGroupPatch(client, groupId, patch); err != nil {
+		respondWithError(w, r, err)
+		return
+	}
+	group, err := client.GetGroup(groupId)
+	respond(w, r, model.ToGroupV2(group), err)
+}
+
+func checkDevicePatch(patch model.DevicePatch) error {
+	if err := check(patch); err != nil {
+		return err
+	}
+	if patch.Light == nil && patch.Outlet == nil && patch.Blind == nil {
+		return invalid("at least one of light, outlet and blind is required")
+	}
+	if c := patch.Light; c != nil && c.Color != nil {
+		switch {
+		case c.Color.Hex != "" && (c.Color.X != nil || c.Color.Y != nil):
+			return invalid("light.color takes either hex or x and y")
+		case c.Color.Hex == "" && (c.Color.X == nil || c.Color.Y == nil):
+			return invalid("light.color requires hex or both x and y")
+		}
+	}
+	return nil
+}
+
+// unsupportedBlock describes the first block of patch the device has no capability for.
+func unsupportedBlock(device model.Device, patch model.DevicePatch) string {
+	switch {
+	case patch.Light != nil && len(device.LightControl) == 0:
+		return "the device is not a light"
+	case patch.Outlet != nil && len(device.OutletControl) == 0:
+		return "the device is not an outlet"
+	case patch.Blind != nil && len(device.BlindControl) == 0:
+		return "the device is not a blind"
+	}
+	return ""
+}
+
+// applyDevicePatch sends the changes of a validated patch to the gateway, in the order power, brightness,
+// color and position.
+func applyDevicePatch(client TradfriClient, deviceId int, patch model.DevicePatch) error {
+	var err error
+	if l := patch.Light; l != nil {
+		if l.On != nil {
+			_, err = client.PutDevicePower(deviceId, boolToPower(*l.On))
+		}
+		if err == nil && l.Brightness != nil {
+			_, err = client.PutDeviceDimming(deviceId, model.PercentToDimmer(*l.Brightness))
+		}
+		if err == nil && l.Color != nil && l.Color.Hex != "" {
+			_, err = client.PutDeviceColorRGB(deviceId, l.Color.Hex)
+		} else if err == nil && l.Color != nil {
+			_, err = client.PutDeviceColor(deviceId, model.UnitToCIE(*l.Color.X), model.UnitToCIE(*l.Color.Y))
+		}
+	}
+	if o := patch.Outlet; err == nil && o != nil && o.On != nil {
+		_, err = client.PutOutletPower(deviceId, boolToPower(*o.On))
+	}
+	if b := patch.Blind; err == nil && b != nil && b.Position != nil {
+		_, err = client.PutDevicePositioning(deviceId, *b.Position)
+	}
+	return err
+}
+
+// applyGroupPatch sends the changes of patch to the gateway, in the order power, brightness and scene.
+func applyGroupPatch(client TradfriClient, groupId int, patch model.GroupPatch) error {
+	if err := check(patch); err != nil {
+		return err
+	}
+	if patch.On == nil && patch.Brightness == nil && patch.SceneId == nil {
+		return invalid("at least one of on, brightness and sceneId is required")
+	}
+	var err error
+	if patch.On != nil {
+		_, err = client.PutGroupPower(groupId, boolToPower(*patch.On))
+	}
+	if err == nil && patch.Brightness != nil {
+		_, err = client.PutGroupDimming(groupId, model.PercentToDimmer(*patch.Brightness))
+	}
+	if err == nil && patch.SceneId != nil {
+		_, err = client.PutGroupScene(groupId, *patch.SceneId)
+	}
+	return err
+}
+
+func boolToPower(on bool) int {
+	if on {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/auth"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{}
+
+// wsConns tracks the open WebSocket connections, which the HTTP server no longer manages after the upgrade.
+var wsConns = struct {
+	sync.Mutex
+	m map[*wsConn]struct{}
+}{m: map[*wsConn]struct{}{}}
+
+// closeWebSockets sends a close message to all open WebSocket connections and closes them.
+func closeWebSockets() {
+	wsConns.Lock()
+	conns := make([]*wsConn, 0, len(wsConns.m))
+	for c := range wsConns.m {
+		conns = append(conns, c)
+	}
+	wsConns.Unlock()
+	for _, c := range conns {
+		c.mu.Lock()
+		_ = c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"), time.Now().Add(time.Second))
+		c.mu.Unlock()
+		c.close()
+	}
+}
+
+// wsConn is a single client connected to /api/ws. Writes are serialized since a WebSocket
+// connection supports only one concurrent writer.
+type wsConn struct {
+	conn      *websocket.Conn
+	client    TradfriClient
+	watcher   *tradfri.Watcher
+	principal *auth.Principal
+
+	mu          sync.Mutex
+	unsubscribe func()
+}
+
+func serveWs(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied with an HTTP error
+		slog.Error("websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+	c := &wsConn{conn: conn, client: clientFor(r), watcher: gatewayFor(r).Watcher, principal: auth.FromContext(r.Context())}
+	wsConns.Lock()
+	wsConns.m[c] = struct{}{}
+	wsConns.Unlock()
+	defer c.close()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				slog.Warn("websocket closed unexpectedly", slog.Any("error", err))
+			}
+			return
+		}
+		req := model.WsRequest{}
+		if err := json.Unmarshal(data, &req); err != nil {
+			c.send(model.WsResponse{Type: "error", Error: err.Error()})
+			continue
+		}
+		c.handle(req)
+	}
+}
+
+func (c *wsConn) handle(req model.WsRequest) {
+	var result model.Result
+	var err error
+	switch req.Type {
+	case "subscribe":
+		err = c.subscribe(req.DeviceIds, req.GroupIds)
+	case "unsubscribe":
+		c.stopSubscription()
+	case "power", "dimmer", "color", "rgb", "state", "position":
+		if err = c.authorize(req.DeviceId); err == nil {
+			result, err = c.command(req)
+		}
+	default:
+		// rejected before authorizing, so that a bad request is not reported as denied
+		err = invalid("unknown message type %q", req.Type)
+	}
+	if err != nil {
+		c.send(model.WsResponse{ID: req.ID, Type: "error", Error: err.Error()})
+		return
+	}
+	c.send(model.WsResponse{ID: req.ID, Type: "ack", Result: &result})
+}
+
+func (c *wsConn) authorize(deviceId int) error {
+	if !c.principal.Allows(auth.ScopeControl) {
+		return errors.New("API key lacks the control scope")
+	}
+	if !c.principal.AllowsDevice(deviceId) {
+		return errors.New("API key may not access this device")
+	}
+	return nil
+}
+
+func (c *wsConn) command(req model.WsRequest) (model.Result, error) {
+	switch req.Type {
+	case "power":
+		return applyPower(c.client, req.DeviceId, model.PowerRequest{Power: req.Power})
+	case "dimmer":
+		return applyDimming(c.client, req.DeviceId, model.DimmingRequest{Dimming: req.Dimmer})
+	case "color":
+		return applyColorXY(c.client, req.DeviceId, model.ColorXYRequest{X: req.X, Y: req.Y})
+	case "rgb":
+		return applyColorRGB(c.client, req.DeviceId, model.RgbColorRequest{RGBcolor: req.RGBcolor})
+	case "state":
+		return applyState(c.client, req.DeviceId, model.StateRequest{Power: req.Power, Dimmer: req.Dimmer})
+	case "position":
+		return applyPositioning(c.client, req.DeviceId, model.PositioningRequest{Positioning: req.Positioning})
+	}
+	return model.Result{}, invalid("unknown message type %q", req.Type)
+}
+
+// subscribe replaces any current subscription with one forwarding the changes of the passed devices and groups.
+func (c *wsConn) subscribe(deviceIds, groupIds []int) error {
+	if c.watcher == nil {
+		return errors.New("state updates are not enabled on this server")
+	}
+	c.stopSubscription()
+	events, unsubscribe := c.watcher.Subscribe()
+	c.mu.Lock()
+	c.unsubscribe = unsubscribe
+	c.mu.Unlock()
+
+	all := len(deviceIds) == 0 && len(groupIds) == 0
+	go func() {
+		for event := range events {
+			if msg, ok := c.toWsEvent(event, all, deviceIds, groupIds); ok {
+				c.send(msg)
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *wsConn) toWsEvent(event tradfri.StateEvent, all bool, deviceIds, groupIds []int) (model.WsResponse, bool) {
+	if event.Device != nil && c.principal.AllowsDevice(event.Device.DeviceId) && (all || slices.Contains(deviceIds, event.Device.DeviceId)) {
+		return model.WsResponse{Type: "device", Device: model.ToDeviceResponse(*event.Device)}, true
+	}
+	if event.Group != nil && c.principal.AllowsGroup(event.Group.DeviceId) && (all || slices.Contains(groupIds, event.Group.DeviceId)) {
+		group := model.ToGroupResponse(*event.Group)
+		return model.WsResponse{Type: "group", Group: &group}, true
+	}
+	return model.WsResponse{}, false
+}
+
+func (c *wsConn) stopSubscription() {
+	c.mu.Lock()
+	unsubscribe := c.unsubscribe
+	c.unsubscribe = nil
+	c.mu.Unlock()
+	if unsubscribe != nil {
+		unsubscribe()
+	}
+}
+
+func (c *wsConn) send(msg model.WsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.WriteJSON(msg); err != nil {
+		slog.Warn("unable to write websocket message", slog.Any("error", err))
+	}
+}
+
+func (c *wsConn) close() {
+	wsConns.Lock()
+	delete(wsConns.m, c)
+	wsConns.Unlock()
+	c.stopSubscription()
+	_ = c.conn.Close()
+}
@@ -0,0 +1,264 @@
+// Package secrets keeps the gateway PSKs and API keys out of the plain text configuration: in a file
+// encrypted with NaCl secretbox, using a key derived from a passphrase with scrypt or read from a key file, or
+// in environment variables only.
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PSK is the name of the PSK of the gateway configured by the top-level settings.
+const PSK = "psk"
+
+// GatewayPSK returns the name of the PSK of a gateway of the "gateways" list.
+func GatewayPSK(gateway string) string {
+	return "psk." + gateway
+}
+
+// APIKey returns the name of the key of an entry of the "api_keys" list.
+func APIKey(name string) string {
+	return "api_key." + name
+}
+
+// MQTTPassword is the name of the password of the MQTT broker of the Home Assistant bridge.
+const MQTTPassword = "mqtt_password"
+
+// ValidName reports whether name is the name of a PSK, API key or the MQTT password.
+func ValidName(name string) bool {
+	return name == PSK || name == MQTTPassword || strings.HasPrefix(name, "psk.") && len(name) > 4 || strings.HasPrefix(name, "api_key.") && len(name) > 8
+}
+
+// EnvPrefix starts the environment variables read by Env.
+const EnvPrefix = "TRADFRI_"
+
+const (
+	// PassphraseEnv is the environment variable holding the passphrase of a secrets file.
+	PassphraseEnv = EnvPrefix + "SECRETS_PASSPHRASE"
+	// NewPassphraseEnv is the environment variable holding the new passphrase when rotating.
+	NewPassphraseEnv = EnvPrefix + "SECRETS_NEW_PASSPHRASE"
+)
+
+// EnvName returns the environment variable of a secret, e.g. TRADFRI_PSK_GARAGE for "psk.garage".
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
+
+// Source looks up secrets by name.
+type Source interface {
+	Lookup(name string) (string, bool)
+}
+
+// Store maps secret names to their values, it is the content of a secrets file.
+type Store map[string]string
+
+// Lookup implements Source.
+func (s Store) Lookup(name string) (string, bool) {
+	v, ok := s[name]
+	return v, ok
+}
+
+// Names returns the sorted names of the secrets.
+func (s Store) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Env is the Source reading the secrets from environment variables named by EnvName, for containers that
+// get their secrets injected.
+type Env struct{}
+
+// Lookup implements Source.
+func (Env) Lookup(name string) (string, bool) {
+	return os.LookupEnv(EnvName(name))
+}
+
+// ErrDecrypt is returned by Load if the key does not match the file, or the file was tampered with.
+var ErrDecrypt = errors.New("unable to decrypt the secrets file, wrong passphrase or key")
+
+// keySize is the key size of secretbox.
+const keySize = 32
+
+// scrypt parameters for passphrases, as recommended for interactive logins in 2017.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Key encrypts a secrets file, either a passphrase or a key read from a key file.
+type Key struct {
+	passphrase []byte
+	key        *[keySize]byte
+}
+
+// Passphrase returns a key derived from passphrase with scrypt and a random salt stored in the file.
+func Passphrase(passphrase string) Key {
+	return Key{passphrase: []byte(passphrase)}
+}
+
+// ReadKeyFile reads a key written by GenerateKeyFile.
+func ReadKeyFile(path string) (Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || len(raw) != keySize {
+		return Key{}, fmt.Errorf("%s is not a key file, expected %d base64 encoded bytes", path, keySize)
+	}
+	k := [keySize]byte(raw)
+	return Key{key: &k}, nil
+}
+
+// GenerateKeyFile writes a new random key to path, readable by the owner only. An existing file is not
+// overwritten.
+func GenerateKeyFile(path string) (Key, error) {
+	k := new([keySize]byte)
+	if _, err := rand.Read(k[:]); err != nil {
+		return Key{}, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return Key{}, err
+	}
+	_, err = fmt.Fprintln(f, base64.StdEncoding.EncodeToString(k[:]))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{key: k}, nil
+}
+
+// file is the format of a secrets file.
+type file struct {
+	Version int `json:"version"`
+	// Scrypt holds the parameters of the key derivation, unless a key file is used.
+	Scrypt *scryptParams `json:"scrypt,omitempty"`
+	Nonce  []byte        `json:"nonce"`
+	Box    []byte        `json:"box"`
+}
+
+type scryptParams struct {
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// Load decrypts the secrets file at path. A missing file is returned as an error wrapping fs.ErrNotExist.
+func Load(path string, k Key) (Store, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := file{}
+	if err := json.Unmarshal(b, &f); err != nil || f.Version != 1 || len(f.Nonce) != 24 {
+		return nil, fmt.Errorf("%s is not a secrets file", path)
+	}
+	switch {
+	case f.Scrypt != nil && k.key != nil:
+		return nil, fmt.Errorf("%w: the file is encrypted with a passphrase", ErrDecrypt)
+	case f.Scrypt == nil && k.key == nil:
+		return nil, fmt.Errorf("%w: the file is encrypted with a key file", ErrDecrypt)
+	}
+	key := k.key
+	if f.Scrypt != nil {
+		if key, err = derive(k.passphrase, *f.Scrypt); err != nil {
+			return nil, err
+		}
+	}
+	plain, ok := secretbox.Open(nil, f.Box, (*[24]byte)(f.Nonce), key)
+	if !ok {
+		return nil, ErrDecrypt
+	}
+	s := Store{}
+	if err := json.Unmarshal(plain, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save encrypts the secrets to path with a new nonce, and a new salt for passphrases. The file is replaced
+// atomically and readable by the owner only.
+func Save(path string, k Key, s Store) error {
+	plain, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	f := file{Version: 1, Nonce: make([]byte, 24)}
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	key := k.key
+	if key == nil {
+		f.Scrypt = &scryptParams{Salt: make([]byte, 16), N: scryptN, R: scryptR, P: scryptP}
+		if _, err := rand.Read(f.Scrypt.Salt); err != nil {
+			return err
+		}
+		if key, err = derive(k.passphrase, *f.Scrypt); err != nil {
+			return err
+		}
+	}
+	f.Box = secretbox.Seal(nil, plain, (*[24]byte)(f.Nonce), key)
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, b)
+}
+
+// Rotate re-encrypts the secrets file at path, which is encrypted with old, with next.
+func Rotate(path string, old, next Key) error {
+	s, err := Load(path, old)
+	if err != nil {
+		return err
+	}
+	return Save(path, next, s)
+}
+
+func derive(passphrase []byte, p scryptParams) (*[keySize]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("the passphrase is empty")
+	}
+	raw, err := scrypt.Key(passphrase, p.Salt, p.N, p.R, p.P, keySize)
+	if err != nil {
+		return nil, err
+	}
+	k := [keySize]byte(raw)
+	return &k, nil
+}
+
+// writeFile replaces path with data through a temporary file in the same directory.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp already creates the file with 0600
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,106 @@
+package secrets
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, err := GenerateKeyFile(filepath.Join(dir, "secrets.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	readKey, err := ReadKeyFile(filepath.Join(dir, "secrets.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := Store{PSK: "s3cr3t", APIKey("ha"): "key-1"}
+	for name, k := range map[string][2]Key{"passphrase": {Passphrase("correct horse"), Passphrase("correct horse")}, "key file": {keyFile, readKey}} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+		if err := Save(path, k[0], store); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+			t.Fatalf("%s: expected a file readable by the owner only, got %v %v", name, fi.Mode(), err)
+		}
+		b, _ := os.ReadFile(path)
+		if strings.Contains(string(b), "s3cr3t") {
+			t.Fatalf("%s: the secrets file contains the PSK in plain text", name)
+		}
+		loaded, err := Load(path, k[1])
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if v, ok := loaded.Lookup(PSK); !ok || v != "s3cr3t" || len(loaded) != 2 {
+			t.Fatalf("%s: unexpected secrets %v", name, loaded)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.json")
+	if err := Save(path, Passphrase("correct horse"), Store{PSK: "s3cr3t"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, Passphrase("battery staple")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected ErrDecrypt for a wrong passphrase, got %v", err)
+	}
+	k, err := GenerateKeyFile(filepath.Join(dir, "secrets.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, k); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected ErrDecrypt for a key file, got %v", err)
+	}
+	if _, err := GenerateKeyFile(filepath.Join(dir, "secrets.key")); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected an existing key file to be kept, got %v", err)
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json"), k); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.json")
+	if err := Save(path, Passphrase("correct horse"), Store{PSK: "s3cr3t"}); err != nil {
+		t.Fatal(err)
+	}
+	k, err := GenerateKeyFile(filepath.Join(dir, "secrets.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Rotate(path, Passphrase("correct horse"), k); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, Passphrase("correct horse")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected the old passphrase to be rejected, got %v", err)
+	}
+	if s, err := Load(path, k); err != nil || s[PSK] != "s3cr3t" {
+		t.Errorf("expected the secrets with the new key, got %v %v", s, err)
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for name, valid := range map[string]bool{"psk": true, "psk.garage": true, "api_key.ha": true, "mqtt_password": true, "psk.": false, "api_key.": false, "client_id": false} {
+		if ValidName(name) != valid {
+			t.Errorf("ValidName(%q) = %v", name, !valid)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TRADFRI_PSK_LIVING_ROOM", "s3cr3t")
+	if v, ok := (Env{}).Lookup(GatewayPSK("living-room")); !ok || v != "s3cr3t" {
+		t.Errorf("expected the PSK from TRADFRI_PSK_LIVING_ROOM, got %q", v)
+	}
+	if _, ok := (Env{}).Lookup(APIKey("ha")); ok {
+		t.Error("expected no API key")
+	}
+}
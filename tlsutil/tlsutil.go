@@ -0,0 +1,223 @@
+// Package tlsutil provides the TLS configuration of the REST and gRPC servers, including self-signed
+// certificate generation and reloading of certificates changed on disk.
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval limits how often the certificate files are checked for changes.
+var checkInterval = time.Second
+
+// Config holds the file based TLS settings.
+type Config struct {
+	// CertFile and KeyFile are the PEM encoded server certificate and private key.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mutual TLS: clients must present a certificate signed by one of its CAs.
+	ClientCAFile string
+}
+
+// Reloader serves the certificate and client CAs from Config, reloading them whenever one of the files
+// changes. The files are checked at most once per checkInterval, during TLS handshakes, so the new certificate is
+// picked up by the next connection without restarting the server.
+type Reloader struct {
+	cfg Config
+
+	mu      sync.Mutex
+	checked time.Time
+	modTime time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// NewReloader loads the files from cfg, failing if they are missing or invalid.
+func NewReloader(cfg Config) (*Reloader, error) {
+	r := &Reloader{cfg: cfg}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ServerConfig returns a TLS configuration serving the reloaded certificate, requiring and verifying
+// client certificates if a client CA file is configured.
+func (r *Reloader) ServerConfig() *tls.Config {
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+	}
+	if r.cfg.ClientCAFile != "" {
+		// verification is done by verifyClient so that it uses the reloaded CAs
+		c.ClientAuth = tls.RequireAnyClientCert
+		c.VerifyPeerCertificate = r.verifyClient
+	}
+	return c
+}
+
+func (r *Reloader) verifyClient(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	_, pool := r.current()
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	var leaf *x509.Certificate
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("parsing client certificate: %w", err)
+		}
+		if i == 0 {
+			leaf = cert
+		} else {
+			opts.Intermediates.AddCert(cert)
+		}
+	}
+	if leaf == nil {
+		return errors.New("no client certificate")
+	}
+	_, err := leaf.Verify(opts)
+	return err
+}
+
+// current returns the certificate and client CA pool, reloading them if the files have changed. Failed
+// reloads are logged and the previous certificate is kept.
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) >= checkInterval {
+		r.checked = time.Now()
+		if modTime := r.latestModTime(); modTime.After(r.modTime) {
+			if err := r.loadLocked(); err != nil {
+				// don't retry until the files change again
+				r.modTime = modTime
+				slog.Error("unable to reload TLS certificate, keeping the previous one", slog.Any("error", err))
+			} else {
+				slog.Info("reloaded TLS certificate", slog.String("file", r.cfg.CertFile))
+			}
+		}
+	}
+	return r.cert, r.pool
+}
+
+func (r *Reloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+func (r *Reloader) loadLocked() error {
+	modTime := r.latestModTime()
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("reading client CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.cfg.ClientCAFile)
+		}
+	}
+	r.cert, r.pool, r.modTime = &cert, pool, modTime
+	return nil
+}
+
+func (r *Reloader) latestModTime() time.Time {
+	var latest time.Time
+	for _, f := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if f == "" {
+			continue
+		}
+		if fi, err := os.Stat(f); err == nil && fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest
+}
+
+// EnsureCertificate generates a self-signed certificate valid for hosts (host names or IP addresses) into
+// certFile and keyFile unless both already exist. It reports whether a certificate was generated.
+func EnsureCertificate(certFile, keyFile string, hosts []string) (bool, error) {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if certErr == nil && keyErr == nil {
+		return false, nil
+	}
+	if !errors.Is(certErr, os.ErrNotExist) || !errors.Is(keyErr, os.ErrNotExist) {
+		return false, fmt.Errorf("only one of %s and %s exists, remove it or provide both", certFile, keyFile)
+	}
+	certPEM, keyPEM, err := SelfSigned(hosts, 365*24*time.Hour)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return false, fmt.Errorf("writing TLS key: %w", err)
+	}
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil {
+		return false, fmt.Errorf("writing TLS certificate: %w", err)
+	}
+	return true, nil
+}
+
+// SelfSigned returns a PEM encoded self-signed ECDSA certificate and key valid for hosts. The certificate is a
+// leaf, not a CA, which peers trust by pinning it. It may be used by servers as well as clients.
+func SelfSigned(hosts []string, validFor time.Duration) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating TLS key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating serial number: %w", err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "tradfri-go"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if h != "" {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding TLS key: %w", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
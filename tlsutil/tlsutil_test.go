@@ -0,0 +1,174 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEnsureCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	generated, err := EnsureCertificate(certFile, keyFile, []string{"localhost", "127.0.0.1"})
+	if err != nil || !generated {
+		t.Fatalf("expected a certificate to be generated, got %v %v", generated, err)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := cert.Leaf
+	if leaf.DNSNames[0] != "localhost" || !leaf.IPAddresses[0].Equal([]byte{127, 0, 0, 1}) {
+		t.Fatalf("unexpected SANs %v %v", leaf.DNSNames, leaf.IPAddresses)
+	}
+
+	generated, err = EnsureCertificate(certFile, keyFile, nil)
+	if err != nil || generated {
+		t.Fatalf("expected the existing certificate to be kept, got %v %v", generated, err)
+	}
+
+	_ = os.Remove(keyFile)
+	if _, err := EnsureCertificate(certFile, keyFile, nil); err == nil {
+		t.Fatal("expected an error when only the certificate exists")
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	checkInterval = 0
+	defer func() { checkInterval = time.Second }()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, time.Now())
+
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := serverCert(t, r)
+
+	writeCert(t, certFile, keyFile, time.Now().Add(time.Minute))
+	if after := serverCert(t, r); after.SerialNumber.Cmp(before.SerialNumber) == 0 {
+		t.Fatal("expected the changed certificate to be served")
+	}
+
+	// a broken certificate keeps the previous one
+	current := serverCert(t, r)
+	_ = os.WriteFile(certFile, []byte("garbage"), 0o644)
+	_ = os.Chtimes(certFile, time.Now().Add(2*time.Minute), time.Now().Add(2*time.Minute))
+	if after := serverCert(t, r); after.SerialNumber.Cmp(current.SerialNumber) != 0 {
+		t.Fatal("expected the previous certificate to be kept")
+	}
+}
+
+func TestReloader_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, time.Now())
+	clientCertPEM, clientKeyPEM, err := SelfSigned([]string{"client"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := filepath.Join(dir, "ca.crt")
+	_ = os.WriteFile(caFile, clientCertPEM, 0o644)
+
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", r.ServerConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			if err := conn.(*tls.Conn).Handshake(); err == nil {
+				_, _ = conn.Write([]byte("ok"))
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	roots := x509.NewCertPool()
+	serverPEM, _ := os.ReadFile(certFile)
+	roots.AppendCertsFromPEM(serverPEM)
+	dial := func(certs ...tls.Certificate) error {
+		conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: certs})
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = io.ReadAll(conn)
+		return err
+	}
+
+	if err := dial(); err == nil {
+		t.Fatal("expected a connection without client certificate to be rejected")
+	}
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dial(clientCert); err != nil {
+		t.Fatalf("expected a connection with client certificate to succeed, got %v", err)
+	}
+}
+
+func TestSelfSigned_Leaf(t *testing.T) {
+	certPEM, _, err := SelfSigned([]string{"localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.IsCA || cert.KeyUsage&x509.KeyUsageCertSign != 0 {
+		t.Fatalf("expected a leaf certificate, got IsCA %v and key usage %v", cert.IsCA, cert.KeyUsage)
+	}
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageServerAuth) {
+		t.Fatalf("expected server authentication, got %v", cert.ExtKeyUsage)
+	}
+	if len(cert.DNSNames) != 1 || len(cert.IPAddresses) != 1 {
+		t.Fatalf("expected the hosts as DNS name and IP address, got %v %v", cert.DNSNames, cert.IPAddresses)
+	}
+}
+
+func writeCert(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	certPEM, keyPEM, err := SelfSigned([]string{"localhost"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, data := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Chtimes(file, modTime, modTime)
+	}
+}
+
+func serverCert(t *testing.T, r *Reloader) *x509.Certificate {
+	t.Helper()
+	cert, err := r.ServerConfig().GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf
+}
@@ -0,0 +1,85 @@
+// Package tracing configures OpenTelemetry tracing for tradfri-go.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporter names accepted by Setup.
+const (
+	ExporterNone = "none"
+	ExporterOTLP = "otlp"
+	ExporterFile = "file"
+)
+
+// Tracer returns the tracer used for all spans created by tradfri-go.
+func Tracer() trace.Tracer {
+	return otel.Tracer("github.com/eriklupander/tradfri-go")
+}
+
+// Setup installs a global tracer provider exporting spans with the named exporter. The OTLP exporter sends
+// spans over gRPC to endpoint (or the OTEL_EXPORTER_OTLP_* environment defaults if empty), the file exporter
+// appends them as JSON to the file at path. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, exporter, endpoint, path string) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{}
+		if endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating OTLP exporter: %w", err)
+		}
+		spanExporter = exp
+	case ExporterFile:
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening trace file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("creating file exporter: %w", err)
+		}
+		spanExporter = closingExporter{SpanExporter: exp, file: f}
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, use one of none, otlp or file", exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("tradfri-go"))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// closingExporter closes the trace file once the exporter has been shut down.
+type closingExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e closingExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package tradfri
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-coap"
+	"github.com/eriklupander/tradfri-go/dtlscoap"
+	"github.com/eriklupander/tradfri-go/metrics"
+	"github.com/eriklupander/tradfri-go/model"
+)
+
+// ErrUnsupportedMethod is returned by RawRequest for methods other than GET, PUT, POST and DELETE.
+var ErrUnsupportedMethod = errors.New("unsupported CoAP method, use one of GET, PUT, POST or DELETE")
+
+var rawMethods = map[string]coap.COAPCode{
+	"GET":    coap.GET,
+	"PUT":    coap.PUT,
+	"POST":   coap.POST,
+	"DELETE": coap.DELETE,
+}
+
+var optionNames = map[coap.OptionID]string{
+	coap.IfMatch:       "If-Match",
+	coap.URIHost:       "Uri-Host",
+	coap.ETag:          "ETag",
+	coap.IfNoneMatch:   "If-None-Match",
+	coap.Observe:       "Observe",
+	coap.URIPort:       "Uri-Port",
+	coap.LocationPath:  "Location-Path",
+	coap.URIPath:       "Uri-Path",
+	coap.ContentFormat: "Content-Format",
+	coap.MaxAge:        "Max-Age",
+	coap.URIQuery:      "Uri-Query",
+	coap.Accept:        "Accept",
+	coap.LocationQuery: "Location-Query",
+	coap.ProxyURI:      "Proxy-Uri",
+	coap.ProxyScheme:   "Proxy-Scheme",
+	coap.Size1:         "Size1",
+}
+
+// RawRequest sends a request with the passed method to an arbitrary gateway path, e.g. "/15011/15012", and
+// returns the response without interpreting it. It allows reaching gateway features not modelled by Client.
+func (tc *Client) RawRequest(method, path string, payload []byte) (model.RawResponse, error) {
+	code, ok := rawMethods[strings.ToUpper(method)]
+	if !ok {
+		return model.RawResponse{}, fmt.Errorf("%w: %q", ErrUnsupportedMethod, method)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	ctx := tc.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// arbitrary paths would make the number of metric series unbounded
+	resp, err := tc.dtlsclient.CallContext(dtlscoap.WithPathLabel(ctx, metrics.RawPath), tc.dtlsclient.BuildMessage(code, path, payload))
+	if err != nil {
+		return model.RawResponse{}, err
+	}
+	return ToRawResponse(resp), nil
+}
+
+// ToRawResponse converts a CoAP message into its RawResponse representation.
+func ToRawResponse(msg coap.Message) model.RawResponse {
+	res := model.RawResponse{
+		Code:     fmt.Sprintf("%d.%02d", msg.Code>>5, msg.Code&0x1f),
+		CodeName: msg.Code.String(),
+		Options:  []model.RawOption{},
+		Payload:  string(msg.Payload),
+	}
+	for id := 0; id < 256; id++ {
+		for _, v := range msg.Options(coap.OptionID(id)) {
+			res.Options = append(res.Options, model.RawOption{
+				Number: id,
+				Name:   optionNames[coap.OptionID(id)],
+				Value:  optionValue(v),
+			})
+		}
+	}
+	return res
+}
+
+func optionValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return hex.EncodeToString(v)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case coap.MediaType:
+		return strconv.Itoa(int(v))
+	}
+	return fmt.Sprint(v)
+}
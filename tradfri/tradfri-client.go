@@ -1,12 +1,13 @@
 package tradfri
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
-	"os"
 	"strings"
 
 	"github.com/dustin/go-coap"
@@ -37,8 +38,12 @@ const (
 )
 
 // Client provides a declarative API for sending CoAP messages to the gateway over DTLS.
+//
+// The typed operations return a GatewayError if the gateway answers with a 4.xx or 5.xx code, see the
+// changelog. Call and RawRequest return every response as is.
 type Client struct {
 	dtlsclient *dtlscoap.DtlsClient
+	ctx        context.Context
 }
 
 // NewTradfriClient creates a new instance of Client, including initiating the DTLS client.
@@ -48,15 +53,56 @@ func NewTradfriClient(gatewayAddress, clientID, psk string) *Client {
 	return client
 }
 
+// DialTradfriClient does the same as NewTradfriClient, but returns an error instead of exiting if the gateway
+// cannot be reached.
+func DialTradfriClient(gatewayAddress, clientID, psk string) (*Client, error) {
+	return DialTradfriClientWithOptions(gatewayAddress, clientID, psk, dtlscoap.Options{})
+}
+
+// DialTradfriClientWithOptions does the same as DialTradfriClient, passing opts to the DTLS client.
+func DialTradfriClientWithOptions(gatewayAddress, clientID, psk string, opts dtlscoap.Options) (*Client, error) {
+	dtlsclient, err := dtlscoap.DialDtlsClientWithOptions(gatewayAddress, clientID, psk, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{dtlsclient: dtlsclient}, nil
+}
+
+// OpenTradfriClientWithOptions returns a client whose DTLS session is established by Connect, or by the first
+// call, so that a gateway that cannot be reached yet does not prevent creating it.
+func OpenTradfriClientWithOptions(gatewayAddress, clientID, psk string, opts dtlscoap.Options) *Client {
+	return &Client{dtlsclient: dtlscoap.OpenDtlsClientWithOptions(gatewayAddress, clientID, psk, opts)}
+}
+
+// Connect establishes the DTLS session with the gateway unless it is already up.
+func (tc *Client) Connect() error {
+	return tc.dtlsclient.Connect()
+}
+
+// WithContext returns a shallow copy of the client whose gateway calls are made within ctx, e.g. to make them
+// part of the trace of the request being served. The copy shares the DTLS session with the original.
+func (tc *Client) WithContext(ctx context.Context) *Client {
+	c := *tc
+	c.ctx = ctx
+	return &c
+}
+
+// Close ends the DTLS session with the gateway. The client, and all copies returned by WithContext, must not
+// be used afterwards.
+func (tc *Client) Close() error {
+	return tc.dtlsclient.Close()
+}
+
 // Yo who decided it would be a good idea to have the deviceId be an int in all the models, but here every function wants it as a string, its stupid
 // But i aint changin it because it could break code that depends on these functions (It would be a great change tho)
 
 // PutDeviceDimming sets the dimming property (0-255) of the specified device.
 // The device must be a bulb supporting dimming, otherwise the call if ineffectual.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceDimming(deviceId int, dimming int) (model.Result, error) {
 	payload := fmt.Sprintf(`{ "3311": [{ "5851": %d }] }`, dimming)
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -65,13 +111,30 @@ func (tc *Client) PutDeviceDimming(deviceId int, dimming int) (model.Result, err
 }
 
 // PutDevicePower switches the power state of the specified device to on (1) or off (0)
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDevicePower(deviceId int, power int) (model.Result, error) {
 	if !(power == 1 || power == 0) {
 		return model.Result{}, fmt.Errorf("invalid value for setting power state, must be 1 or 0")
 	}
 	payload := fmt.Sprintf(`{ "3311": [{ "5850": %d }] }`, power)
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	if err != nil {
+		return model.Result{}, err
+	}
+	slog.Debug("Response", slog.Any("response", resp))
+	return model.Result{Msg: resp.Code.String()}, nil
+}
+
+// PutOutletPower switches the power state of the specified power outlet to on (1) or off (0).
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutOutletPower(deviceId int, power int) (model.Result, error) {
+	if !(power == 1 || power == 0) {
+		return model.Result{}, fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	payload := fmt.Sprintf(`{ "3312": [{ "5850": %d }] }`, power)
+	slog.Debug("Payload", slog.String("payload", payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -80,13 +143,14 @@ func (tc *Client) PutDevicePower(deviceId int, power int) (model.Result, error)
 }
 
 // PutDeviceState allows changing both power (1 or 0) and dimmer (0-255) for a given device with one command.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceState(deviceId int, power int, dimmer int) (model.Result, error) {
 	if !(power == 1 || power == 0) {
 		return model.Result{}, fmt.Errorf("invalid value for setting power state, must be 1 or 0")
 	}
 	payload := fmt.Sprintf(`{ "3311": [{ "5850": %d, "5851": %d}] }`, power, dimmer) // , "5706": "%s"
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -97,15 +161,17 @@ func (tc *Client) PutDeviceState(deviceId int, power int, dimmer int) (model.Res
 // PutDeviceColor sets the CIE 1931 color space x/y color, x and y must be between 0-65536 but note that
 // many combinations won't work. See CIE 1931 for more details.
 // It is not recommended to use these values to set colors, as it is often not supported by the gateway and is intended for internal use.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColor(deviceId int, x, y int) (model.Result, error) {
 	return tc.PutDeviceColorTimed(deviceId, x, y, 500)
 }
 
 // PutDeviceColorTimed does the same as PutDeviceColor but it gives you the ability to change the speed at which the color changes
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorTimed(deviceId int, x, y int, transitionTimeMS int) (model.Result, error) {
 	payload := fmt.Sprintf(`{ "3311": [ {"5709": %d, "5710": %d, "5712": %d}] }`, x, y, transitionTimeMS/100)
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -115,11 +181,13 @@ func (tc *Client) PutDeviceColorTimed(deviceId int, x, y int, transitionTimeMS i
 
 // PutDeviceColorRGB sets the color of the bulb using RGB hex string such as 8f2686 (purple). Note that
 // It does not use the built in rgb hex parameter as that does not work reliably, so the rgb is converted to hsl and that is sent
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorRGB(deviceId int, rgb string) (model.Result, error) {
 	return tc.PutDeviceColorRGBTimed(deviceId, rgb, 500)
 }
 
 // PutDeviceColorRGBTimed does the same as PutDeviceColorRGB but it gives you the ability to change the speed at which the color changes
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorRGBTimed(deviceId int, rgb string, transitionTimeMS int) (model.Result, error) {
 	r, g, b, err := hexStringToRgb(rgb)
 	if err != nil {
@@ -130,11 +198,13 @@ func (tc *Client) PutDeviceColorRGBTimed(deviceId int, rgb string, transitionTim
 }
 
 // PutDeviceColorRGBInt does about the same as PutDeviceColorRGB except you can directly pass the rgb instead of a hex string
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorRGBInt(deviceId int, r, g, b int) (model.Result, error) {
 	return tc.PutDeviceColorRGBIntTimed(deviceId, r, g, b, 500)
 }
 
 // PutDeviceColorRGBIntTimed does the same as PutDeviceColorRGBInt but it gives you the ability to change the speed at which the color changes
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorRGBIntTimed(deviceId int, r, g, b int, transitionTimeMS int) (model.Result, error) {
 	h, s, l := rgbToHsl(r, g, b)
 
@@ -143,11 +213,13 @@ func (tc *Client) PutDeviceColorRGBIntTimed(deviceId int, r, g, b int, transitio
 
 // PutDeviceColorHSL sets the color of the bulb using the HSL color notation
 // This is more effictive than RGB because RGB is always at full brightness, ("000000" is the same as "ffffff")
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorHSL(deviceId int, hue float64, saturation float64, lightness float64) (model.Result, error) {
 	return tc.PutDeviceColorHSLTimed(deviceId, hue, saturation, lightness, 500)
 }
 
 // PutDeviceColorHSLTimed does the same as PutDeviceColorHSL but it gives you the ability to change the speed at which the color changes
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDeviceColorHSLTimed(deviceId int, hue float64, saturation float64, lightness float64, transitionTimeMS int) (model.Result, error) {
 	hueInt := int(mapRange(hue, 0, 360, 0, 65535))
 	saturationInt := int(mapRange(saturation, 0, 100, 0, 65279))
@@ -155,7 +227,31 @@ func (tc *Client) PutDeviceColorHSLTimed(deviceId int, hue float64, saturation f
 
 	payload := fmt.Sprintf(`{ "3311": [ {"5707": %d, "5708": %d, "5851": %d, "5712": %d}] }`, hueInt, saturationInt, lightnessInt, transitionTimeMS/100)
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	if err != nil {
+		return model.Result{}, err
+	}
+	slog.Debug("Response", slog.Any("response", resp))
+	return model.Result{Msg: resp.Code.String()}, nil
+}
+
+// PutDeviceColorTemperature sets the color temperature of a white spectrum bulb in Kelvin, IKEA bulbs support
+// 2200 to 4000.
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutDeviceColorTemperature(deviceId int, kelvin int) (model.Result, error) {
+	return tc.PutDeviceColorTemperatureTimed(deviceId, kelvin, 500)
+}
+
+// PutDeviceColorTemperatureTimed does the same as PutDeviceColorTemperature but it gives you the ability to change the speed at which the color changes
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutDeviceColorTemperatureTimed(deviceId int, kelvin int, transitionTimeMS int) (model.Result, error) {
+	if kelvin <= 0 {
+		return model.Result{}, fmt.Errorf("invalid color temperature %d, must be a positive number of Kelvin", kelvin)
+	}
+	mireds := int(math.Round(1e6 / float64(kelvin)))
+	payload := fmt.Sprintf(`{ "3311": [ {"5711": %d, "5712": %d}] }`, mireds, transitionTimeMS/100)
+	slog.Debug("Payload", slog.String("payload", payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -164,10 +260,42 @@ func (tc *Client) PutDeviceColorHSLTimed(deviceId int, hue float64, saturation f
 }
 
 // PutDevicePositioning sets the positioning property (0-100) of the specified device.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) PutDevicePositioning(deviceId int, positioning float32) (model.Result, error) {
 	payload := fmt.Sprintf(`{ "15015": [{ "5536": %f }] }`, positioning)
 	slog.Debug("Payload", slog.String("payload", payload))
-	resp, err := tc.Call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toDeviceUri(deviceId), payload))
+	if err != nil {
+		return model.Result{}, err
+	}
+	slog.Debug("Response", slog.Any("response", resp))
+	return model.Result{Msg: resp.Code.String()}, nil
+}
+
+// PutGroupPower switches all devices of the specified group on (1) or off (0).
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutGroupPower(groupId int, power int) (model.Result, error) {
+	if !(power == 1 || power == 0) {
+		return model.Result{}, fmt.Errorf("invalid value for setting power state, must be 1 or 0")
+	}
+	return tc.putGroup(groupId, fmt.Sprintf(`{ "5850": %d }`, power))
+}
+
+// PutGroupDimming sets the dimming property (0-254) of all devices of the specified group.
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutGroupDimming(groupId int, dimming int) (model.Result, error) {
+	return tc.putGroup(groupId, fmt.Sprintf(`{ "5851": %d }`, dimming))
+}
+
+// PutGroupScene activates a scene (called mood by the gateway) of the specified group, switching it on.
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) PutGroupScene(groupId int, sceneId int) (model.Result, error) {
+	return tc.putGroup(groupId, fmt.Sprintf(`{ "5850": 1, "9039": %d }`, sceneId))
+}
+
+func (tc *Client) putGroup(groupId int, payload string) (model.Result, error) {
+	slog.Debug("Payload", slog.String("payload", payload))
+	resp, err := tc.call(tc.dtlsclient.BuildPUTMessage(toGroupUri(groupId), payload))
 	if err != nil {
 		return model.Result{}, err
 	}
@@ -176,10 +304,11 @@ func (tc *Client) PutDevicePositioning(deviceId int, positioning float32) (model
 }
 
 // ListGroups lists all groups
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) ListGroups() ([]model.Group, error) {
 	groups := make([]model.Group, 0)
 
-	resp, err := tc.Call(tc.dtlsclient.BuildGETMessage("/15004"))
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage("/15004"))
 	if err != nil {
 		slog.Error("Unable to call Trådfri Gateway", slog.Any("error", err))
 		return groups, err
@@ -200,8 +329,9 @@ func (tc *Client) ListGroups() ([]model.Group, error) {
 }
 
 // GetGroup gets the JSON representation of the specified group.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) GetGroup(groupId int) (model.Group, error) {
-	resp, err := tc.Call(tc.dtlsclient.BuildGETMessage(toGroupUri(groupId)))
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage(toGroupUri(groupId)))
 	group := &model.Group{}
 	if err != nil {
 		return *group, err
@@ -214,11 +344,38 @@ func (tc *Client) GetGroup(groupId int) (model.Group, error) {
 	return *group, nil
 }
 
+// ListScenes gets the scenes of the specified group.
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) ListScenes(groupId int) ([]model.Scene, error) {
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage(toSceneUri(groupId)))
+	if err != nil {
+		return nil, err
+	}
+	sceneIds := make([]int, 0)
+	if err := json.Unmarshal(resp.Payload, &sceneIds); err != nil {
+		return nil, err
+	}
+	scenes := make([]model.Scene, 0, len(sceneIds))
+	for _, sceneId := range sceneIds {
+		resp, err := tc.call(tc.dtlsclient.BuildGETMessage(fmt.Sprintf("%s/%d", toSceneUri(groupId), sceneId)))
+		if err != nil {
+			return scenes, err
+		}
+		scene := model.Scene{}
+		if err := json.Unmarshal(resp.Payload, &scene); err != nil {
+			return scenes, err
+		}
+		scenes = append(scenes, scene)
+	}
+	return scenes, nil
+}
+
 // GetDevice gets the JSON representation of the specified device.
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) GetDevice(deviceId int) (model.Device, error) {
 	device := &model.Device{}
 
-	resp, err := tc.Call(tc.dtlsclient.BuildGETMessage(toDeviceUri(deviceId)))
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage(toDeviceUri(deviceId)))
 	if err != nil {
 		return *device, err
 	}
@@ -230,11 +387,24 @@ func (tc *Client) GetDevice(deviceId int) (model.Device, error) {
 	return *device, nil
 }
 
+// GetGatewayInfo gets the details of the gateway, like its firmware version.
+// Error responses of the gateway are returned as a GatewayError.
+func (tc *Client) GetGatewayInfo() (model.GatewayInfo, error) {
+	info := model.GatewayInfo{}
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage("/15011/15012"))
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(resp.Payload, &info)
+	return info, err
+}
+
 // ListDeviceIds gives you a list of all connected device id's
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) ListDeviceIds() ([]int, error) {
 	var devices []int
 
-	resp, err := tc.Call(tc.dtlsclient.BuildGETMessage("/15001/"))
+	resp, err := tc.call(tc.dtlsclient.BuildGETMessage("/15001/"))
 	if err != nil {
 		return devices, err
 	}
@@ -247,6 +417,7 @@ func (tc *Client) ListDeviceIds() ([]int, error) {
 }
 
 // ListDevices gives you a list of all devices
+// Error responses of the gateway are returned as a GatewayError.
 func (tc *Client) ListDevices() ([]model.Device, error) {
 	var devices []model.Device
 
@@ -285,32 +456,70 @@ func (tc *Client) Put(id string, payload string) (coap.Message, error) {
 	return tc.Call(tc.dtlsclient.BuildPUTMessage(id, payload))
 }
 
-// AuthExchange performs the initial PSK exchange.
+// ErrIdentityExists is returned by AuthExchange if the gateway already has a client with the requested ID,
+// which it answers with 4.00 Bad Request.
+var ErrIdentityExists = errors.New("the client ID is already registered on the gateway")
+
+// ErrNoToken is returned by AuthExchange if the gateway accepted the client ID without returning a PSK.
+var ErrNoToken = errors.New("the gateway returned no PSK")
+
+// AuthExchange performs the initial PSK exchange, registering clientId with the gateway. The client must
+// have been created with the "Client_identity" ID and the security code printed on the gateway as PSK.
 // see ref: https://community.openhab.org/t/ikea-tradfri-gateway/26135/148?u=kai
 func (tc *Client) AuthExchange(clientId string) (model.TokenExchange, error) {
-
-	req := tc.dtlsclient.BuildPOSTMessage("/15011/9063", fmt.Sprintf(`{"9090":"%s"}`, clientId))
+	payload, _ := json.Marshal(map[string]string{"9090": clientId})
+	req := tc.dtlsclient.BuildPOSTMessage("/15011/9063", string(payload))
 
 	// Send CoAP message for token exchange
-	resp, err := tc.Call(req)
+	resp, err := tc.call(req)
+	var gwErr GatewayError
+	if errors.As(err, &gwErr) && gwErr.Code == coap.BadRequest {
+		return model.TokenExchange{}, fmt.Errorf("token exchange for %q: %w: %w", clientId, ErrIdentityExists, err)
+	}
 	if err != nil {
-		slog.Error("error performing call to Gateway for token exchange", slog.Any("error", err))
-		os.Exit(1)
+		return model.TokenExchange{}, fmt.Errorf("token exchange for %q: %w", clientId, err)
 	}
 
 	// Handle response and return
 	token := model.TokenExchange{}
-	err = json.Unmarshal(resp.Payload, &token)
-	if err != nil {
-		slog.Error("error unmarshalling response from Gateway for token exchange", slog.Any("error", err))
-		os.Exit(1)
+	if err := json.Unmarshal(resp.Payload, &token); err != nil {
+		return model.TokenExchange{}, fmt.Errorf("token exchange for %q: invalid response: %w", clientId, err)
+	}
+	if token.Token == "" {
+		return model.TokenExchange{}, fmt.Errorf("token exchange for %q: %w", clientId, ErrNoToken)
 	}
 	return token, nil
 }
 
-// Call is just a proxy to the underlying DtlsClient Call
+// Call is just a proxy to the underlying DtlsClient Call, passing on the client's context
 func (tc *Client) Call(msg coap.Message) (coap.Message, error) {
-	return tc.dtlsclient.Call(msg)
+	ctx := tc.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return tc.dtlsclient.CallContext(ctx, msg)
+}
+
+// GatewayError is returned by the typed operations of Client if the gateway answers with an error code.
+type GatewayError struct {
+	Code coap.COAPCode
+	Path string
+}
+
+func (e GatewayError) Error() string {
+	return fmt.Sprintf("gateway responded %d.%02d %s to %s", e.Code>>5, e.Code&0x1f, e.Code, e.Path)
+}
+
+// call does the same as Call, but returns a GatewayError for error responses.
+func (tc *Client) call(msg coap.Message) (coap.Message, error) {
+	resp, err := tc.Call(msg)
+	if err != nil {
+		return resp, err
+	}
+	if resp.Code >= coap.BadRequest {
+		return resp, GatewayError{Code: resp.Code, Path: msg.PathString()}
+	}
+	return resp, nil
 }
 
 func mapRange(x, inMin, inMax, outMin, outMax float64) float64 {
@@ -379,3 +588,7 @@ func toDeviceUri(deviceId int) string {
 func toGroupUri(groupId int) string {
 	return fmt.Sprintf("/15004/%d", groupId)
 }
+
+func toSceneUri(groupId int) string {
+	return fmt.Sprintf("/15005/%d", groupId)
+}
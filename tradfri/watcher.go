@@ -0,0 +1,172 @@
+package tradfri
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/model"
+)
+
+// StateSource is the subset of Client used by a Watcher to read the gateway state.
+type StateSource interface {
+	ListDevices() ([]model.Device, error)
+	ListGroups() ([]model.Group, error)
+}
+
+// StateEvent is emitted by a Watcher when a device or a group has changed. Exactly one of Device and Group is set.
+type StateEvent struct {
+	Device *model.Device
+	Group  *model.Group
+}
+
+// Watcher polls the gateway for device and group state and notifies its subscribers about changes.
+// Polling only takes place while there is at least one subscriber.
+type Watcher struct {
+	source   StateSource
+	interval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[int]chan StateEvent
+	nextID      int
+	stopped     bool
+	devices     map[int]model.Device
+	groups      map[int]model.Group
+}
+
+// NewWatcher creates a Watcher polling the passed source at the given interval. Call Run to start it.
+func NewWatcher(source StateSource, interval time.Duration) *Watcher {
+	return &Watcher{
+		source:      source,
+		interval:    interval,
+		subscribers: make(map[int]chan StateEvent),
+		devices:     make(map[int]model.Device),
+		groups:      make(map[int]model.Group),
+	}
+}
+
+// Run polls the gateway until the passed context is cancelled. All subscriptions are closed when Run returns.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer w.closeSubscribers()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.subscriberCount() > 0 {
+				w.poll()
+			}
+		}
+	}
+}
+
+// Subscribe registers a new subscriber. The returned function must be called to unsubscribe.
+// Events are dropped for subscribers that do not keep up. Once the watcher has stopped, the returned
+// channel is closed.
+func (w *Watcher) Subscribe() (<-chan StateEvent, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ch := make(chan StateEvent, 64)
+	if w.stopped {
+		close(ch)
+		return ch, func() {}
+	}
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = ch
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.subscribers[id]; ok {
+			delete(w.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+// Snapshot returns the most recently polled state of all devices and groups.
+func (w *Watcher) Snapshot() ([]model.Device, []model.Group) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	devices := make([]model.Device, 0, len(w.devices))
+	for _, d := range w.devices {
+		devices = append(devices, d)
+	}
+	groups := make([]model.Group, 0, len(w.groups))
+	for _, g := range w.groups {
+		groups = append(groups, g)
+	}
+	return devices, groups
+}
+
+// Polling reports whether the watcher has subscribers and therefore keeps the Snapshot current.
+func (w *Watcher) Polling() bool {
+	return w.subscriberCount() > 0
+}
+
+func (w *Watcher) closeSubscribers() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	for id, ch := range w.subscribers {
+		delete(w.subscribers, id)
+		close(ch)
+	}
+}
+
+func (w *Watcher) subscriberCount() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.subscribers)
+}
+
+func (w *Watcher) poll() {
+	devices, err := w.source.ListDevices()
+	if err != nil {
+		slog.Warn("unable to poll devices", slog.Any("error", err))
+	}
+	groups, err := w.source.ListGroups()
+	if err != nil {
+		slog.Warn("unable to poll groups", slog.Any("error", err))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range devices {
+		d := devices[i]
+		old, ok := w.devices[d.DeviceId]
+		w.devices[d.DeviceId] = d
+		if !ok || !deviceStateEqual(old, d) {
+			w.publish(StateEvent{Device: &d})
+		}
+	}
+	for i := range groups {
+		g := groups[i]
+		if old, ok := w.groups[g.DeviceId]; ok && reflect.DeepEqual(old, g) {
+			continue
+		}
+		w.groups[g.DeviceId] = g
+		w.publish(StateEvent{Group: &g})
+	}
+}
+
+// publish must be called with w.mu held.
+func (w *Watcher) publish(event StateEvent) {
+	for id, ch := range w.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("dropping state event for slow subscriber", slog.Int("subscriber", id))
+		}
+	}
+}
+
+// deviceStateEqual compares two devices ignoring the last seen timestamp, which changes on every heartbeat.
+func deviceStateEqual(a, b model.Device) bool {
+	a.LastSeen, b.LastSeen = 0, 0
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,469 @@
+// Package tui implements an interactive terminal dashboard showing the live state of all groups and devices,
+// which can be switched, dimmed and positioned with the keyboard.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// Client defines the gateway operations used by the dashboard.
+type Client interface {
+	tradfri.StateSource
+	PutDevicePower(deviceId int, power int) (model.Result, error)
+	PutOutletPower(deviceId int, power int) (model.Result, error)
+	PutDeviceDimming(deviceId int, dimming int) (model.Result, error)
+	PutDevicePositioning(deviceId int, positioning float32) (model.Result, error)
+	PutGroupPower(groupId int, power int) (model.Result, error)
+	PutGroupDimming(groupId int, dimming int) (model.Result, error)
+}
+
+// step is the change in percent of the dim and position keys.
+const step = 10
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	groupStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	aliveStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	deadStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+const help = "↑/↓ select · space toggle · ←/→ dim or move ±10% · 1-9 set 10-90%, 0 set 100% · r refresh · q quit"
+
+// Run shows the dashboard until the user quits or ctx is cancelled. The state is polled every interval while
+// the dashboard is shown, and reloaded right after every change.
+func Run(ctx context.Context, c Client, interval time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watcher := tradfri.NewWatcher(c, interval)
+	events, unsubscribe := watcher.Subscribe()
+	defer unsubscribe()
+	go watcher.Run(ctx)
+
+	_, err := tea.NewProgram(New(c, events), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// Model is the bubbletea model of the dashboard.
+type Model struct {
+	client  Client
+	events  <-chan tradfri.StateEvent
+	devices map[int]model.Device
+	groups  map[int]model.Group
+	rows    []row
+	cursor  int
+	updated time.Time
+	status  string
+	err     error
+}
+
+// row is a line of the dashboard, either a group or a device listed below its group.
+type row struct {
+	group  *model.GroupV2
+	device *model.DeviceV2
+	// groupId is the group a device is listed below, 0 for devices not in any group.
+	groupId int
+}
+
+func (r row) key() string {
+	if r.group != nil {
+		return fmt.Sprintf("g%d", r.group.Id)
+	}
+	return fmt.Sprintf("d%d/%d", r.groupId, r.device.Id)
+}
+
+// stateMsg carries the complete state read from the gateway.
+type stateMsg struct {
+	devices []model.Device
+	groups  []model.Group
+	err     error
+}
+
+// eventMsg carries a change published by the watcher.
+type eventMsg struct {
+	event tradfri.StateEvent
+	ok    bool
+}
+
+// doneMsg reports the outcome of a change.
+type doneMsg struct {
+	desc string
+	err  error
+}
+
+// New returns a dashboard reading the state from c and applying the changes of events, which may be nil.
+func New(c Client, events <-chan tradfri.StateEvent) Model {
+	return Model{client: c, events: events, devices: map[int]model.Device{}, groups: map[int]model.Group{}}
+}
+
+// Init loads the state and starts listening for changes.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.load(), m.waitForEvent())
+}
+
+func (m Model) load() tea.Cmd {
+	return func() tea.Msg {
+		devices, err := m.client.ListDevices()
+		if err != nil {
+			return stateMsg{err: err}
+		}
+		groups, err := m.client.ListGroups()
+		return stateMsg{devices: devices, groups: groups, err: err}
+	}
+}
+
+func (m Model) waitForEvent() tea.Cmd {
+	if m.events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		event, ok := <-m.events
+		return eventMsg{event: event, ok: ok}
+	}
+}
+
+// Update handles keys and the results of the commands.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case stateMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.devices, m.groups = map[int]model.Device{}, map[int]model.Group{}
+		for _, d := range msg.devices {
+			m.devices[d.DeviceId] = d
+		}
+		for _, g := range msg.groups {
+			m.groups[g.DeviceId] = g
+		}
+		m.err, m.updated = nil, time.Now()
+		m.rebuild()
+	case eventMsg:
+		if !msg.ok {
+			return m, nil
+		}
+		if d := msg.event.Device; d != nil {
+			m.devices[d.DeviceId] = *d
+		}
+		if g := msg.event.Group; g != nil {
+			m.groups[g.DeviceId] = *g
+		}
+		m.updated = time.Now()
+		m.rebuild()
+		return m, m.waitForEvent()
+	case doneMsg:
+		m.status, m.err = msg.desc, msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		return m, m.load()
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case "r":
+		m.status = "Refreshing"
+		return m, m.load()
+	case " ", "enter":
+		return m, m.toggle()
+	case "left", "h", "-":
+		return m, m.adjust(-step)
+	case "right", "l", "+", "=":
+		return m, m.adjust(step)
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9", "0":
+		percent := int(key[0]-'0') * 10
+		if percent == 0 {
+			percent = 100
+		}
+		return m, m.set(percent)
+	}
+	return m, nil
+}
+
+func (m Model) selected() (row, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return row{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// change runs a change of the gateway state in the background.
+func (m Model) change(desc string, fn func() (model.Result, error)) tea.Cmd {
+	return func() tea.Msg {
+		_, err := fn()
+		return doneMsg{desc: desc, err: err}
+	}
+}
+
+func (m Model) toggle() tea.Cmd {
+	r, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	c := m.client
+	if g := r.group; g != nil {
+		power := onOff(!g.On)
+		return m.change(fmt.Sprintf("Switched %s %s", g.Name, powerName(power)), func() (model.Result, error) {
+			return c.PutGroupPower(g.Id, power)
+		})
+	}
+	d := r.device
+	switch {
+	case d.Light != nil:
+		power := onOff(!d.Light.On)
+		return m.change(fmt.Sprintf("Switched %s %s", d.Name, powerName(power)), func() (model.Result, error) {
+			return c.PutDevicePower(d.Id, power)
+		})
+	case d.Outlet != nil:
+		power := onOff(!d.Outlet.On)
+		return m.change(fmt.Sprintf("Switched %s %s", d.Name, powerName(power)), func() (model.Result, error) {
+			return c.PutOutletPower(d.Id, power)
+		})
+	case d.Blind != nil:
+		// toggling opens a blind that is mostly closed and closes one that is mostly open
+		position := float32(100)
+		if d.Blind.Position >= 50 {
+			position = 0
+		}
+		return m.position(d, position)
+	}
+	return m.unsupported(d)
+}
+
+// adjust changes the brightness or position of the selected row by delta percent.
+func (m Model) adjust(delta int) tea.Cmd {
+	r, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	switch {
+	case r.group != nil:
+		return m.set(r.group.Brightness + delta)
+	case r.device.Light != nil:
+		return m.set(r.device.Light.Brightness + delta)
+	case r.device.Blind != nil:
+		return m.set(int(r.device.Blind.Position) + delta)
+	}
+	return m.unsupported(r.device)
+}
+
+// set sets the brightness or position of the selected row to percent.
+func (m Model) set(percent int) tea.Cmd {
+	r, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	percent = min(max(percent, 0), 100)
+	c := m.client
+	if g := r.group; g != nil {
+		return m.change(fmt.Sprintf("Dimmed %s to %d%%", g.Name, percent), func() (model.Result, error) {
+			return c.PutGroupDimming(g.Id, model.PercentToDimmer(percent))
+		})
+	}
+	d := r.device
+	switch {
+	case d.Light != nil:
+		return m.change(fmt.Sprintf("Dimmed %s to %d%%", d.Name, percent), func() (model.Result, error) {
+			return c.PutDeviceDimming(d.Id, model.PercentToDimmer(percent))
+		})
+	case d.Blind != nil:
+		return m.position(d, float32(percent))
+	}
+	return m.unsupported(d)
+}
+
+func (m Model) position(d *model.DeviceV2, position float32) tea.Cmd {
+	c := m.client
+	return m.change(fmt.Sprintf("Moved %s to %g%%", d.Name, position), func() (model.Result, error) {
+		return c.PutDevicePositioning(d.Id, position)
+	})
+}
+
+func (m Model) unsupported(d *model.DeviceV2) tea.Cmd {
+	return func() tea.Msg {
+		return doneMsg{err: fmt.Errorf("%s is a %s, which cannot be changed here", d.Name, d.Kind)}
+	}
+}
+
+// rebuild lists every group followed by its devices, then the devices not in any group, keeping the
+// selected row.
+func (m *Model) rebuild() {
+	selected := ""
+	if r, ok := m.selected(); ok {
+		selected = r.key()
+	}
+
+	groups := make([]model.GroupV2, 0, len(m.groups))
+	for _, g := range m.groups {
+		groups = append(groups, model.ToGroupV2(g))
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Id < groups[j].Id })
+
+	grouped := map[int]bool{}
+	rows := make([]row, 0, len(m.groups)+len(m.devices))
+	for i := range groups {
+		g := &groups[i]
+		rows = append(rows, row{group: g})
+		for _, id := range g.DeviceIds {
+			if d, ok := m.devices[id]; ok {
+				dv := model.ToDeviceV2(d)
+				rows = append(rows, row{device: &dv, groupId: g.Id})
+				grouped[id] = true
+			}
+		}
+	}
+	ids := make([]int, 0, len(m.devices))
+	for id := range m.devices {
+		if !grouped[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		dv := model.ToDeviceV2(m.devices[id])
+		rows = append(rows, row{device: &dv})
+	}
+
+	m.rows, m.cursor = rows, min(m.cursor, max(len(rows)-1, 0))
+	for i, r := range rows {
+		if r.key() == selected {
+			m.cursor = i
+		}
+	}
+}
+
+// View renders the dashboard.
+func (m Model) View() string {
+	b := strings.Builder{}
+	title := fmt.Sprintf("tradfri-go · %d groups · %d devices", len(m.groups), len(m.devices))
+	if !m.updated.IsZero() {
+		title += " · updated " + m.updated.Format(time.TimeOnly)
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+
+	ungrouped := false
+	for i, r := range m.rows {
+		if r.group == nil && r.groupId == 0 && !ungrouped {
+			ungrouped = true
+			b.WriteString(groupStyle.Render("Not in a group") + "\n")
+		}
+		line := r.render()
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.rows) == 0 && m.err == nil {
+		b.WriteString(dimStyle.Render("Loading...") + "\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.err != nil:
+		b.WriteString(errorStyle.Render("Error: "+m.err.Error()) + "\n")
+	case m.status != "":
+		b.WriteString(m.status + "\n")
+	default:
+		b.WriteString("\n")
+	}
+	b.WriteString(dimStyle.Render(help) + "\n")
+	return b.String()
+}
+
+func (r row) render() string {
+	if g := r.group; g != nil {
+		state := "off"
+		if g.On {
+			state = "on"
+		}
+		return groupStyle.Render(fmt.Sprintf("%-28s %-7s %-4s %s", truncate(g.Name, 28), "group", state, bar(g.Brightness)))
+	}
+	d := r.device
+	state, level, swatch := "", "", "  "
+	switch {
+	case d.Light != nil:
+		state, level = "off", bar(d.Light.Brightness)
+		if d.Light.On {
+			state = "on"
+		}
+		if d.Light.Color.Hex != "" {
+			swatch = lipgloss.NewStyle().Foreground(lipgloss.Color("#" + d.Light.Color.Hex)).Render("██")
+		}
+	case d.Outlet != nil:
+		state = "off"
+		if d.Outlet.On {
+			state = "on"
+		}
+		level = strings.Repeat(" ", 15)
+	case d.Blind != nil:
+		state, level = "", bar(int(d.Blind.Position))
+	default:
+		level = strings.Repeat(" ", 15)
+	}
+	battery := ""
+	if d.Metadata.BatteryLevel != nil {
+		battery = fmt.Sprintf("battery %d%%", *d.Metadata.BatteryLevel)
+	}
+	alive := aliveStyle.Render("●")
+	if !d.Metadata.Alive {
+		alive = deadStyle.Render("✕")
+	}
+	return fmt.Sprintf("  %-26s %-7s %-4s %s %s %-12s %s", truncate(d.Name, 26), d.Kind, state, level, swatch, battery, alive)
+}
+
+// bar draws a percentage as a 10 character bar followed by the value.
+func bar(percent int) string {
+	filled := min(max(percent, 0), 100) / 10
+	return strings.Repeat("█", filled) + dimStyle.Render(strings.Repeat("░", 10-filled)) + fmt.Sprintf(" %3d%%", percent)
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+func onOff(on bool) int {
+	if on {
+		return 1
+	}
+	return 0
+}
+
+func powerName(power int) string {
+	if power == 1 {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eriklupander/tradfri-go/model"
+	"github.com/eriklupander/tradfri-go/tradfri"
+)
+
+// mockClient implements Client, recording the changes.
+type mockClient struct {
+	devices []model.Device
+	groups  []model.Group
+	calls   []string
+}
+
+func (m *mockClient) ListDevices() ([]model.Device, error) { return m.devices, nil }
+func (m *mockClient) ListGroups() ([]model.Group, error)   { return m.groups, nil }
+
+func (m *mockClient) record(format string, args ...any) (model.Result, error) {
+	m.calls = append(m.calls, fmt.Sprintf(format, args...))
+	return model.Result{Msg: "Changed"}, nil
+}
+
+func (m *mockClient) PutDevicePower(id int, power int) (model.Result, error) {
+	return m.record("power %d %d", id, power)
+}
+func (m *mockClient) PutOutletPower(id int, power int) (model.Result, error) {
+	return m.record("outlet %d %d", id, power)
+}
+func (m *mockClient) PutDeviceDimming(id int, dimming int) (model.Result, error) {
+	return m.record("dimming %d %d", id, dimming)
+}
+func (m *mockClient) PutDevicePositioning(id int, position float32) (model.Result, error) {
+	return m.record("position %d %g", id, position)
+}
+func (m *mockClient) PutGroupPower(id int, power int) (model.Result, error) {
+	return m.record("group power %d %d", id, power)
+}
+func (m *mockClient) PutGroupDimming(id int, dimming int) (model.Result, error) {
+	return m.record("group dimming %d %d", id, dimming)
+}
+
+func device(t *testing.T, payload string) model.Device {
+	d := model.Device{}
+	if err := json.Unmarshal([]byte(payload), &d); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func newTestModel(t *testing.T) (*mockClient, Model) {
+	mc := &mockClient{
+		devices: []model.Device{
+			device(t, `{"9003":65537,"9001":"Bulb","9019":1,"3311":[{"5850":1,"5851":127,"5706":"f1e0b5"}]}`),
+			device(t, `{"9003":65538,"9001":"Blind","9019":1,"3":{"6":3,"9":80},"15015":[{"5536":75}]}`),
+			device(t, `{"9003":65539,"9001":"Outlet","9019":0,"3312":[{"5850":0}]}`),
+		},
+		groups: []model.Group{{DeviceId: 131073, Name: "Living room", Power: 1, Dimmer: 254}},
+	}
+	mc.groups[0].Content.DeviceList.DeviceIds = []int{65537, 65538}
+	m := New(mc, nil)
+	next, _ := m.Update(m.load()())
+	return mc, next.(Model)
+}
+
+func press(m Model, keys ...string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+		}
+		next, c := m.Update(msg)
+		m, cmd = next.(Model), c
+	}
+	return m, cmd
+}
+
+func TestView(t *testing.T) {
+	_, m := newTestModel(t)
+	view := m.View()
+	for _, s := range []string{"1 groups · 3 devices", "Living room", "Bulb", "50%", "Blind", "75%", "battery 80%", "Not in a group", "Outlet", "✕"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected %q in view:\n%s", s, view)
+		}
+	}
+	if len(m.rows) != 4 || m.rows[0].group == nil || m.rows[3].device.Id != 65539 {
+		t.Fatalf("unexpected rows %+v", m.rows)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		keys []string
+		call string
+	}{
+		{[]string{" "}, "group power 131073 0"},
+		{[]string{"-"}, "group dimming 131073 229"},
+		{[]string{"down", " "}, "power 65537 0"},
+		{[]string{"down", "+"}, "dimming 65537 152"},
+		{[]string{"down", "0"}, "dimming 65537 254"},
+		{[]string{"down", "down", " "}, "position 65538 0"},
+		{[]string{"down", "down", "3"}, "position 65538 30"},
+		{[]string{"down", "down", "down", " "}, "outlet 65539 1"},
+	}
+	for _, tt := range tests {
+		mc, m := newTestModel(t)
+		m, cmd := press(m, tt.keys...)
+		if cmd == nil {
+			t.Fatalf("%v: expected a command", tt.keys)
+		}
+		next, reload := m.Update(cmd())
+		m = next.(Model)
+		if len(mc.calls) != 1 || mc.calls[0] != tt.call || m.err != nil || reload == nil {
+			t.Errorf("%v: expected %q, got %v, err %v", tt.keys, tt.call, mc.calls, m.err)
+		}
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	mc, m := newTestModel(t)
+	m, cmd := press(m, "down", "down", "down", "+")
+	next, _ := m.Update(cmd())
+	if m = next.(Model); m.err == nil || len(mc.calls) != 0 {
+		t.Fatalf("expected an error dimming an outlet, got calls %v", mc.calls)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	_, m := newTestModel(t)
+	events := make(chan tradfri.StateEvent, 1)
+	m.events = events
+	m, _ = press(m, "down", "down")
+
+	changed := device(t, `{"9003":65538,"9001":"Blind","9019":1,"15015":[{"5536":20}]}`)
+	events <- tradfri.StateEvent{Device: &changed}
+	next, cmd := m.Update(m.waitForEvent()())
+	m = next.(Model)
+	if cmd == nil || !strings.Contains(m.View(), " 20%") {
+		t.Fatalf("expected the blind at 20%%:\n%s", m.View())
+	}
+	if r, _ := m.selected(); r.device == nil || r.device.Id != 65538 {
+		t.Fatalf("expected the blind to stay selected, got %+v", r)
+	}
+
+	close(events)
+	if _, cmd := m.Update(m.waitForEvent()()); cmd != nil {
+		t.Fatal("expected no more commands once the watcher stopped")
+	}
+}
@@ -0,0 +1,82 @@
+// Package validation checks requests against the constraints in their validate struct tags, so that the REST,
+// WebSocket and gRPC APIs accept and reject exactly the same input.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/go-playground/validator/v10"
+)
+
+// RGBPattern is the pattern of the rgbhex constraint, a color as 6 hex digits.
+var RGBPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// report fields by the names clients use
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+		return name
+	})
+	_ = v.RegisterValidation("rgbhex", func(fl validator.FieldLevel) bool {
+		return RGBPattern.MatchString(fl.Field().String())
+	})
+	return v
+}
+
+// Error lists the fields rejected by Check.
+type Error struct {
+	Fields []problem.FieldError
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+" "+f.Message)
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Check validates v, a struct or a pointer to one, returning an *Error listing every rejected field.
+func Check(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	fields := make([]problem.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		// the namespace starts with the name of the validated struct
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		fields = append(fields, problem.FieldError{Field: field, Message: fieldMessage(fe)})
+	}
+	return &Error{Fields: fields}
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "min":
+		return "must be at least " + fe.Param() + ", got " + fmt.Sprint(fe.Value())
+	case "max":
+		return "must be at most " + fe.Param() + ", got " + fmt.Sprint(fe.Value())
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ") + ", got " + fmt.Sprint(fe.Value())
+	case "rgbhex":
+		return fmt.Sprintf("must be a 6 digit hex string, got %q", fe.Value())
+	case "required":
+		return "is required"
+	}
+	return "failed the " + fe.Tag() + " constraint"
+}
@@ -0,0 +1,361 @@
+// The tradfri-go web UI. It uses the REST API (/api/v2) for the rooms and devices and, while the server is in
+// setup mode, the onboarding endpoints below /ui/setup.
+"use strict";
+
+const keyStorage = "tradfri-go.api-key";
+const refreshInterval = 15000;
+
+const $ = (id) => document.getElementById(id);
+
+const state = {
+  gateway: "",
+  refreshTimer: null,
+};
+
+class APIError extends Error {
+  constructor(status, message) {
+    super(message);
+    this.status = status;
+  }
+}
+
+// request calls the server and returns the parsed JSON response. Problem details are thrown as APIError.
+async function request(method, path, body) {
+  const headers = {};
+  const key = localStorage.getItem(keyStorage);
+  if (key) {
+    headers["Authorization"] = "Bearer " + key;
+  }
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+  const res = await fetch(path, {method, headers, body: body === undefined ? undefined : JSON.stringify(body)});
+  const text = await res.text();
+  const data = text ? JSON.parse(text) : null;
+  if (!res.ok) {
+    let message = (data && (data.detail || data.title)) || res.statusText;
+    if (data && data.errors) {
+      message += ": " + data.errors.map((e) => e.field + " " + e.message).join(", ");
+    }
+    throw new APIError(res.status, message);
+  }
+  return data;
+}
+
+// api returns the path of an endpoint of the selected gateway.
+function api(path) {
+  return state.gateway ? "/api/gw/" + encodeURIComponent(state.gateway) + path : "/api" + path;
+}
+
+function show(section) {
+  for (const id of ["setup", "login", "rooms"]) {
+    $(id).hidden = id !== section;
+  }
+}
+
+function notify(text, isError) {
+  const msg = $("message");
+  msg.textContent = text;
+  msg.className = isError ? "error" : "";
+  msg.hidden = !text;
+}
+
+// handle reports errors, asking for an API key if the server requires one.
+function handle(err) {
+  if (err instanceof APIError && err.status === 401) {
+    stopRefresh();
+    notify(localStorage.getItem(keyStorage) ? "The API key was not accepted." : "", true);
+    show("login");
+    return;
+  }
+  notify(err.message, true);
+}
+
+// Setup
+
+function initSetup() {
+  show("setup");
+  notify("");
+  $("discover").onclick = async () => {
+    const button = $("discover");
+    button.disabled = true;
+    button.textContent = "Searching…";
+    const found = $("found");
+    found.replaceChildren();
+    try {
+      const gateways = await request("GET", "/ui/setup/gateways");
+      if (gateways.length === 0) {
+        notify("No gateway answered. Check that it is powered and connected, or enter its address.", true);
+      } else {
+        notify("");
+      }
+      for (const g of gateways) {
+        const li = document.createElement("li");
+        const choice = document.createElement("button");
+        choice.textContent = g.id + " (" + g.ip + ")";
+        choice.onclick = () => { $("address").value = g.ip + ":" + g.port; };
+        li.append(choice);
+        found.append(li);
+      }
+      if (gateways.length === 1) {
+        $("address").value = gateways[0].ip + ":" + gateways[0].port;
+      }
+    } catch (err) {
+      handle(err);
+    } finally {
+      button.disabled = false;
+      button.textContent = "Search the network";
+    }
+  };
+  $("register").onclick = async () => {
+    const button = $("register");
+    button.disabled = true;
+    notify("Connecting to the gateway, this can take up to a minute…");
+    try {
+      const res = await request("POST", "/ui/setup/register", {
+        gatewayAddress: $("address").value.trim(),
+        securityCode: $("security-code").value.trim(),
+        clientId: $("client-id").value.trim(),
+      });
+      notify("Connected as " + res.clientId + ". Starting…");
+      await waitForServer();
+      start();
+    } catch (err) {
+      handle(err);
+    } finally {
+      button.disabled = false;
+    }
+  };
+}
+
+// waitForServer waits until the server restarted with the new gateway.
+async function waitForServer() {
+  for (;;) {
+    await new Promise((resolve) => setTimeout(resolve, 2000));
+    try {
+      const status = await request("GET", "/ui/setup");
+      if (!status.setupRequired) {
+        return;
+      }
+    } catch (err) {
+      // the server is restarting
+    }
+  }
+}
+
+// Rooms
+
+async function loadGateways() {
+  const gateways = await request("GET", "/api/gateways");
+  const select = $("gateway");
+  select.replaceChildren();
+  for (const g of gateways) {
+    select.append(new Option(g.name, g.name, g.default, g.default));
+  }
+  select.hidden = gateways.length < 2;
+  select.onchange = () => {
+    state.gateway = select.value;
+    refresh();
+  };
+}
+
+async function refresh() {
+  // don't move sliders under the user's fingers
+  if (document.hidden || $("rooms").contains(document.activeElement) && document.activeElement.type === "range") {
+    return;
+  }
+  try {
+    const [groups, devices] = await Promise.all([request("GET", api("/v2/groups")), request("GET", api("/v2/devices"))]);
+    renderRooms(groups, devices);
+    notify("");
+    show("rooms");
+  } catch (err) {
+    handle(err);
+  }
+}
+
+function renderRooms(groups, devices) {
+  const byId = new Map(devices.map((d) => [d.id, d]));
+  const rooms = $("rooms");
+  rooms.replaceChildren();
+  groups.sort((a, b) => a.name.localeCompare(b.name));
+  for (const g of groups) {
+    const room = $("room-template").content.firstElementChild.cloneNode(true);
+    room.querySelector(".name").textContent = g.name;
+    const power = room.querySelector(".power");
+    power.checked = g.on;
+    power.onchange = () => patchGroup(g.id, {on: power.checked});
+    const brightness = room.querySelector(".brightness");
+    brightness.value = g.brightness;
+    brightness.onchange = () => patchGroup(g.id, {brightness: Number(brightness.value)});
+    loadScenes(room, g);
+    const list = room.querySelector(".devices");
+    for (const id of g.deviceIds) {
+      const d = byId.get(id);
+      if (d) {
+        list.append(renderDevice(d));
+      }
+    }
+    rooms.append(room);
+  }
+  if (groups.length === 0) {
+    const empty = document.createElement("p");
+    empty.className = "muted";
+    empty.textContent = "The gateway has no rooms yet, add them in the IKEA app.";
+    rooms.append(empty);
+  }
+}
+
+async function loadScenes(room, group) {
+  try {
+    const scenes = await request("GET", api("/v2/groups/" + group.id + "/scenes"));
+    if (scenes.length === 0) {
+      return;
+    }
+    const select = room.querySelector(".scene");
+    select.append(new Option("—", ""));
+    for (const s of scenes) {
+      select.append(new Option(s.name, s.id, false, s.id === group.sceneId));
+    }
+    select.onchange = () => {
+      if (select.value) {
+        patchGroup(group.id, {sceneId: Number(select.value)});
+      }
+    };
+    room.querySelector(".scenes").hidden = false;
+  } catch (err) {
+    // scenes are optional, the room works without them
+  }
+}
+
+function renderDevice(d) {
+  const li = document.createElement("li");
+  const name = document.createElement("span");
+  name.className = "name";
+  name.textContent = d.name;
+  li.append(name);
+  if (!d.metadata.alive) {
+    li.append(muted("unreachable"));
+  }
+  if (d.light) {
+    li.append(checkbox(d.light.on, (on) => patchDevice(d.id, {light: {on}})));
+    li.append(range(d.light.brightness, (brightness) => patchDevice(d.id, {light: {brightness}})));
+    if (d.light.color && d.light.color.hex) {
+      const color = document.createElement("input");
+      color.type = "color";
+      color.value = "#" + d.light.color.hex;
+      color.title = "Color";
+      color.onchange = () => patchDevice(d.id, {light: {color: {hex: color.value.slice(1)}}});
+      li.append(color);
+    }
+  } else if (d.outlet) {
+    li.append(checkbox(d.outlet.on, (on) => patchDevice(d.id, {outlet: {on}})));
+  } else if (d.blind) {
+    const label = muted("position");
+    li.append(label, range(Math.round(d.blind.position), (position) => patchDevice(d.id, {blind: {position}})));
+  }
+  if (d.metadata.batteryLevel !== undefined) {
+    li.append(muted("battery " + d.metadata.batteryLevel + "%"));
+  }
+  return li;
+}
+
+function muted(text) {
+  const span = document.createElement("span");
+  span.className = "muted";
+  span.textContent = text;
+  return span;
+}
+
+function checkbox(checked, onChange) {
+  const input = document.createElement("input");
+  input.type = "checkbox";
+  input.checked = checked;
+  input.title = "On";
+  input.onchange = () => onChange(input.checked);
+  return input;
+}
+
+function range(value, onChange) {
+  const input = document.createElement("input");
+  input.type = "range";
+  input.min = 0;
+  input.max = 100;
+  input.value = value;
+  input.onchange = () => onChange(Number(input.value));
+  return input;
+}
+
+async function patchGroup(id, patch) {
+  try {
+    await request("PATCH", api("/v2/groups/" + id), patch);
+    setTimeout(refresh, 500);
+  } catch (err) {
+    handle(err);
+  }
+}
+
+async function patchDevice(id, patch) {
+  try {
+    await request("PATCH", api("/v2/devices/" + id), patch);
+    setTimeout(refresh, 500);
+  } catch (err) {
+    handle(err);
+  }
+}
+
+function stopRefresh() {
+  clearInterval(state.refreshTimer);
+  state.refreshTimer = null;
+}
+
+async function startRooms() {
+  try {
+    await loadGateways();
+  } catch (err) {
+    handle(err);
+    return;
+  }
+  await refresh();
+  stopRefresh();
+  state.refreshTimer = setInterval(refresh, refreshInterval);
+}
+
+// Start
+
+async function start() {
+  $("forget-key").hidden = !localStorage.getItem(keyStorage);
+  try {
+    const status = await request("GET", "/ui/setup");
+    if (status.setupRequired) {
+      initSetup();
+      return;
+    }
+  } catch (err) {
+    handle(err);
+    return;
+  }
+  startRooms();
+}
+
+$("login-form").onsubmit = (e) => {
+  e.preventDefault();
+  localStorage.setItem(keyStorage, $("api-key").value.trim());
+  $("api-key").value = "";
+  notify("");
+  start();
+};
+
+$("forget-key").onclick = () => {
+  localStorage.removeItem(keyStorage);
+  stopRefresh();
+  start();
+};
+
+document.addEventListener("visibilitychange", () => {
+  if (!document.hidden && state.refreshTimer) {
+    refresh();
+  }
+});
+
+start();
@@ -0,0 +1,151 @@
+// Package webui serves the web UI embedded in the binary. It controls the groups and devices through the REST
+// API and, while no gateway is configured, walks through discovering the gateway and registering a client
+// with the security code printed on it.
+package webui
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/onboarding"
+	"github.com/eriklupander/tradfri-go/problem"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+//go:embed static
+var static embed.FS
+
+// files are the UI files, served below /ui/.
+var files, _ = fs.Sub(static, "static")
+
+// gatewayPort is the CoAPS port of the gateways, added to addresses entered without one.
+const gatewayPort = "5684"
+
+// Status tells the UI whether the onboarding is required, served at /ui/setup.
+type Status struct {
+	SetupRequired bool `json:"setupRequired"`
+}
+
+// RegisterRequest starts the onboarding, posted to /ui/setup/register.
+type RegisterRequest struct {
+	GatewayAddress string `json:"gatewayAddress"`
+	SecurityCode   string `json:"securityCode"`
+	ClientID       string `json:"clientId"`
+}
+
+// RegisterResponse reports the registered client.
+type RegisterResponse struct {
+	ClientID        string `json:"clientId"`
+	FirmwareVersion string `json:"firmwareVersion"`
+}
+
+// Handler serves the UI of a configured server, to be mounted at /ui and /ui/* of the REST router.
+func Handler() http.Handler {
+	r := chi.NewRouter()
+	routes(r, Status{})
+	return r
+}
+
+// SetupOptions configures the onboarding served by SetupHandler.
+type SetupOptions struct {
+	// DiscoveryTimeout is how long the gateways are looked for, defaults to discovery.DefaultTimeout.
+	DiscoveryTimeout time.Duration
+	// Registered is called with the credentials of the registered client, typically to store them and to
+	// leave the setup mode. An error fails the registration.
+	Registered func(onboarding.Credentials) error
+}
+
+// discover and register find gateways and register clients, replaced in tests.
+var (
+	discover = discovery.Discover
+	register = onboarding.Run
+)
+
+// SetupHandler serves the UI of a server without gateway credentials, which offers only the onboarding. The
+// root path redirects to the UI.
+func SetupHandler(opts SetupOptions) http.Handler {
+	// registering is one exchange at a time and only once
+	var mu sync.Mutex
+	registered := false
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	routes(r, Status{SetupRequired: true})
+	r.Get("/", http.RedirectHandler("/ui/", http.StatusFound).ServeHTTP)
+	r.Get("/ui/setup/gateways", func(w http.ResponseWriter, r *http.Request) {
+		gateways, err := discover(r.Context(), discovery.Options{Timeout: opts.DiscoveryTimeout})
+		if err != nil {
+			problem.Write(w, r, problem.New(problem.Internal, err.Error()))
+			return
+		}
+		if gateways == nil {
+			gateways = []discovery.Gateway{}
+		}
+		writeJSON(w, gateways)
+	})
+	r.Post("/ui/setup/register", func(w http.ResponseWriter, r *http.Request) {
+		req := RegisterRequest{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			problem.Write(w, r, problem.New(problem.InvalidRequest, err.Error()))
+			return
+		}
+		if errs := validate(req); len(errs) > 0 {
+			problem.Write(w, r, problem.Validation("the registration is incomplete", errs))
+			return
+		}
+		if _, _, err := net.SplitHostPort(req.GatewayAddress); err != nil {
+			req.GatewayAddress = net.JoinHostPort(req.GatewayAddress, gatewayPort)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if registered {
+			problem.Write(w, r, problem.New(problem.Conflict, "a client is already registered, the server is restarting"))
+			return
+		}
+		// the exchange must not be abandoned halfway by a closed browser tab
+		creds, err := register(context.WithoutCancel(r.Context()), onboarding.Options{GatewayAddress: req.GatewayAddress, ClientID: req.ClientID, SecurityCode: req.SecurityCode})
+		if err == nil && opts.Registered != nil {
+			err = opts.Registered(creds)
+		}
+		if err != nil {
+			problem.Write(w, r, problem.FromError(err))
+			return
+		}
+		registered = true
+		writeJSON(w, RegisterResponse{ClientID: creds.ClientID, FirmwareVersion: creds.FirmwareVersion})
+	})
+	return r
+}
+
+func routes(r chi.Router, status Status) {
+	r.Get("/ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently).ServeHTTP)
+	r.Get("/ui/setup", func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, status) })
+	r.Handle("/ui/*", http.StripPrefix("/ui/", http.FileServerFS(files)))
+}
+
+func validate(req RegisterRequest) []problem.FieldError {
+	var errs []problem.FieldError
+	if req.GatewayAddress == "" {
+		errs = append(errs, problem.FieldError{Field: "gatewayAddress", Message: "is required"})
+	}
+	if len(req.SecurityCode) < 10 {
+		errs = append(errs, problem.FieldError{Field: "securityCode", Message: "must be the code printed on the gateway"})
+	}
+	if req.ClientID == "" {
+		errs = append(errs, problem.FieldError{Field: "clientId", Message: "is required"})
+	}
+	return errs
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,88 @@
+package webui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eriklupander/tradfri-go/discovery"
+	"github.com/eriklupander/tradfri-go/onboarding"
+)
+
+func serve(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler()
+	if rec := serve(h, http.MethodGet, "/ui/", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "app.js") {
+		t.Fatalf("expected the index page, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(h, http.MethodGet, "/ui/app.js", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Type"), "javascript") {
+		t.Fatalf("expected the script, got %d %v", rec.Code, rec.Header())
+	}
+	if rec := serve(h, http.MethodGet, "/ui/setup", ""); rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"setupRequired":false}` {
+		t.Fatalf("expected no setup, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(h, http.MethodPost, "/ui/setup/register", "{}"); rec.Code != http.StatusMethodNotAllowed && rec.Code != http.StatusNotFound {
+		t.Fatalf("expected no registration once configured, got %d", rec.Code)
+	}
+}
+
+func TestSetupHandler(t *testing.T) {
+	d, reg := discover, register
+	t.Cleanup(func() { discover, register = d, reg })
+	discover = func(context.Context, discovery.Options) ([]discovery.Gateway, error) {
+		return []discovery.Gateway{{ID: "gw-b072bf257a41", IP: net.IPv4(192, 168, 1, 19), Port: 5684}}, nil
+	}
+	var got onboarding.Options
+	register = func(_ context.Context, opts onboarding.Options) (onboarding.Credentials, error) {
+		got = opts
+		if opts.SecurityCode != "the-security-code" {
+			return onboarding.Credentials{}, errors.New("handshake failed")
+		}
+		return onboarding.Credentials{GatewayAddress: opts.GatewayAddress, ClientID: opts.ClientID, PSK: "psk", FirmwareVersion: "1.19.26"}, nil
+	}
+	var stored onboarding.Credentials
+	h := SetupHandler(SetupOptions{Registered: func(creds onboarding.Credentials) error {
+		stored = creds
+		return nil
+	}})
+
+	if rec := serve(h, http.MethodGet, "/ui/setup", ""); strings.TrimSpace(rec.Body.String()) != `{"setupRequired":true}` {
+		t.Fatalf("expected the setup to be required, got %s", rec.Body.String())
+	}
+	if rec := serve(h, http.MethodGet, "/", ""); rec.Code != http.StatusFound {
+		t.Fatalf("expected a redirect to the UI, got %d", rec.Code)
+	}
+	rec := serve(h, http.MethodGet, "/ui/setup/gateways", "")
+	var gateways []discovery.Gateway
+	_ = json.Unmarshal(rec.Body.Bytes(), &gateways)
+	if rec.Code != http.StatusOK || len(gateways) != 1 || gateways[0].Address() != "192.168.1.19:5684" {
+		t.Fatalf("unexpected gateways %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serve(h, http.MethodPost, "/ui/setup/register", `{"gatewayAddress": "192.168.1.19"}`); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "securityCode") {
+		t.Fatalf("expected a validation error, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(h, http.MethodPost, "/ui/setup/register", `{"gatewayAddress": "192.168.1.19", "securityCode": "wrong-security-code", "clientId": "home"}`); rec.Code != http.StatusInternalServerError || stored.PSK != "" {
+		t.Fatalf("expected the registration to fail, got %d %s", rec.Code, rec.Body.String())
+	}
+	rec = serve(h, http.MethodPost, "/ui/setup/register", `{"gatewayAddress": "192.168.1.19", "securityCode": "the-security-code", "clientId": "home"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"clientId":"home"`) {
+		t.Fatalf("expected the registration to succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	if got.GatewayAddress != "192.168.1.19:5684" || stored.PSK != "psk" {
+		t.Fatalf("expected the credentials to be stored, got %+v from %+v", stored, got)
+	}
+	if rec := serve(h, http.MethodPost, "/ui/setup/register", `{"gatewayAddress": "192.168.1.19", "securityCode": "the-security-code", "clientId": "home"}`); rec.Code != http.StatusConflict {
+		t.Fatalf("expected a second registration to be rejected, got %d", rec.Code)
+	}
+}